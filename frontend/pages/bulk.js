@@ -27,7 +27,11 @@ export function renderBulkPage() {
           </select>
           <div class="category-hint">💡 Выберите категорию для фильтрации клиентов по их покупкам</div>
         </label>
-        <label>Сообщение <textarea name="message" required placeholder="Введите текст сообщения..."></textarea></label>
+        <label>Сообщение <textarea name="message" required placeholder="Введите текст сообщения..."></textarea>
+          <div class="category-hint">💡 Используйте {{имя_колонки}} для подстановки значений из файла, например {{name}} или {{номер_заказа}}</div>
+        </label>
+        <label>Вариант B сообщения (A/B тест) <textarea name="variant_b_message" placeholder="Оставьте пустым, чтобы отправить всем одно сообщение"></textarea></label>
+        <label>Доля варианта B, % <input type="number" name="variant_b_percent" min="1" max="99" value="50"></label>
         <label class="file-label">
           Медиа файл
           <span class="file-input-wrapper">
@@ -37,6 +41,18 @@ export function renderBulkPage() {
             <span class="file-name" id="file-name-media">Файл не выбран</span>
           </span>
         </label>
+        <label>Запланировать запуск <input type="datetime-local" name="scheduled_at" id="scheduled-at-input"></label>
+        <label>
+          Окно отправки
+          <span class="send-window-inputs">
+            с <input type="time" name="send_window_start"> до <input type="time" name="send_window_end">
+          </span>
+          <div class="category-hint">💡 Вне окна рассылка приостанавливается и продолжается при его открытии</div>
+        </label>
+        <label>
+          <input type="checkbox" name="send_window_weekdays_only">
+          <span>Отправлять только в будни</span>
+        </label>
         <label>
           <input type="checkbox" name="auto_start_after_filter" id="auto-start-checkbox">
           <span>Автоматически запустить после фильтрации</span>
@@ -251,6 +267,47 @@ export function initBulkForm(showToast) {
       fd.append('auto_start_after_filter', 'on');
     }
     
+    // Добавляем варианты сообщения для A/B теста (основной текст — вариант A)
+    const variantBMessage = form.variant_b_message.value.trim();
+    if (variantBMessage) {
+      const percentB = parseInt(form.variant_b_percent.value, 10);
+      if (!(percentB >= 1 && percentB <= 99)) {
+        showToast('Доля варианта B должна быть от 1 до 99%', 'danger');
+        setLoading(false, form.querySelector('button[type="submit"]'));
+        return;
+      }
+      fd.append('variants', JSON.stringify([
+        { name: 'A', message: '', percent: 100 - percentB },
+        { name: 'B', message: variantBMessage, percent: percentB },
+      ]));
+    }
+    
+    // Добавляем время запланированного запуска (в RFC3339 с учетом часового пояса браузера)
+    if (form.scheduled_at && form.scheduled_at.value) {
+      const scheduledAt = new Date(form.scheduled_at.value);
+      if (scheduledAt <= new Date()) {
+        showToast('Время запуска должно быть в будущем', 'danger');
+        setLoading(false, form.querySelector('button[type="submit"]'));
+        return;
+      }
+      fd.append('scheduled_at', scheduledAt.toISOString());
+    }
+    
+    // Добавляем окно отправки (в часовом поясе браузера)
+    if (form.send_window_start.value || form.send_window_end.value) {
+      if (!form.send_window_start.value || !form.send_window_end.value) {
+        showToast('Укажите начало и конец окна отправки', 'danger');
+        setLoading(false, form.querySelector('button[type="submit"]'));
+        return;
+      }
+      fd.append('send_window_start', form.send_window_start.value);
+      fd.append('send_window_end', form.send_window_end.value);
+      fd.append('send_window_timezone', Intl.DateTimeFormat().resolvedOptions().timeZone || 'UTC');
+      if (form.send_window_weekdays_only.checked) {
+        fd.append('send_window_weekdays', '1,2,3,4,5');
+      }
+    }
+    
     // Добавляем дополнительные и исключаемые номера
     const additionalTextarea = document.querySelector('textarea[name="additional_numbers"]');
     const excludeTextarea = document.querySelector('textarea[name="exclude_numbers"]');
@@ -1,4 +1,4 @@
-import { apiGet, apiPost, apiGetCampaignErrors } from '../ui/api.js';
+import { apiGet, apiPost, apiGetCampaignErrors, apiGetCampaignRecipients, apiGetCampaignStats } from '../ui/api.js';
 
 // Страница истории рассылок
 export function renderHistoryPage() {
@@ -52,6 +52,9 @@ export function renderHistoryPage() {
             <option value="failed">Ошибка</option>
             <option value="pending">Ожидает</option>
             <option value="filtering">Фильтрация</option>
+            <option value="interrupted">Прервана</option>
+            <option value="paused">Приостановлена</option>
+            <option value="scheduled">Запланирована</option>
           </select>
           <button id="refresh-history" class="refresh-btn">
             <span class="refresh-icon">🔄</span>
@@ -329,6 +332,19 @@ export function initHistoryPage(showToast) {
     try {
       // Используем наш новый GetByID API
       const campaign = await apiGet(`/api/v1/campaigns/${campaignId}`, showToast);
+      // Статистика по дням не критична для отображения деталей
+      const stats = await apiGetCampaignStats(campaignId).catch(() => null);
+      const statsDays = stats && stats.days ? stats.days.filter(day => day.sent || day.failed || day.delivered || day.read) : [];
+      // Номера загружаются постранично: для просмотра и копирования достаточно первой тысячи, полный список — в выгрузке
+      const counts = campaign.recipient_counts || {};
+      const [sentPage, failedPage] = await Promise.all([
+        counts.sent ? apiGetCampaignRecipients(campaignId, { status: 'sent', limit: 1000 }).catch(() => null) : null,
+        counts.failed ? apiGetCampaignRecipients(campaignId, { status: 'failed', limit: 1000 }).catch(() => null) : null,
+      ]);
+      const sentNumbers = sentPage ? sentPage.recipients : [];
+      const failedNumbers = failedPage ? failedPage.recipients : [];
+      const errors = campaign.error_count > 0 ? await apiGetCampaignErrors(campaignId).catch(() => null) : null;
+      const errorGroups = errors && errors.groups ? errors.groups : [];
       
       modalTitle.textContent = campaign.name || 'Детали рассылки';
       modalBody.innerHTML = `
@@ -352,6 +368,18 @@ export function initHistoryPage(showToast) {
                 <label>Дата создания:</label>
                 <span class="detail-value">${formatDate(campaign.created_at)}</span>
               </div>
+              ${campaign.sending_window ? `
+              <div class="detail-item">
+                <label>Окно отправки:</label>
+                <span class="detail-value">${campaign.sending_window.start}–${campaign.sending_window.end} (${campaign.sending_window.timezone})${campaign.sending_window.weekdays && campaign.sending_window.weekdays.length ? ', ' + formatWeekdays(campaign.sending_window.weekdays) : ''}</span>
+              </div>
+              ` : ''}
+              ${campaign.scheduled_at ? `
+              <div class="detail-item">
+                <label>Запланированный запуск:</label>
+                <span class="detail-value">${formatDate(campaign.scheduled_at)}</span>
+              </div>
+              ` : ''}
               <div class="detail-item">
                 <label>Категория:</label>
                 <span class="detail-value">${campaign.category_name ? `<span class="category-tag">${campaign.category_name}</span>` : 'Без фильтрации'}</span>
@@ -408,11 +436,15 @@ export function initHistoryPage(showToast) {
                 </div>
                 <div class="detail-item">
                   <label>Отправлено успешно:</label>
-                  <span class="detail-value success">${campaign.sent_numbers ? campaign.sent_numbers.filter(n => n.status === 'sent').length : 0}</span>
+                  <span class="detail-value success">${counts.sent || 0}</span>
+                </div>
+                <div class="detail-item">
+                  <label>Доставлено / прочитано:</label>
+                  <span class="detail-value">${campaign.delivered_count || 0} / ${campaign.read_count || 0}</span>
                 </div>
                 <div class="detail-item">
                   <label>Ошибки отправки:</label>
-                  <span class="detail-value numbers-error">${campaign.failed_numbers ? campaign.failed_numbers.filter(n => n.status === 'failed').length : 0}</span>
+                  <span class="detail-value numbers-error">${counts.failed || 0}</span>
                 </div>
                 <div class="detail-item">
                   <label>Скорость отправки:</label>
@@ -427,6 +459,59 @@ export function initHistoryPage(showToast) {
             <div class="message-preview">${campaign.message}</div>
           </div>
           
+          ${campaign.variants && campaign.variants.length ? `
+          <div class="detail-section">
+            <h4>🧪 Варианты сообщения</h4>
+            <div class="detail-grid">
+              ${campaign.variants.map(variant => `
+                <div class="detail-item">
+                  <label>Вариант ${variant.name} (${variant.percent}%)${variant.has_media ? ' 📎' : ''}:</label>
+                  <span class="detail-value">
+                    <span class="success">${variant.sent}</span> / <span class="numbers-error">${variant.failed}</span> из ${variant.total}
+                  </span>
+                  ${variant.message ? `<div class="message-preview">${variant.message}</div>` : ''}
+                </div>
+              `).join('')}
+            </div>
+          </div>
+          ` : ''}
+          
+          ${statsDays.length ? `
+          <div class="detail-section">
+            <h4>📈 Статистика по дням</h4>
+            <div class="detail-grid">
+              ${statsDays.map(day => `
+                <div class="detail-item">
+                  <label>${new Date(day.date).toLocaleDateString('ru-RU')}:</label>
+                  <span class="detail-value">
+                    <span class="success">${day.sent}</span> отправлено •
+                    <span class="numbers-error">${day.failed}</span> ошибок •
+                    ${day.delivered} доставлено (${day.delivery_rate}%) •
+                    ${day.read} прочитано (${day.read_rate}%)
+                  </span>
+                </div>
+              `).join('')}
+            </div>
+          </div>
+          ` : ''}
+          
+          ${errorGroups.length ? `
+          <div class="detail-section">
+            <h4>🔎 Причины ошибок (${errors.total_failed}, можно повторить: ${errors.retryable_count})</h4>
+            <div class="detail-grid">
+              ${errorGroups.map(group => `
+                <div class="detail-item">
+                  <label>${group.reason}${group.retryable ? ' 🔁' : ''}:</label>
+                  <span class="detail-value">
+                    <span class="numbers-error">${group.count}</span>
+                  </span>
+                  <div class="message-preview">${group.example}</div>
+                </div>
+              `).join('')}
+            </div>
+          </div>
+          ` : ''}
+          
           ${campaign.media ? `
           <div class="detail-section">
             <h4>📎 Медиа файл</h4>
@@ -442,88 +527,112 @@ export function initHistoryPage(showToast) {
           </div>
           ` : ''}
           
-          ${campaign.sent_numbers && campaign.sent_numbers.length > 0 ? `
+          ${sentNumbers.length > 0 ? `
           <div class="detail-section">
-            <h4>✅ Успешно отправлено (${campaign.sent_numbers.filter(n => n.status === 'sent').length})</h4>
+            <h4>✅ Успешно отправлено (${counts.sent})</h4>
             <div class="phone-numbers-container">
               <div class="phone-numbers-header">
                 <span class="phone-numbers-label">Номера с успешной отправкой:</span>
               </div>
               <div class="phone-numbers-list">
-                ${campaign.sent_numbers.filter(n => n.status === 'sent').slice(0, 50).map(number => `
+                ${sentNumbers.slice(0, 50).map(number => `
                   <div class="phone-number-item success">
                     <span class="phone-number">${number.phone_number}</span>
                     <span class="phone-time">${formatDate(number.sent_at)}</span>
                   </div>
                 `).join('')}
-                ${campaign.sent_numbers.filter(n => n.status === 'sent').length > 50 ? `
+                ${counts.sent > 50 ? `
                   <div class="phone-numbers-more">
-                    ... и еще ${campaign.sent_numbers.filter(n => n.status === 'sent').length - 50} номеров
+                    ... и еще ${counts.sent - 50} номеров
                   </div>
                 ` : ''}
               </div>
-              ${campaign.sent_numbers.filter(n => n.status === 'sent').length > 0 ? `
+              ${sentNumbers.length > 0 ? `
               <div class="phone-numbers-textarea-container">
                 <div class="textarea-header">
-                  <label class="phone-numbers-textarea-label">Все успешно отправленные номера (${campaign.sent_numbers.filter(n => n.status === 'sent').length}):</label>
+                  <label class="phone-numbers-textarea-label">Все успешно отправленные номера (${formatLoadedCount(sentNumbers.length, counts.sent)}):</label>
                   <button class="copy-textarea-btn" onclick="copySuccessfulNumbers('${campaign.id}')" title="Копировать все успешно отправленные номера">
                     <span class="copy-btn-text">📋 Копировать номера</span>
                   </button>
                 </div>
-                <textarea id="successful-numbers-${campaign.id}" class="phone-numbers-textarea" readonly title="Выделите нужные номера для копирования">${campaign.sent_numbers.filter(n => n.status === 'sent').map(n => n.phone_number).join('\n')}</textarea>
+                <textarea id="successful-numbers-${campaign.id}" class="phone-numbers-textarea" readonly title="Выделите нужные номера для копирования">${sentNumbers.map(n => n.phone_number).join('\n')}</textarea>
               </div>
               ` : ''}
             </div>
           </div>
           ` : ''}
           
-          ${campaign.failed_numbers && campaign.failed_numbers.filter(n => n.status === 'failed').length > 0 ? `
+          ${failedNumbers.length > 0 ? `
           <div class="detail-section">
-            <h4>❌ Ошибки отправки (${campaign.failed_numbers.filter(n => n.status === 'failed').length})</h4>
+            <h4>❌ Ошибки отправки (${counts.failed})</h4>
             <div class="phone-numbers-container">
               <div class="phone-numbers-header">
                 <span class="phone-numbers-label">Номера с ошибками отправки:</span>
               </div>
               <div class="phone-numbers-list">
-                ${campaign.failed_numbers.filter(n => n.status === 'failed').slice(0, 50).map(number => `
+                ${failedNumbers.slice(0, 50).map(number => `
                   <div class="phone-number-item error">
                     <span class="phone-number">${number.phone_number}</span>
                     <span class="phone-error">${number.error || 'Неизвестная ошибка'}</span>
                   </div>
                 `).join('')}
-                ${campaign.failed_numbers.filter(n => n.status === 'failed').length > 50 ? `
+                ${counts.failed > 50 ? `
                   <div class="phone-numbers-more">
-                    ... и еще ${campaign.failed_numbers.filter(n => n.status === 'failed').length - 50} номеров с ошибками
+                    ... и еще ${counts.failed - 50} номеров с ошибками
                   </div>
                 ` : ''}
               </div>
               <div class="phone-numbers-textarea-container">
                 <div class="textarea-header">
-                  <label class="phone-numbers-textarea-label">Все номера с ошибками отправки (${campaign.failed_numbers.filter(n => n.status === 'failed').length}):</label>
+                  <label class="phone-numbers-textarea-label">Все номера с ошибками отправки (${formatLoadedCount(failedNumbers.length, counts.failed)}):</label>
                   <button class="copy-textarea-btn" onclick="copyFailedNumbers('${campaign.id}')" title="Копировать все номера с ошибками">
                     <span class="copy-btn-text">📋 Копировать ошибки</span>
                   </button>
                 </div>
-                <textarea id="failed-numbers-${campaign.id}" class="phone-numbers-textarea" readonly title="Выделите нужные номера для копирования">${campaign.failed_numbers.filter(n => n.status === 'failed').map(n => n.phone_number).join('\n')}</textarea>
+                <textarea id="failed-numbers-${campaign.id}" class="phone-numbers-textarea" readonly title="Выделите нужные номера для копирования">${failedNumbers.map(n => n.phone_number).join('\n')}</textarea>
               </div>
+              ${campaign.status === 'finished' || campaign.status === 'failed' ? `
+                <button class="start-campaign-btn" onclick="retryFailedNumbers('${campaign.id}', '${campaign.name.replace(/'/g, "\\'")}')">
+                  🔁 Повторить отправку на номера с ошибками
+                </button>
+              ` : ''}
             </div>
           </div>
           ` : ''}
           
-          ${campaign.status === 'started' || campaign.status === 'pending' || campaign.status === 'filtering' ? `
+          ${campaign.status === 'started' || campaign.status === 'pending' || campaign.status === 'filtering' || campaign.status === 'interrupted' || campaign.status === 'paused' || campaign.status === 'scheduled' ? `
           <div class="detail-section">
             <div class="cancel-campaign-container">
-              ${campaign.status === 'pending' ? `
+              ${campaign.status === 'pending' || campaign.status === 'scheduled' ? `
                 <button class="start-campaign-btn" onclick="startCampaign('${campaign.id}', '${campaign.name.replace(/'/g, "\\'")}')">
                   🚀 Запустить рассылку
                 </button>
               ` : ''}
+              ${campaign.status === 'started' ? `
+                <button class="start-campaign-btn" onclick="pauseCampaign('${campaign.id}', '${campaign.name.replace(/'/g, "\\'")}')">
+                  ⏸️ Приостановить рассылку
+                </button>
+              ` : ''}
+              ${campaign.status === 'interrupted' || campaign.status === 'paused' ? `
+                <button class="start-campaign-btn" onclick="resumeCampaign('${campaign.id}', '${campaign.name.replace(/'/g, "\\'")}')">
+                  ▶️ Продолжить рассылку
+                </button>
+              ` : ''}
               <button class="cancel-campaign-btn" onclick="cancelCampaign('${campaign.id}', '${campaign.name.replace(/'/g, "\\'")}')">
                 🚫 Отменить рассылку
               </button>
             </div>
           </div>
           ` : ''}
+          
+          <div class="detail-section">
+            <h4>⬇️ Выгрузка результатов</h4>
+            <div class="cancel-campaign-container">
+              <a class="start-campaign-btn" href="/api/v1/campaigns/${campaign.id}/export?format=xlsx" download>📊 Все номера (XLSX)</a>
+              <a class="start-campaign-btn" href="/api/v1/campaigns/${campaign.id}/export?format=csv" download>📄 Все номера (CSV)</a>
+              <a class="start-campaign-btn" href="/api/v1/campaigns/${campaign.id}/export?format=xlsx&status=failed" download>❌ Ошибки (XLSX)</a>
+            </div>
+          </div>
         </div>
       `;
       
@@ -540,6 +649,11 @@ export function initHistoryPage(showToast) {
     }
   }
 
+  // Доставленные и прочитанные сообщения тоже считаются успешно отправленными
+  function formatLoadedCount(loaded, total) {
+    return loaded < total ? `первые ${loaded} из ${total}, полный список — в выгрузке` : `${total}`;
+  }
+
   function getStatusIcon(status) {
     const iconMap = {
       'started': '🔄',
@@ -547,11 +661,19 @@ export function initHistoryPage(showToast) {
       'failed': '❌',
       'pending': '⏳',
       'cancelled': '🚫',
-      'filtering': '🔍'
+      'filtering': '🔍',
+      'interrupted': '⏸️',
+      'paused': '⏸️',
+      'scheduled': '🕒'
     };
     return iconMap[status] || '❓';
   }
 
+  function formatWeekdays(weekdays) {
+    const names = ['Вс', 'Пн', 'Вт', 'Ср', 'Чт', 'Пт', 'Сб'];
+    return weekdays.map(day => names[day] || day).join(', ');
+  }
+
   function getStatusText(status) {
     const statusMap = {
       'started': 'Запущена',
@@ -559,7 +681,10 @@ export function initHistoryPage(showToast) {
       'failed': 'Ошибка',
       'pending': 'Ожидает',
       'cancelled': 'Отменена',
-      'filtering': 'Фильтрация'
+      'filtering': 'Фильтрация',
+      'interrupted': 'Прервана',
+      'paused': 'Приостановлена',
+      'scheduled': 'Запланирована'
     };
     return statusMap[status] || status;
   }
@@ -811,6 +936,72 @@ export function initHistoryPage(showToast) {
     }
   };
 
+  // Глобальная функция для приостановки кампании
+  window.pauseCampaign = async function(campaignId, campaignName) {
+    if (!confirm(`Приостановить рассылку "${campaignName}"?`)) {
+      return;
+    }
+
+    try {
+      const response = await apiPost(`/api/v1/campaigns/${campaignId}/pause`, {}, showToast);
+
+      if (response.status === 'paused') {
+        showToast('Рассылка приостановлена', 'success');
+        loadHistory();
+        modal.style.display = 'none';
+      } else {
+        showToast(`Ошибка приостановки: ${response.error || response.message || 'Неизвестная ошибка'}`, 'danger');
+      }
+    } catch (error) {
+      console.error('Error pausing campaign:', error);
+      showToast('Ошибка приостановки рассылки', 'danger');
+    }
+  };
+
+  // Глобальная функция для возобновления приостановленной или прерванной кампании
+  window.resumeCampaign = async function(campaignId, campaignName) {
+    if (!confirm(`Продолжить рассылку "${campaignName}"? Сообщения получат только номера, которым они еще не отправлялись.`)) {
+      return;
+    }
+
+    try {
+      const response = await apiPost(`/api/v1/campaigns/${campaignId}/resume`, {}, showToast);
+
+      if (response.status === 'started' || response.status === 'finished') {
+        showToast('Рассылка успешно возобновлена!', 'success');
+        loadHistory();
+        modal.style.display = 'none';
+      } else {
+        showToast(`Ошибка возобновления: ${response.error || response.message || 'Неизвестная ошибка'}`, 'danger');
+      }
+    } catch (error) {
+      console.error('Error resuming campaign:', error);
+      showToast('Ошибка возобновления рассылки', 'danger');
+    }
+  };
+
+  // Глобальная функция для повторной отправки на номера с ошибками
+  window.retryFailedNumbers = async function(campaignId, campaignName) {
+    if (!confirm(`Повторить отправку на номера с ошибками в рассылке "${campaignName}"?`)) {
+      return;
+    }
+
+    try {
+      const response = await apiPost(`/api/v1/campaigns/${campaignId}/retry-failed`, {}, showToast);
+
+      if (response.status === 'started') {
+        showToast(`Повторная отправка запущена: ${response.retried_numbers} номеров`, 'success');
+        loadHistory();
+        modal.style.display = 'none';
+      } else {
+        showToast(`Ошибка повторной отправки: ${response.error || response.message || 'Неизвестная ошибка'}`, 'danger');
+      }
+    } catch (error) {
+      console.error('Error retrying failed numbers:', error);
+      showToast('Ошибка повторной отправки', 'danger');
+    }
+  };
+
   // Глобальная функция для отмены кампании
   window.cancelCampaign = async function(campaignId, campaignName) {
     if (!confirm(`Отменить рассылку "${campaignName}"? Это действие нельзя отменить.`)) {
@@ -107,4 +107,23 @@ export async function apiDelete(url, showToast = null) {
 
 export async function apiGetCampaignErrors(campaignId, showToast = null) {
   return apiGet(`/api/v1/campaigns/${campaignId}/errors`, showToast);
-} 
\ No newline at end of file
+} 
+
+export async function apiGetCampaignRecipients(campaignId, { status = '', search = '', limit = 0, offset = 0, after = '' } = {}, showToast = null) {
+  const params = new URLSearchParams();
+  if (status) params.set('status', status);
+  if (search) params.set('search', search);
+  if (limit) params.set('limit', limit);
+  if (offset) params.set('offset', offset);
+  if (after) params.set('after', after);
+  const query = params.toString();
+  return apiGet(`/api/v1/campaigns/${campaignId}/recipients${query ? `?${query}` : ''}`, showToast);
+}
+
+export async function apiGetCampaignStats(campaignId, from = '', to = '', showToast = null) {
+  const params = new URLSearchParams();
+  if (from) params.set('from', from);
+  if (to) params.set('to', to);
+  const query = params.toString();
+  return apiGet(`/api/v1/campaigns/${campaignId}/stats${query ? `?${query}` : ''}`, showToast);
+}
@@ -2,9 +2,12 @@ package converter
 
 import (
 	"mime/multipart"
+	"time"
 	httpDTO "whatsapp-service/internal/adapters/dto/campaign"
 	"whatsapp-service/internal/entities/campaign"
 	usecaseDTO "whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+	sharedDTO "whatsapp-service/internal/usecases/dto"
 )
 
 // CampaignConverter интерфейс для конверсий кампаний
@@ -12,16 +15,33 @@ type CampaignConverter interface {
 	// HTTP -> UseCase
 	ToCreateCampaignRequest(httpReq httpDTO.CreateCampaignRequest, phoneFile, mediaFile *multipart.FileHeader) usecaseDTO.CreateCampaignRequest
 	ToStartCampaignRequest(campaignID string) usecaseDTO.StartCampaignRequest
+	ToPauseCampaignRequest(campaignID string) usecaseDTO.PauseCampaignRequest
+	ToResumeCampaignRequest(campaignID string) usecaseDTO.ResumeCampaignRequest
+	ToRetryFailedRequest(campaignID, errorContains string) usecaseDTO.RetryFailedRequest
 	ToCancelCampaignRequest(campaignID, reason string) usecaseDTO.CancelCampaignRequest
 	ToGetCampaignByIDRequest(campaignID string) usecaseDTO.GetCampaignByIDRequest
 	ToListCampaignsRequest(limit, offset int, status string) usecaseDTO.ListCampaignsRequest
+	ToGetCampaignStatsRequest(campaignID string, from, to time.Time) usecaseDTO.GetCampaignStatsRequest
+	ToGetCampaignErrorsRequest(campaignID, reason string, limit, offset int) usecaseDTO.GetCampaignErrorsRequest
+	ToExportCampaignRequest(campaignID, format, status string) usecaseDTO.ExportCampaignRequest
+	ToListRecipientsRequest(campaignID string, params httpDTO.ListRecipientsParams) usecaseDTO.ListRecipientsRequest
+	ToPreviewPhoneFileRequest(file *multipart.FileHeader, sheet string) usecaseDTO.PreviewPhoneFileRequest
+	ToPreviewAudienceRequest(httpReq httpDTO.PreviewAudienceRequest, phoneFile *multipart.FileHeader) usecaseDTO.PreviewAudienceRequest
 
 	// UseCase -> HTTP
 	ToCreateCampaignResponse(ucResp *usecaseDTO.CreateCampaignResponse) httpDTO.CreateCampaignResponse
 	ToStartCampaignResponse(ucResp *usecaseDTO.StartCampaignResponse) httpDTO.StartCampaignResponse
+	ToPauseCampaignResponse(ucResp *usecaseDTO.PauseCampaignResponse) httpDTO.PauseCampaignResponse
+	ToResumeCampaignResponse(ucResp *usecaseDTO.ResumeCampaignResponse) httpDTO.ResumeCampaignResponse
+	ToRetryFailedResponse(ucResp *usecaseDTO.RetryFailedResponse) httpDTO.RetryFailedResponse
 	ToCancelCampaignResponse(ucResp *usecaseDTO.CancelCampaignResponse) httpDTO.CancelCampaignResponse
 	ToGetCampaignByIDResponse(ucResp *usecaseDTO.GetCampaignByIDResponse) httpDTO.GetCampaignByIDResponse
 	ToListCampaignsResponse(ucResp *usecaseDTO.ListCampaignsResponse) httpDTO.ListCampaignsResponse
+	ToGetCampaignStatsResponse(ucResp *usecaseDTO.GetCampaignStatsResponse) httpDTO.GetCampaignStatsResponse
+	ToGetCampaignErrorsResponse(ucResp *usecaseDTO.GetCampaignErrorsResponse) httpDTO.GetCampaignErrorsResponse
+	ToListRecipientsResponse(ucResp *usecaseDTO.ListRecipientsResponse) httpDTO.ListRecipientsResponse
+	ToPhoneFilePreviewResponse(analysis *sharedDTO.FileAnalysis) httpDTO.PhoneFilePreviewResponse
+	ToPreviewAudienceResponse(ucResp *usecaseDTO.PreviewAudienceResponse) httpDTO.PreviewAudienceResponse
 
 	// Entity -> HTTP
 	ToCampaignResponse(entity *campaign.Campaign) httpDTO.CampaignResponse
@@ -54,6 +74,109 @@ func (c *campaignConverter) ToCreateCampaignRequest(httpReq httpDTO.CreateCampai
 		Async:                false, // По умолчанию синхронно
 		SelectedCategoryName: httpReq.SelectedCategoryName,
 		AutoStartAfterFilter: httpReq.AutoStartAfterFilter,
+		ScheduledAt:          httpReq.ScheduledAt,
+		SendingWindow:        c.toSendingWindow(httpReq.SendingWindow),
+		Variants:             c.toMessageVariants(httpReq.Variants),
+		PhoneSheet:           httpReq.PhoneSheet,
+		PhoneAllSheets:       httpReq.PhoneAllSheets,
+		PhoneColumn:          httpReq.PhoneColumn,
+	}
+}
+
+// ToPreviewPhoneFileRequest преобразует HTTP запрос анализа файла в UseCase запрос
+func (c *campaignConverter) ToPreviewPhoneFileRequest(file *multipart.FileHeader, sheet string) usecaseDTO.PreviewPhoneFileRequest {
+	return usecaseDTO.PreviewPhoneFileRequest{
+		File:  file,
+		Sheet: sheet,
+	}
+}
+
+// ToPreviewAudienceRequest преобразует HTTP запрос расчета аудитории в UseCase запрос
+func (c *campaignConverter) ToPreviewAudienceRequest(httpReq httpDTO.PreviewAudienceRequest, phoneFile *multipart.FileHeader) usecaseDTO.PreviewAudienceRequest {
+	return usecaseDTO.PreviewAudienceRequest{
+		PhoneFile:            phoneFile,
+		PhoneSheet:           httpReq.PhoneSheet,
+		PhoneAllSheets:       httpReq.PhoneAllSheets,
+		PhoneColumn:          httpReq.PhoneColumn,
+		AdditionalNumbers:    httpReq.AdditionalPhones,
+		ExcludeNumbers:       httpReq.ExcludePhones,
+		SelectedCategoryName: httpReq.SelectedCategoryName,
+	}
+}
+
+// ToPhoneFilePreviewResponse преобразует анализ файла с номерами в HTTP ответ
+func (c *campaignConverter) ToPhoneFilePreviewResponse(analysis *sharedDTO.FileAnalysis) httpDTO.PhoneFilePreviewResponse {
+	sheets := analysis.Sheets
+	if sheets == nil {
+		sheets = []string{}
+	}
+
+	return httpDTO.PhoneFilePreviewResponse{
+		Filename:        analysis.Filename,
+		Sheets:          sheets,
+		Sheet:           analysis.Sheet,
+		TotalRows:       analysis.TotalRows,
+		Columns:         analysis.Columns,
+		SuggestedColumn: analysis.SuggestedColumn,
+		EstimatedPhones: analysis.EstimatedPhones,
+		SampleRows:      analysis.SampleRows,
+		Warnings:        analysis.Warnings,
+	}
+}
+
+// toMessageVariants преобразует HTTP варианты сообщения в UseCase DTO
+func (c *campaignConverter) toMessageVariants(httpVariants []httpDTO.MessageVariant) []usecaseDTO.MessageVariant {
+	if len(httpVariants) == 0 {
+		return nil
+	}
+
+	variants := make([]usecaseDTO.MessageVariant, len(httpVariants))
+	for i, variant := range httpVariants {
+		variants[i] = usecaseDTO.MessageVariant{
+			Name:      variant.Name,
+			Message:   variant.Message,
+			MediaFile: variant.MediaFile,
+			Percent:   variant.Percent,
+		}
+	}
+	return variants
+}
+
+// toSendingWindow преобразует HTTP окно отправки в UseCase DTO
+func (c *campaignConverter) toSendingWindow(httpWindow *httpDTO.SendingWindow) *usecaseDTO.SendingWindow {
+	if httpWindow == nil {
+		return nil
+	}
+
+	weekdays := make([]time.Weekday, len(httpWindow.Weekdays))
+	for i, day := range httpWindow.Weekdays {
+		weekdays[i] = time.Weekday(day)
+	}
+
+	return &usecaseDTO.SendingWindow{
+		Start:    httpWindow.Start,
+		End:      httpWindow.End,
+		Timezone: httpWindow.Timezone,
+		Weekdays: weekdays,
+	}
+}
+
+// fromSendingWindow преобразует UseCase окно отправки в HTTP DTO
+func (c *campaignConverter) fromSendingWindow(ucWindow *usecaseDTO.SendingWindow) *httpDTO.SendingWindow {
+	if ucWindow == nil {
+		return nil
+	}
+
+	weekdays := make([]int, len(ucWindow.Weekdays))
+	for i, day := range ucWindow.Weekdays {
+		weekdays[i] = int(day)
+	}
+
+	return &httpDTO.SendingWindow{
+		Start:    ucWindow.Start,
+		End:      ucWindow.End,
+		Timezone: ucWindow.Timezone,
+		Weekdays: weekdays,
 	}
 }
 
@@ -64,6 +187,28 @@ func (c *campaignConverter) ToStartCampaignRequest(campaignID string) usecaseDTO
 	}
 }
 
+// ToPauseCampaignRequest преобразует campaignID в UseCase запрос
+func (c *campaignConverter) ToPauseCampaignRequest(campaignID string) usecaseDTO.PauseCampaignRequest {
+	return usecaseDTO.PauseCampaignRequest{
+		CampaignID: campaignID,
+	}
+}
+
+// ToResumeCampaignRequest преобразует campaignID в UseCase запрос
+func (c *campaignConverter) ToResumeCampaignRequest(campaignID string) usecaseDTO.ResumeCampaignRequest {
+	return usecaseDTO.ResumeCampaignRequest{
+		CampaignID: campaignID,
+	}
+}
+
+// ToRetryFailedRequest преобразует campaignID и фильтр ошибки в UseCase запрос
+func (c *campaignConverter) ToRetryFailedRequest(campaignID, errorContains string) usecaseDTO.RetryFailedRequest {
+	return usecaseDTO.RetryFailedRequest{
+		CampaignID:    campaignID,
+		ErrorContains: errorContains,
+	}
+}
+
 // ToCancelCampaignRequest преобразует campaignID и reason в UseCase запрос
 func (c *campaignConverter) ToCancelCampaignRequest(campaignID, reason string) usecaseDTO.CancelCampaignRequest {
 	return usecaseDTO.CancelCampaignRequest{
@@ -88,6 +233,128 @@ func (c *campaignConverter) ToListCampaignsRequest(limit, offset int, status str
 	}
 }
 
+// ToGetCampaignStatsRequest преобразует параметры запроса статистики в UseCase запрос
+func (c *campaignConverter) ToGetCampaignStatsRequest(campaignID string, from, to time.Time) usecaseDTO.GetCampaignStatsRequest {
+	return usecaseDTO.GetCampaignStatsRequest{
+		CampaignID: campaignID,
+		From:       from,
+		To:         to,
+	}
+}
+
+// ToGetCampaignStatsResponse преобразует UseCase статистику кампании в HTTP ответ
+func (c *campaignConverter) ToGetCampaignStatsResponse(ucResp *usecaseDTO.GetCampaignStatsResponse) httpDTO.GetCampaignStatsResponse {
+	days := make([]httpDTO.DailyStats, len(ucResp.Days))
+	for i, day := range ucResp.Days {
+		days[i] = c.toDailyStats(day)
+	}
+
+	return httpDTO.GetCampaignStatsResponse{
+		CampaignID: ucResp.CampaignID,
+		From:       ucResp.From,
+		To:         ucResp.To,
+		Days:       days,
+		Total:      c.toDailyStats(ucResp.Total),
+	}
+}
+
+// toDailyStats преобразует UseCase дневную статистику в HTTP DTO
+func (c *campaignConverter) toDailyStats(day usecaseDTO.DailyStats) httpDTO.DailyStats {
+	return httpDTO.DailyStats{
+		Date:         day.Date,
+		Sent:         day.Sent,
+		Delivered:    day.Delivered,
+		Read:         day.Read,
+		Failed:       day.Failed,
+		DeliveryRate: day.DeliveryRate,
+		ReadRate:     day.ReadRate,
+	}
+}
+
+// ToGetCampaignErrorsRequest преобразует параметры запроса ошибок кампании в UseCase запрос
+func (c *campaignConverter) ToGetCampaignErrorsRequest(campaignID, reason string, limit, offset int) usecaseDTO.GetCampaignErrorsRequest {
+	return usecaseDTO.GetCampaignErrorsRequest{
+		CampaignID: campaignID,
+		Reason:     reason,
+		Limit:      limit,
+		Offset:     offset,
+	}
+}
+
+// ToExportCampaignRequest преобразует параметры выгрузки результатов в UseCase запрос
+func (c *campaignConverter) ToExportCampaignRequest(campaignID, format, status string) usecaseDTO.ExportCampaignRequest {
+	return usecaseDTO.ExportCampaignRequest{
+		CampaignID: campaignID,
+		Format:     ports.ExportFormat(format),
+		Status:     status,
+	}
+}
+
+// ToListRecipientsRequest преобразует параметры списка номеров в UseCase запрос
+func (c *campaignConverter) ToListRecipientsRequest(campaignID string, params httpDTO.ListRecipientsParams) usecaseDTO.ListRecipientsRequest {
+	return usecaseDTO.ListRecipientsRequest{
+		CampaignID: campaignID,
+		Status:     params.Status,
+		Search:     params.Search,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+		After:      params.After,
+	}
+}
+
+// ToListRecipientsResponse преобразует UseCase страницу номеров в HTTP ответ
+func (c *campaignConverter) ToListRecipientsResponse(ucResp *usecaseDTO.ListRecipientsResponse) httpDTO.ListRecipientsResponse {
+	recipients := c.convertPhoneNumberStatuses(ucResp.Recipients)
+	if recipients == nil {
+		recipients = []httpDTO.PhoneNumberStatus{}
+	}
+
+	return httpDTO.ListRecipientsResponse{
+		CampaignID: ucResp.CampaignID,
+		Recipients: recipients,
+		Total:      ucResp.Total,
+		Limit:      ucResp.Limit,
+		Offset:     ucResp.Offset,
+		NextCursor: ucResp.NextCursor,
+	}
+}
+
+// ToGetCampaignErrorsResponse преобразует UseCase разбор ошибок кампании в HTTP ответ
+func (c *campaignConverter) ToGetCampaignErrorsResponse(ucResp *usecaseDTO.GetCampaignErrorsResponse) httpDTO.GetCampaignErrorsResponse {
+	groups := make([]httpDTO.CampaignErrorGroup, len(ucResp.Groups))
+	for i, group := range ucResp.Groups {
+		groups[i] = httpDTO.CampaignErrorGroup{
+			Reason:         group.Reason,
+			Count:          group.Count,
+			RetryableCount: group.RetryableCount,
+			Retryable:      group.Retryable,
+			Example:        group.Example,
+		}
+	}
+
+	numbers := make([]httpDTO.FailedNumber, len(ucResp.Errors))
+	for i, number := range ucResp.Errors {
+		numbers[i] = httpDTO.FailedNumber{
+			PhoneNumber: number.PhoneNumber,
+			Error:       number.Error,
+			Reason:      number.Reason,
+			Retryable:   number.Retryable,
+			Variant:     number.Variant,
+		}
+	}
+
+	return httpDTO.GetCampaignErrorsResponse{
+		CampaignID:     ucResp.CampaignID,
+		TotalFailed:    ucResp.TotalFailed,
+		RetryableCount: ucResp.RetryableCount,
+		Groups:         groups,
+		Errors:         numbers,
+		Total:          ucResp.Total,
+		Limit:          ucResp.Limit,
+		Offset:         ucResp.Offset,
+	}
+}
+
 // ToCreateCampaignResponse преобразует UseCase ответ в HTTP ответ
 func (c *campaignConverter) ToCreateCampaignResponse(ucResp *usecaseDTO.CreateCampaignResponse) httpDTO.CreateCampaignResponse {
 	return httpDTO.CreateCampaignResponse{
@@ -95,6 +362,8 @@ func (c *campaignConverter) ToCreateCampaignResponse(ucResp *usecaseDTO.CreateCa
 		TotalPhones:   ucResp.TotalNumbers,
 		ValidPhones:   ucResp.ValidPhones,
 		InvalidPhones: ucResp.InvalidPhones,
+		BlockedPhones: ucResp.BlockedPhones,
+		CappedPhones:  ucResp.CappedPhones,
 	}
 }
 
@@ -105,12 +374,59 @@ func (c *campaignConverter) ToStartCampaignResponse(ucResp *usecaseDTO.StartCamp
 		CampaignID:          ucResp.CampaignID,
 		Status:              string(ucResp.Status),
 		TotalNumbers:        ucResp.TotalNumbers,
+		SkippedNumbers:      ucResp.SkippedNumbers,
+		BlockedNumbers:      ucResp.BlockedNumbers,
+		CappedNumbers:       ucResp.CappedNumbers,
 		EstimatedCompletion: ucResp.EstimatedCompletion,
 		WorkerStarted:       ucResp.WorkerStarted,
 		Async:               true,
 	}
 }
 
+// ToPauseCampaignResponse преобразует UseCase ответ в HTTP ответ
+func (c *campaignConverter) ToPauseCampaignResponse(ucResp *usecaseDTO.PauseCampaignResponse) httpDTO.PauseCampaignResponse {
+	return httpDTO.PauseCampaignResponse{
+		Message:        "Campaign paused successfully",
+		CampaignID:     ucResp.CampaignID,
+		Status:         string(ucResp.Status),
+		PendingNumbers: ucResp.PendingNumbers,
+	}
+}
+
+// ToResumeCampaignResponse преобразует UseCase ответ в HTTP ответ
+func (c *campaignConverter) ToResumeCampaignResponse(ucResp *usecaseDTO.ResumeCampaignResponse) httpDTO.ResumeCampaignResponse {
+	message := "Campaign resumed successfully"
+	if !ucResp.WorkerStarted {
+		message = "Campaign has no pending numbers and was finished"
+	}
+
+	return httpDTO.ResumeCampaignResponse{
+		Message:             message,
+		CampaignID:          ucResp.CampaignID,
+		Status:              string(ucResp.Status),
+		PendingNumbers:      ucResp.PendingNumbers,
+		AlreadyProcessed:    ucResp.AlreadyProcessed,
+		BlockedNumbers:      ucResp.BlockedNumbers,
+		CappedNumbers:       ucResp.CappedNumbers,
+		EstimatedCompletion: ucResp.EstimatedCompletion,
+		WorkerStarted:       ucResp.WorkerStarted,
+	}
+}
+
+// ToRetryFailedResponse преобразует UseCase ответ в HTTP ответ
+func (c *campaignConverter) ToRetryFailedResponse(ucResp *usecaseDTO.RetryFailedResponse) httpDTO.RetryFailedResponse {
+	return httpDTO.RetryFailedResponse{
+		Message:             "Failed numbers resubmitted successfully",
+		CampaignID:          ucResp.CampaignID,
+		Status:              string(ucResp.Status),
+		RetriedNumbers:      ucResp.RetriedNumbers,
+		BlockedNumbers:      ucResp.BlockedNumbers,
+		CappedNumbers:       ucResp.CappedNumbers,
+		EstimatedCompletion: ucResp.EstimatedCompletion,
+		WorkerStarted:       ucResp.WorkerStarted,
+	}
+}
+
 // ToCancelCampaignResponse преобразует UseCase ответ в HTTP ответ
 func (c *campaignConverter) ToCancelCampaignResponse(ucResp *usecaseDTO.CancelCampaignResponse) httpDTO.CancelCampaignResponse {
 	return httpDTO.CancelCampaignResponse{
@@ -135,11 +451,21 @@ func (c *campaignConverter) ToGetCampaignByIDResponse(ucResp *usecaseDTO.GetCamp
 		TotalCount:      ucResp.TotalCount,
 		ProcessedCount:  ucResp.ProcessedCount,
 		ErrorCount:      ucResp.ErrorCount,
+		DeliveredCount:  ucResp.DeliveredCount,
+		ReadCount:       ucResp.ReadCount,
 		MessagesPerHour: ucResp.MessagesPerHour,
 		CategoryName:    ucResp.CategoryName,
+		ScheduledAt:     ucResp.ScheduledAt,
+		SendingWindow:   c.fromSendingWindow(ucResp.SendingWindow),
 		CreatedAt:       ucResp.CreatedAt,
-		SentNumbers:     c.convertPhoneNumberStatuses(ucResp.SentNumbers),
-		FailedNumbers:   c.convertPhoneNumberStatuses(ucResp.FailedNumbers),
+		RecipientCounts: httpDTO.RecipientCounts{
+			Pending:   ucResp.RecipientCounts.Pending,
+			Sent:      ucResp.RecipientCounts.Sent,
+			Delivered: ucResp.RecipientCounts.Delivered,
+			Read:      ucResp.RecipientCounts.Read,
+			Failed:    ucResp.RecipientCounts.Failed,
+			Cancelled: ucResp.RecipientCounts.Cancelled,
+		},
 	}
 
 	if ucResp.Media != nil {
@@ -147,6 +473,18 @@ func (c *campaignConverter) ToGetCampaignByIDResponse(ucResp *usecaseDTO.GetCamp
 		response.Media = &mediaInfo
 	}
 
+	for _, variant := range ucResp.Variants {
+		response.Variants = append(response.Variants, httpDTO.VariantStats{
+			Name:     variant.Name,
+			Message:  variant.Message,
+			Percent:  variant.Percent,
+			HasMedia: variant.HasMedia,
+			Total:    variant.Total,
+			Sent:     variant.Sent,
+			Failed:   variant.Failed,
+		})
+	}
+
 	return response
 }
 
@@ -167,6 +505,7 @@ func (c *campaignConverter) convertPhoneNumberStatuses(ucStatuses []usecaseDTO.P
 			SentAt:            ucStatus.SentAt,
 			DeliveredAt:       ucStatus.DeliveredAt,
 			ReadAt:            ucStatus.ReadAt,
+			Variant:           ucStatus.Variant,
 			CreatedAt:         ucStatus.CreatedAt,
 		}
 	}
@@ -198,8 +537,11 @@ func (c *campaignConverter) ToListCampaignsResponse(ucResp *usecaseDTO.ListCampa
 			TotalCount:      summary.TotalCount,
 			ProcessedCount:  summary.ProcessedCount,
 			ErrorCount:      summary.ErrorCount,
+			DeliveredCount:  summary.DeliveredCount,
+			ReadCount:       summary.ReadCount,
 			MessagesPerHour: summary.MessagesPerHour,
 			CategoryName:    summary.CategoryName,
+			ScheduledAt:     summary.ScheduledAt,
 			CreatedAt:       summary.CreatedAt,
 		}
 	}
@@ -224,6 +566,7 @@ func (c *campaignConverter) ToCampaignResponse(entity *campaign.Campaign) httpDT
 		ErrorCount:      entity.Metrics().Errors,
 		MessagesPerHour: entity.MessagesPerHour(),
 		CategoryName:    entity.CategoryName(),
+		ScheduledAt:     formatScheduledAt(entity.ScheduledAt(), "2006-01-02T15:04:05Z07:00"),
 		CreatedAt:       entity.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
@@ -239,6 +582,7 @@ func (c *campaignConverter) ToBriefCampaignResponse(entity *campaign.Campaign) h
 		ErrorCount:      entity.Metrics().Errors,
 		MessagesPerHour: entity.MessagesPerHour(),
 		CategoryName:    entity.CategoryName(),
+		ScheduledAt:     formatScheduledAt(entity.ScheduledAt(), "2006-01-02T15:04:05Z07:00"),
 		CreatedAt:       entity.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
@@ -252,8 +596,11 @@ func (c *campaignConverter) ToCampaignSummary(entity *campaign.Campaign) httpDTO
 		TotalCount:      entity.Metrics().Total,
 		ProcessedCount:  entity.Metrics().Processed,
 		ErrorCount:      entity.Metrics().Errors,
+		DeliveredCount:  entity.Metrics().Delivered,
+		ReadCount:       entity.Metrics().Read,
 		MessagesPerHour: entity.MessagesPerHour(),
 		CategoryName:    entity.CategoryName(),
+		ScheduledAt:     formatScheduledAt(entity.ScheduledAt(), "2006-01-02T15:04:05Z07:00"),
 		CreatedAt:       entity.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
@@ -284,3 +631,62 @@ func (c *campaignConverter) ToCampaignSummaryList(entities []*campaign.Campaign)
 	}
 	return summaries
 }
+
+// formatScheduledAt форматирует время запланированного запуска (пустая строка, если запуск не запланирован)
+func formatScheduledAt(scheduledAt *time.Time, layout string) string {
+	if scheduledAt == nil {
+		return ""
+	}
+	return scheduledAt.Format(layout)
+}
+
+// ToPreviewAudienceResponse преобразует итоговую аудиторию кампании в HTTP ответ
+func (c *campaignConverter) ToPreviewAudienceResponse(ucResp *usecaseDTO.PreviewAudienceResponse) httpDTO.PreviewAudienceResponse {
+	invalidPhones := make([]httpDTO.InvalidPhoneRow, len(ucResp.InvalidPhones))
+	for i, phone := range ucResp.InvalidPhones {
+		invalidPhones[i] = httpDTO.InvalidPhoneRow{
+			RawValue: phone.RawValue,
+			Sheet:    phone.Sheet,
+			Row:      phone.Row,
+			Reason:   phone.Reason,
+		}
+	}
+
+	duplicatePhones := make([]httpDTO.DuplicatePhoneRow, len(ucResp.DuplicatePhones))
+	for i, phone := range ucResp.DuplicatePhones {
+		duplicatePhones[i] = httpDTO.DuplicatePhoneRow{
+			PhoneNumber:    phone.PhoneNumber.Value(),
+			RawValue:       phone.RawValue,
+			Sheet:          phone.Sheet,
+			Row:            phone.Row,
+			FirstSeenRow:   phone.FirstSeenAt,
+			FirstSeenSheet: phone.FirstSeenSheet,
+		}
+	}
+
+	additionalInvalid := ucResp.AdditionalInvalid
+	if additionalInvalid == nil {
+		additionalInvalid = []string{}
+	}
+
+	return httpDTO.PreviewAudienceResponse{
+		File: httpDTO.AudienceFileStats{
+			Rows:       ucResp.FileRows,
+			Valid:      ucResp.FileValid,
+			Invalid:    ucResp.FileInvalid,
+			Duplicates: ucResp.FileDuplicates,
+			Corrected:  ucResp.FileCorrected,
+		},
+		InvalidPhones:         invalidPhones,
+		DuplicatePhones:       duplicatePhones,
+		AdditionalValid:       ucResp.AdditionalValid,
+		AdditionalInvalid:     additionalInvalid,
+		AdditionalDuplicates:  ucResp.AdditionalDuplicates,
+		ExcludedCount:         ucResp.ExcludedCount,
+		CategoryFilteredCount: ucResp.CategoryFilteredCount,
+		BlockedCount:          ucResp.BlockedCount,
+		CappedCount:           ucResp.CappedCount,
+		TotalNumbers:          ucResp.TotalNumbers,
+		Warnings:              ucResp.Warnings,
+	}
+}
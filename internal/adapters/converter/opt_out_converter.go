@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"mime/multipart"
+	httpDTO "whatsapp-service/internal/adapters/dto/optout"
+	usecaseDTO "whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// OptOutConverter интерфейс для конверсий стоп-листа
+type OptOutConverter interface {
+	// HTTP -> UseCase
+	ToAddOptOutRequest(httpReq httpDTO.AddOptOutRequest) usecaseDTO.AddOptOutRequest
+	ToRemoveOptOutRequest(phoneNumber string) usecaseDTO.RemoveOptOutRequest
+	ToListOptOutsRequest(params httpDTO.ListOptOutsParams) usecaseDTO.ListOptOutsRequest
+	ToImportOptOutsRequest(file *multipart.FileHeader, phoneNumbers []string, reason string) usecaseDTO.ImportOptOutsRequest
+
+	// UseCase -> HTTP
+	ToAddOptOutResponse(ucResp *usecaseDTO.AddOptOutResponse) httpDTO.AddOptOutResponse
+	ToListOptOutsResponse(ucResp *usecaseDTO.ListOptOutsResponse) httpDTO.ListOptOutsResponse
+	ToImportOptOutsResponse(ucResp *usecaseDTO.ImportOptOutsResponse) httpDTO.ImportOptOutsResponse
+}
+
+// optOutConverter реализация конвертера
+type optOutConverter struct{}
+
+// NewOptOutConverter создает новый конвертер стоп-листа
+func NewOptOutConverter() OptOutConverter {
+	return &optOutConverter{}
+}
+
+// ToAddOptOutRequest преобразует HTTP запрос в UseCase запрос
+func (c *optOutConverter) ToAddOptOutRequest(httpReq httpDTO.AddOptOutRequest) usecaseDTO.AddOptOutRequest {
+	return usecaseDTO.AddOptOutRequest{
+		PhoneNumber: httpReq.PhoneNumber,
+		Reason:      httpReq.Reason,
+	}
+}
+
+// ToRemoveOptOutRequest создает UseCase запрос удаления номера
+func (c *optOutConverter) ToRemoveOptOutRequest(phoneNumber string) usecaseDTO.RemoveOptOutRequest {
+	return usecaseDTO.RemoveOptOutRequest{PhoneNumber: phoneNumber}
+}
+
+// ToListOptOutsRequest преобразует query параметры в UseCase запрос
+func (c *optOutConverter) ToListOptOutsRequest(params httpDTO.ListOptOutsParams) usecaseDTO.ListOptOutsRequest {
+	return usecaseDTO.ListOptOutsRequest{
+		Search: params.Search,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+}
+
+// ToImportOptOutsRequest создает UseCase запрос загрузки номеров
+func (c *optOutConverter) ToImportOptOutsRequest(file *multipart.FileHeader, phoneNumbers []string, reason string) usecaseDTO.ImportOptOutsRequest {
+	return usecaseDTO.ImportOptOutsRequest{
+		File:         file,
+		PhoneNumbers: phoneNumbers,
+		Reason:       reason,
+	}
+}
+
+// ToAddOptOutResponse преобразует UseCase ответ в HTTP ответ
+func (c *optOutConverter) ToAddOptOutResponse(ucResp *usecaseDTO.AddOptOutResponse) httpDTO.AddOptOutResponse {
+	return httpDTO.AddOptOutResponse{
+		OptOut: c.toOptOutResponse(ucResp.OptOut),
+		Added:  ucResp.Added,
+	}
+}
+
+// ToListOptOutsResponse преобразует UseCase ответ в HTTP ответ
+func (c *optOutConverter) ToListOptOutsResponse(ucResp *usecaseDTO.ListOptOutsResponse) httpDTO.ListOptOutsResponse {
+	optOuts := make([]httpDTO.OptOutResponse, len(ucResp.OptOuts))
+	for i, optOut := range ucResp.OptOuts {
+		optOuts[i] = c.toOptOutResponse(optOut)
+	}
+
+	return httpDTO.ListOptOutsResponse{
+		OptOuts: optOuts,
+		Total:   ucResp.Total,
+		Limit:   ucResp.Limit,
+		Offset:  ucResp.Offset,
+	}
+}
+
+// ToImportOptOutsResponse преобразует UseCase ответ в HTTP ответ
+func (c *optOutConverter) ToImportOptOutsResponse(ucResp *usecaseDTO.ImportOptOutsResponse) httpDTO.ImportOptOutsResponse {
+	return httpDTO.ImportOptOutsResponse{
+		Total:          ucResp.Total,
+		Added:          ucResp.Added,
+		AlreadyPresent: ucResp.AlreadyPresent,
+		Invalid:        ucResp.Invalid,
+	}
+}
+
+// toOptOutResponse преобразует номер стоп-листа в HTTP DTO
+func (c *optOutConverter) toOptOutResponse(optOut usecaseDTO.OptOut) httpDTO.OptOutResponse {
+	return httpDTO.OptOutResponse{
+		PhoneNumber: optOut.PhoneNumber,
+		Reason:      optOut.Reason,
+		Source:      optOut.Source,
+		CreatedAt:   optOut.CreatedAt,
+	}
+}
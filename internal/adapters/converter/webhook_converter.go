@@ -0,0 +1,75 @@
+package converter
+
+import (
+	"strings"
+	"time"
+
+	httpDTO "whatsapp-service/internal/adapters/dto/webhook"
+	"whatsapp-service/internal/entities/campaign"
+	usecaseDTO "whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// WebhookConverter интерфейс для конверсий входящих webhook
+type WebhookConverter interface {
+	// HTTP -> UseCase
+	ToProcessDeliveryReceiptsRequest(httpReq httpDTO.WhatsgateWebhookRequest) usecaseDTO.ProcessDeliveryReceiptsRequest
+	ToProcessInboundMessagesRequest(httpReq httpDTO.WhatsgateWebhookRequest) usecaseDTO.ProcessInboundMessagesRequest
+
+	// UseCase -> HTTP
+	ToWhatsgateWebhookResponse(receipts *usecaseDTO.ProcessDeliveryReceiptsResponse, inbound *usecaseDTO.ProcessInboundMessagesResponse) httpDTO.WhatsgateWebhookResponse
+}
+
+// webhookConverter реализация конвертера
+type webhookConverter struct{}
+
+// NewWebhookConverter создает новый конвертер webhook
+func NewWebhookConverter() WebhookConverter {
+	return &webhookConverter{}
+}
+
+// ToProcessDeliveryReceiptsRequest преобразует статусы сообщений WhatsGate в подтверждения доставки
+func (c *webhookConverter) ToProcessDeliveryReceiptsRequest(httpReq httpDTO.WhatsgateWebhookRequest) usecaseDTO.ProcessDeliveryReceiptsRequest {
+	receipts := make([]usecaseDTO.DeliveryReceipt, 0, len(httpReq.Statuses))
+	for _, status := range httpReq.Statuses {
+		receipt := usecaseDTO.DeliveryReceipt{
+			MessageID: strings.TrimSpace(status.MessageID),
+			Status:    campaign.CampaignStatusType(strings.ToLower(strings.TrimSpace(status.Status))),
+		}
+		if status.Timestamp > 0 {
+			receipt.At = time.Unix(status.Timestamp, 0)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	return usecaseDTO.ProcessDeliveryReceiptsRequest{Receipts: receipts}
+}
+
+// ToProcessInboundMessagesRequest преобразует входящие сообщения WhatsGate в запрос обработки отписок
+func (c *webhookConverter) ToProcessInboundMessagesRequest(httpReq httpDTO.WhatsgateWebhookRequest) usecaseDTO.ProcessInboundMessagesRequest {
+	messages := make([]usecaseDTO.InboundMessage, 0, len(httpReq.Messages))
+	for _, message := range httpReq.Messages {
+		// WhatsApp ID отправителя имеет вид "79991234567@c.us"
+		from, _, _ := strings.Cut(strings.TrimSpace(message.From), "@")
+
+		inbound := usecaseDTO.InboundMessage{
+			From: from,
+			Text: message.Text,
+		}
+		if message.Timestamp > 0 {
+			inbound.At = time.Unix(message.Timestamp, 0)
+		}
+		messages = append(messages, inbound)
+	}
+
+	return usecaseDTO.ProcessInboundMessagesRequest{Messages: messages}
+}
+
+// ToWhatsgateWebhookResponse преобразует результаты обработки подтверждений и входящих сообщений в HTTP ответ
+func (c *webhookConverter) ToWhatsgateWebhookResponse(receipts *usecaseDTO.ProcessDeliveryReceiptsResponse, inbound *usecaseDTO.ProcessInboundMessagesResponse) httpDTO.WhatsgateWebhookResponse {
+	return httpDTO.WhatsgateWebhookResponse{
+		Updated:  receipts.Updated,
+		Ignored:  receipts.Ignored + inbound.Ignored,
+		NotFound: receipts.NotFound,
+		OptedOut: inbound.OptedOut,
+	}
+}
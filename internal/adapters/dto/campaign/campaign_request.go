@@ -1,13 +1,59 @@
 package campaign
 
+import (
+	"mime/multipart"
+	"time"
+)
+
 // CreateCampaignRequest представляет HTTP-запрос на создание кампании
 type CreateCampaignRequest struct {
-	Name                 string   `json:"name" form:"name" binding:"required"`
-	Message              string   `json:"message" form:"message" binding:"required"`
+	Name                 string           `json:"name" form:"name" binding:"required"`
+	Message              string           `json:"message" form:"message" binding:"required"`
+	AdditionalPhones     []string         `json:"additional_phones" form:"additional_phones"`
+	ExcludePhones        []string         `json:"exclude_phones" form:"exclude_phones"`
+	MessagesPerHour      int              `json:"messages_per_hour" form:"messages_per_hour"`
+	Initiator            string           `json:"initiator" form:"initiator"`
+	SelectedCategoryName string           `json:"selected_category_name" form:"selected_category_name"`
+	AutoStartAfterFilter bool             `json:"auto_start_after_filter" form:"auto_start_after_filter"`
+	ScheduledAt          *time.Time       `json:"scheduled_at,omitempty" form:"scheduled_at"`
+	SendingWindow        *SendingWindow   `json:"sending_window,omitempty"`
+	Variants             []MessageVariant `json:"variants,omitempty" form:"variants"`
+	PhoneSheet           string           `json:"numbers_sheet,omitempty" form:"numbers_sheet"`
+	PhoneAllSheets       bool             `json:"numbers_all_sheets,omitempty" form:"numbers_all_sheets"`
+	PhoneColumn          string           `json:"numbers_column,omitempty" form:"numbers_column"`
+}
+
+// PreviewAudienceRequest представляет HTTP-запрос предварительного расчета аудитории кампании
+type PreviewAudienceRequest struct {
 	AdditionalPhones     []string `json:"additional_phones" form:"additional_phones"`
 	ExcludePhones        []string `json:"exclude_phones" form:"exclude_phones"`
-	MessagesPerHour      int      `json:"messages_per_hour" form:"messages_per_hour"`
-	Initiator            string   `json:"initiator" form:"initiator"`
 	SelectedCategoryName string   `json:"selected_category_name" form:"selected_category_name"`
-	AutoStartAfterFilter bool     `json:"auto_start_after_filter" form:"auto_start_after_filter"`
+	PhoneSheet           string   `json:"numbers_sheet,omitempty" form:"numbers_sheet"`
+	PhoneAllSheets       bool     `json:"numbers_all_sheets,omitempty" form:"numbers_all_sheets"`
+	PhoneColumn          string   `json:"numbers_column,omitempty" form:"numbers_column"`
+}
+
+// MessageVariant представляет вариант сообщения для A/B тестирования
+type MessageVariant struct {
+	Name      string                `json:"name"`
+	Message   string                `json:"message,omitempty"` // пустая строка = текст кампании
+	Percent   int                   `json:"percent"`
+	MediaFile *multipart.FileHeader `json:"-"` // файл из поля variant_media_<индекс>
+}
+
+// SendingWindow представляет разрешенное окно отправки сообщений кампании
+type SendingWindow struct {
+	Start    string `json:"start" form:"send_window_start"`
+	End      string `json:"end" form:"send_window_end"`
+	Timezone string `json:"timezone" form:"send_window_timezone"`
+	Weekdays []int  `json:"weekdays,omitempty" form:"send_window_weekdays"` // 0 = воскресенье, 6 = суббота
+}
+
+// ListRecipientsParams представляет query параметры списка номеров кампании
+type ListRecipientsParams struct {
+	Status string // Фильтр по статусу номера
+	Search string // Подстрока номера телефона
+	Limit  int
+	Offset int
+	After  string // Курсор: ID последнего номера предыдущей страницы
 }
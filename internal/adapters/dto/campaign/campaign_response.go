@@ -10,6 +10,7 @@ type PhoneNumberStatus struct {
 	SentAt            string `json:"sent_at,omitempty"`
 	DeliveredAt       string `json:"delivered_at,omitempty"`
 	ReadAt            string `json:"read_at,omitempty"`
+	Variant           string `json:"variant,omitempty"`
 	CreatedAt         string `json:"created_at"`
 }
 
@@ -34,6 +35,7 @@ type BriefCampaignResponse struct {
 	ErrorCount      int    `json:"error_count"`
 	MessagesPerHour int    `json:"messages_per_hour"`
 	CategoryName    string `json:"category_name,omitempty"`
+	ScheduledAt     string `json:"scheduled_at,omitempty"`
 	CreatedAt       string `json:"created_at"`
 }
 
@@ -48,6 +50,7 @@ type CampaignResponse struct {
 	ErrorCount      int    `json:"error_count"`
 	MessagesPerHour int    `json:"messages_per_hour"`
 	CategoryName    string `json:"category_name,omitempty"`
+	ScheduledAt     string `json:"scheduled_at,omitempty"`
 	CreatedAt       string `json:"created_at"`
 }
 
@@ -57,6 +60,8 @@ type CreateCampaignResponse struct {
 	TotalPhones   int              `json:"total_phones"`
 	ValidPhones   int              `json:"valid_phones"`
 	InvalidPhones int              `json:"invalid_phones"`
+	BlockedPhones int              `json:"blocked_phones"`
+	CappedPhones  int              `json:"capped_phones"`
 }
 
 // StartCampaignResponse представляет HTTP-ответ на запуск кампании
@@ -65,11 +70,47 @@ type StartCampaignResponse struct {
 	CampaignID          string `json:"campaign_id"`
 	Status              string `json:"status"`
 	TotalNumbers        int    `json:"total_numbers"`
+	SkippedNumbers      int    `json:"skipped_numbers"`
+	BlockedNumbers      int    `json:"blocked_numbers"`
+	CappedNumbers       int    `json:"capped_numbers"`
 	EstimatedCompletion string `json:"estimated_completion"`
 	WorkerStarted       bool   `json:"worker_started"`
 	Async               bool   `json:"async"`
 }
 
+// PauseCampaignResponse представляет HTTP-ответ на приостановку кампании
+type PauseCampaignResponse struct {
+	Message        string `json:"message"`
+	CampaignID     string `json:"campaign_id"`
+	Status         string `json:"status"`
+	PendingNumbers int    `json:"pending_numbers"`
+}
+
+// ResumeCampaignResponse представляет HTTP-ответ на возобновление кампании
+type ResumeCampaignResponse struct {
+	Message             string `json:"message"`
+	CampaignID          string `json:"campaign_id"`
+	Status              string `json:"status"`
+	PendingNumbers      int    `json:"pending_numbers"`
+	AlreadyProcessed    int    `json:"already_processed"`
+	BlockedNumbers      int    `json:"blocked_numbers"`
+	CappedNumbers       int    `json:"capped_numbers"`
+	EstimatedCompletion string `json:"estimated_completion"`
+	WorkerStarted       bool   `json:"worker_started"`
+}
+
+// RetryFailedResponse представляет HTTP-ответ на повторную отправку неудачных номеров
+type RetryFailedResponse struct {
+	Message             string `json:"message"`
+	CampaignID          string `json:"campaign_id"`
+	Status              string `json:"status"`
+	RetriedNumbers      int    `json:"retried_numbers"`
+	BlockedNumbers      int    `json:"blocked_numbers"`
+	CappedNumbers       int    `json:"capped_numbers"`
+	EstimatedCompletion string `json:"estimated_completion"`
+	WorkerStarted       bool   `json:"worker_started"`
+}
+
 // CancelCampaignResponse представляет HTTP-ответ на отмену кампании
 type CancelCampaignResponse struct {
 	Message            string `json:"message"`
@@ -84,19 +125,54 @@ type CancelCampaignResponse struct {
 
 // GetCampaignByIDResponse представляет HTTP-ответ на получение кампании по ID
 type GetCampaignByIDResponse struct {
-	ID              string              `json:"id"`
-	Name            string              `json:"name"`
-	Message         string              `json:"message"`
-	Status          string              `json:"status"`
-	TotalCount      int                 `json:"total_count"`
-	ProcessedCount  int                 `json:"processed_count"`
-	ErrorCount      int                 `json:"error_count"`
-	MessagesPerHour int                 `json:"messages_per_hour"`
-	CategoryName    string              `json:"category_name,omitempty"`
-	CreatedAt       string              `json:"created_at"`
-	SentNumbers     []PhoneNumberStatus `json:"sent_numbers"`
-	FailedNumbers   []PhoneNumberStatus `json:"failed_numbers"`
-	Media           *MediaInfo          `json:"media,omitempty"`
+	ID              string          `json:"id"`
+	Name            string          `json:"name"`
+	Message         string          `json:"message"`
+	Status          string          `json:"status"`
+	TotalCount      int             `json:"total_count"`
+	ProcessedCount  int             `json:"processed_count"`
+	ErrorCount      int             `json:"error_count"`
+	DeliveredCount  int             `json:"delivered_count"`
+	ReadCount       int             `json:"read_count"`
+	MessagesPerHour int             `json:"messages_per_hour"`
+	CategoryName    string          `json:"category_name,omitempty"`
+	ScheduledAt     string          `json:"scheduled_at,omitempty"`
+	SendingWindow   *SendingWindow  `json:"sending_window,omitempty"`
+	CreatedAt       string          `json:"created_at"`
+	RecipientCounts RecipientCounts `json:"recipient_counts"`
+	Media           *MediaInfo      `json:"media,omitempty"`
+	Variants        []VariantStats  `json:"variants,omitempty"`
+}
+
+// RecipientCounts представляет количество номеров кампании по статусам
+type RecipientCounts struct {
+	Pending   int `json:"pending"`
+	Sent      int `json:"sent"`
+	Delivered int `json:"delivered"`
+	Read      int `json:"read"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+}
+
+// ListRecipientsResponse представляет HTTP-ответ со страницей номеров кампании
+type ListRecipientsResponse struct {
+	CampaignID string              `json:"campaign_id"`
+	Recipients []PhoneNumberStatus `json:"recipients"`
+	Total      int                 `json:"total"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// VariantStats представляет вариант сообщения и результаты его отправки
+type VariantStats struct {
+	Name     string `json:"name"`
+	Message  string `json:"message,omitempty"`
+	Percent  int    `json:"percent"`
+	HasMedia bool   `json:"has_media"`
+	Total    int    `json:"total"`
+	Sent     int    `json:"sent"`
+	Failed   int    `json:"failed"`
 }
 
 // CampaignSummary представляет краткую информацию о кампании для списка
@@ -107,8 +183,11 @@ type CampaignSummary struct {
 	TotalCount      int    `json:"total_count"`
 	ProcessedCount  int    `json:"processed_count"`
 	ErrorCount      int    `json:"error_count"`
+	DeliveredCount  int    `json:"delivered_count"`
+	ReadCount       int    `json:"read_count"`
 	MessagesPerHour int    `json:"messages_per_hour"`
 	CategoryName    string `json:"category_name,omitempty"`
+	ScheduledAt     string `json:"scheduled_at,omitempty"`
 	CreatedAt       string `json:"created_at"`
 }
 
@@ -119,3 +198,109 @@ type ListCampaignsResponse struct {
 	Limit     int               `json:"limit"`
 	Offset    int               `json:"offset"`
 }
+
+// GetCampaignStatsResponse представляет HTTP-ответ с дневной статистикой кампании
+type GetCampaignStatsResponse struct {
+	CampaignID string       `json:"campaign_id"`
+	From       string       `json:"from"`
+	To         string       `json:"to"`
+	Days       []DailyStats `json:"days"`
+	Total      DailyStats   `json:"total"`
+}
+
+// DailyStats представляет статистику отправки кампании за один день
+type DailyStats struct {
+	Date         string  `json:"date,omitempty"`
+	Sent         int     `json:"sent"`
+	Delivered    int     `json:"delivered"`
+	Read         int     `json:"read"`
+	Failed       int     `json:"failed"`
+	DeliveryRate float64 `json:"delivery_rate"`
+	ReadRate     float64 `json:"read_rate"`
+}
+
+// GetCampaignErrorsResponse представляет HTTP-ответ с ошибками отправки кампании, сгруппированными по причине
+type GetCampaignErrorsResponse struct {
+	CampaignID     string               `json:"campaign_id"`
+	TotalFailed    int                  `json:"total_failed"`
+	RetryableCount int                  `json:"retryable_count"`
+	Groups         []CampaignErrorGroup `json:"groups"`
+	Errors         []FailedNumber       `json:"errors"`
+	Total          int                  `json:"total"`
+	Limit          int                  `json:"limit"`
+	Offset         int                  `json:"offset"`
+}
+
+// CampaignErrorGroup представляет группу неудачных номеров с одной причиной ошибки
+type CampaignErrorGroup struct {
+	Reason         string `json:"reason"`
+	Count          int    `json:"count"`
+	RetryableCount int    `json:"retryable_count"`
+	Retryable      bool   `json:"retryable"`
+	Example        string `json:"example"`
+}
+
+// FailedNumber представляет неудачный номер с исходным текстом ошибки
+type FailedNumber struct {
+	PhoneNumber string `json:"phone_number"`
+	Error       string `json:"error"`
+	Reason      string `json:"reason"`
+	Retryable   bool   `json:"retryable"`
+	Variant     string `json:"variant,omitempty"`
+}
+
+// PhoneFilePreviewResponse представляет HTTP-ответ с анализом файла с номерами
+type PhoneFilePreviewResponse struct {
+	Filename        string     `json:"filename"`
+	Sheets          []string   `json:"sheets"`
+	Sheet           string     `json:"sheet,omitempty"`
+	TotalRows       int        `json:"total_rows"`
+	Columns         []string   `json:"columns"`
+	SuggestedColumn string     `json:"suggested_column"`
+	EstimatedPhones int        `json:"estimated_phones"`
+	SampleRows      [][]string `json:"sample_rows"`
+	Warnings        []string   `json:"warnings"`
+}
+
+// PreviewAudienceResponse представляет HTTP-ответ с итоговой аудиторией кампании без ее создания
+type PreviewAudienceResponse struct {
+	File                  AudienceFileStats   `json:"file"`
+	InvalidPhones         []InvalidPhoneRow   `json:"invalid_phones"`
+	DuplicatePhones       []DuplicatePhoneRow `json:"duplicate_phones"`
+	AdditionalValid       int                 `json:"additional_valid"`
+	AdditionalInvalid     []string            `json:"additional_invalid"`
+	AdditionalDuplicates  int                 `json:"additional_duplicates"`
+	ExcludedCount         int                 `json:"excluded_count"`
+	CategoryFilteredCount int                 `json:"category_filtered_count"`
+	BlockedCount          int                 `json:"blocked_count"`
+	CappedCount           int                 `json:"capped_count"`
+	TotalNumbers          int                 `json:"total_numbers"`
+	Warnings              []string            `json:"warnings"`
+}
+
+// AudienceFileStats представляет статистику файла с номерами
+type AudienceFileStats struct {
+	Rows       int `json:"rows"`
+	Valid      int `json:"valid"`
+	Invalid    int `json:"invalid"`
+	Duplicates int `json:"duplicates"`
+	Corrected  int `json:"corrected"`
+}
+
+// InvalidPhoneRow представляет невалидный номер файла
+type InvalidPhoneRow struct {
+	RawValue string `json:"raw_value"`
+	Sheet    string `json:"sheet,omitempty"`
+	Row      int    `json:"row"`
+	Reason   string `json:"reason"`
+}
+
+// DuplicatePhoneRow представляет повтор номера в файле
+type DuplicatePhoneRow struct {
+	PhoneNumber    string `json:"phone_number"`
+	RawValue       string `json:"raw_value"`
+	Sheet          string `json:"sheet,omitempty"`
+	Row            int    `json:"row"`
+	FirstSeenRow   int    `json:"first_seen_row"`
+	FirstSeenSheet string `json:"first_seen_sheet,omitempty"`
+}
@@ -0,0 +1,14 @@
+package optout
+
+// AddOptOutRequest представляет тело запроса добавления номера в стоп-лист
+type AddOptOutRequest struct {
+	PhoneNumber string `json:"phone_number"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// ListOptOutsParams представляет query параметры списка стоп-листа
+type ListOptOutsParams struct {
+	Search string // Подстрока номера телефона
+	Limit  int
+	Offset int
+}
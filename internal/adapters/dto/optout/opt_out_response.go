@@ -0,0 +1,31 @@
+package optout
+
+// OptOutResponse представляет номер стоп-листа
+type OptOutResponse struct {
+	PhoneNumber string `json:"phone_number"`
+	Reason      string `json:"reason,omitempty"`
+	Source      string `json:"source"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// AddOptOutResponse представляет HTTP-ответ на добавление номера в стоп-лист
+type AddOptOutResponse struct {
+	OptOut OptOutResponse `json:"opt_out"`
+	Added  bool           `json:"added"`
+}
+
+// ListOptOutsResponse представляет HTTP-ответ со страницей стоп-листа
+type ListOptOutsResponse struct {
+	OptOuts []OptOutResponse `json:"opt_outs"`
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// ImportOptOutsResponse представляет HTTP-ответ на загрузку номеров в стоп-лист
+type ImportOptOutsResponse struct {
+	Total          int `json:"total"`
+	Added          int `json:"added"`
+	AlreadyPresent int `json:"already_present"`
+	Invalid        int `json:"invalid"`
+}
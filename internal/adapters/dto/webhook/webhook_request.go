@@ -0,0 +1,22 @@
+package webhook
+
+// WhatsgateWebhookRequest представляет тело webhook WhatsGate
+type WhatsgateWebhookRequest struct {
+	Statuses []WhatsgateMessageStatus  `json:"statuses"`
+	Messages []WhatsgateInboundMessage `json:"messages"`
+}
+
+// WhatsgateMessageStatus представляет изменение статуса отправленного сообщения
+type WhatsgateMessageStatus struct {
+	MessageID string `json:"id"`
+	Status    string `json:"status"`    // delivered или read, остальные статусы игнорируются
+	Timestamp int64  `json:"timestamp"` // Unix-время события в секундах (0 = время получения webhook)
+}
+
+// WhatsgateInboundMessage представляет входящее сообщение от получателя
+type WhatsgateInboundMessage struct {
+	MessageID string `json:"id"`
+	From      string `json:"from"` // Номер отправителя, допускается WhatsApp ID вида 79991234567@c.us
+	Text      string `json:"text"`
+	Timestamp int64  `json:"timestamp"` // Unix-время сообщения в секундах (0 = время получения webhook)
+}
@@ -0,0 +1,9 @@
+package webhook
+
+// WhatsgateWebhookResponse представляет результат обработки webhook WhatsGate
+type WhatsgateWebhookResponse struct {
+	Updated  int `json:"updated"`
+	Ignored  int `json:"ignored"`
+	NotFound int `json:"not_found"`
+	OptedOut int `json:"opted_out"`
+}
@@ -1,11 +1,14 @@
 package presenters
 
 import (
+	"errors"
 	"net/http"
 	"whatsapp-service/internal/adapters/converter"
 	"whatsapp-service/internal/delivery/http/response"
 	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/interactor"
+	sharedDTO "whatsapp-service/internal/usecases/dto"
 )
 
 // CampaignPresenterInterface определяет интерфейс для presenter кампаний
@@ -13,9 +16,17 @@ type CampaignPresenterInterface interface {
 	// UseCase responses
 	PresentCreateCampaignSuccess(w http.ResponseWriter, ucResponse *dto.CreateCampaignResponse)
 	PresentStartCampaignSuccess(w http.ResponseWriter, ucResponse *dto.StartCampaignResponse)
+	PresentPauseCampaignSuccess(w http.ResponseWriter, ucResponse *dto.PauseCampaignResponse)
+	PresentResumeCampaignSuccess(w http.ResponseWriter, ucResponse *dto.ResumeCampaignResponse)
+	PresentRetryFailedSuccess(w http.ResponseWriter, ucResponse *dto.RetryFailedResponse)
 	PresentCancelCampaignSuccess(w http.ResponseWriter, ucResponse *dto.CancelCampaignResponse)
 	PresentGetCampaignByIDSuccess(w http.ResponseWriter, ucResponse *dto.GetCampaignByIDResponse)
 	PresentListCampaignsSuccess(w http.ResponseWriter, ucResponse *dto.ListCampaignsResponse)
+	PresentCampaignStatsSuccess(w http.ResponseWriter, ucResponse *dto.GetCampaignStatsResponse)
+	PresentCampaignErrorsSuccess(w http.ResponseWriter, ucResponse *dto.GetCampaignErrorsResponse)
+	PresentListRecipientsSuccess(w http.ResponseWriter, ucResponse *dto.ListRecipientsResponse)
+	PresentPhoneFilePreviewSuccess(w http.ResponseWriter, analysis *sharedDTO.FileAnalysis)
+	PresentPreviewAudienceSuccess(w http.ResponseWriter, ucResponse *dto.PreviewAudienceResponse)
 
 	// Entity responses
 	PresentCampaign(w http.ResponseWriter, campaign *campaign.Campaign)
@@ -52,6 +63,24 @@ func (p *CampaignPresenter) PresentStartCampaignSuccess(w http.ResponseWriter, u
 	response.WriteJSON(w, http.StatusOK, responseDTO)
 }
 
+// PresentPauseCampaignSuccess представляет успешный ответ на приостановку кампании
+func (p *CampaignPresenter) PresentPauseCampaignSuccess(w http.ResponseWriter, ucResponse *dto.PauseCampaignResponse) {
+	responseDTO := p.converter.ToPauseCampaignResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentResumeCampaignSuccess представляет успешный ответ на возобновление кампании
+func (p *CampaignPresenter) PresentResumeCampaignSuccess(w http.ResponseWriter, ucResponse *dto.ResumeCampaignResponse) {
+	responseDTO := p.converter.ToResumeCampaignResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentRetryFailedSuccess представляет успешный ответ на повторную отправку неудачных номеров
+func (p *CampaignPresenter) PresentRetryFailedSuccess(w http.ResponseWriter, ucResponse *dto.RetryFailedResponse) {
+	responseDTO := p.converter.ToRetryFailedResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
 // PresentCancelCampaignSuccess представляет успешный ответ на отмену кампании
 func (p *CampaignPresenter) PresentCancelCampaignSuccess(w http.ResponseWriter, ucResponse *dto.CancelCampaignResponse) {
 	responseDTO := p.converter.ToCancelCampaignResponse(ucResponse)
@@ -70,6 +99,36 @@ func (p *CampaignPresenter) PresentListCampaignsSuccess(w http.ResponseWriter, u
 	response.WriteJSON(w, http.StatusOK, responseDTO)
 }
 
+// PresentCampaignStatsSuccess представляет успешный ответ на получение статистики кампании
+func (p *CampaignPresenter) PresentCampaignStatsSuccess(w http.ResponseWriter, ucResponse *dto.GetCampaignStatsResponse) {
+	responseDTO := p.converter.ToGetCampaignStatsResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentCampaignErrorsSuccess представляет успешный ответ на получение ошибок кампании
+func (p *CampaignPresenter) PresentCampaignErrorsSuccess(w http.ResponseWriter, ucResponse *dto.GetCampaignErrorsResponse) {
+	responseDTO := p.converter.ToGetCampaignErrorsResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentListRecipientsSuccess представляет успешный ответ на получение страницы номеров кампании
+func (p *CampaignPresenter) PresentListRecipientsSuccess(w http.ResponseWriter, ucResponse *dto.ListRecipientsResponse) {
+	responseDTO := p.converter.ToListRecipientsResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentPhoneFilePreviewSuccess представляет успешный ответ с анализом файла с номерами
+func (p *CampaignPresenter) PresentPhoneFilePreviewSuccess(w http.ResponseWriter, analysis *sharedDTO.FileAnalysis) {
+	responseDTO := p.converter.ToPhoneFilePreviewResponse(analysis)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentPreviewAudienceSuccess представляет успешный ответ с итоговой аудиторией кампании
+func (p *CampaignPresenter) PresentPreviewAudienceSuccess(w http.ResponseWriter, ucResponse *dto.PreviewAudienceResponse) {
+	responseDTO := p.converter.ToPreviewAudienceResponse(ucResponse)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
 // PresentCampaign представляет одну кампанию
 func (p *CampaignPresenter) PresentCampaign(w http.ResponseWriter, campaign *campaign.Campaign) {
 	responseDTO := p.converter.ToCampaignResponse(campaign)
@@ -104,39 +163,58 @@ func (p *CampaignPresenter) PresentUseCaseError(w http.ResponseWriter, err error
 	response.WriteError(w, statusCode, err.Error())
 }
 
-// mapErrorToStatusCode преобразует ошибку UseCase в HTTP статус код
+// mapErrorToStatusCode преобразует ошибку UseCase в HTTP статус код.
+// Ошибки сравниваются через errors.Is: use case возвращает их с контекстом
 func (p *CampaignPresenter) mapErrorToStatusCode(err error) int {
-	switch err {
+	switch {
 	// Конфликты состояния (409)
-	case campaign.ErrCannotStartCampaign:
-		return http.StatusConflict
-	case campaign.ErrCannotCancelCampaign:
-		return http.StatusConflict
-	case campaign.ErrCampaignNotPending:
-		return http.StatusConflict
-	case campaign.ErrCampaignAlreadyRunning:
+	case errors.Is(err, campaign.ErrCannotStartCampaign),
+		errors.Is(err, campaign.ErrCannotCancelCampaign),
+		errors.Is(err, campaign.ErrCampaignNotPending),
+		errors.Is(err, campaign.ErrCampaignAlreadyRunning),
+		errors.Is(err, campaign.ErrCannotResumeCampaign),
+		errors.Is(err, campaign.ErrCannotPauseCampaign),
+		errors.Is(err, campaign.ErrCannotRetryCampaign),
+		errors.Is(err, campaign.ErrCannotScheduleCampaign),
+		errors.Is(err, interactor.ErrCannotBePaused),
+		errors.Is(err, interactor.ErrCannotBeResumed),
+		errors.Is(err, interactor.ErrCannotRetryFailed),
+		errors.Is(err, interactor.ErrNoFailedNumbers):
 		return http.StatusConflict
 
 	// Ошибки валидации (400)
-	case campaign.ErrInvalidPhoneNumber:
-		return http.StatusBadRequest
-	case campaign.ErrInvalidMessagesPerHour:
-		return http.StatusBadRequest
-	case campaign.ErrCampaignNameRequired:
-		return http.StatusBadRequest
-	case campaign.ErrCampaignMessageRequired:
-		return http.StatusBadRequest
-	case campaign.ErrNoPhoneNumbers:
+	case errors.Is(err, campaign.ErrInvalidPhoneNumber),
+		errors.Is(err, campaign.ErrUnsupportedPhoneFile),
+		errors.Is(err, campaign.ErrPhoneFileSheetNotFound),
+		errors.Is(err, campaign.ErrPhoneFileTooManyRows),
+		errors.Is(err, campaign.ErrInvalidMessagesPerHour),
+		errors.Is(err, campaign.ErrCampaignNameRequired),
+		errors.Is(err, campaign.ErrCampaignMessageRequired),
+		errors.Is(err, campaign.ErrNoPhoneNumbers),
+		errors.Is(err, campaign.ErrScheduledTimeInPast),
+		errors.Is(err, campaign.ErrInvalidSendingWindow),
+		errors.Is(err, campaign.ErrInvalidMessageVariants),
+		errors.Is(err, interactor.ErrMessageTooLong),
+		errors.Is(err, interactor.ErrPauseCampaignIDRequired),
+		errors.Is(err, interactor.ErrPauseCampaignIDTooLong),
+		errors.Is(err, interactor.ErrResumeCampaignIDRequired),
+		errors.Is(err, interactor.ErrResumeCampaignIDTooLong),
+		errors.Is(err, interactor.ErrRetryCampaignIDRequired),
+		errors.Is(err, interactor.ErrRetryCampaignIDTooLong),
+		errors.Is(err, interactor.ErrRetryErrorFilterTooLong):
 		return http.StatusBadRequest
+	case errors.Is(err, campaign.ErrPhoneFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+
+	// Достигнут лимит одновременно отправляемых кампаний, запуск можно повторить позже (429)
+	case errors.Is(err, interactor.ErrConcurrencyLimitReached):
+		return http.StatusTooManyRequests
 
 	// Ошибки не найдено (404)
-	case campaign.ErrCampaignNotFound:
+	case errors.Is(err, campaign.ErrCampaignNotFound):
 		return http.StatusNotFound
 
 	// Внутренние ошибки (500)
-	case campaign.ErrRepositoryError:
-		return http.StatusInternalServerError
-
 	default:
 		return http.StatusInternalServerError
 	}
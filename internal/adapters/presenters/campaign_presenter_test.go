@@ -0,0 +1,115 @@
+package presenters
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/interactor"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCampaignPresenter_MapErrorToStatusCode проверяет, что статус определяется и по обернутым ошибкам
+func TestCampaignPresenter_MapErrorToStatusCode(t *testing.T) {
+	presenter := &CampaignPresenter{}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "pause_finished_campaign",
+			err:      fmt.Errorf("%w: current status is %s", interactor.ErrCannotBePaused, campaign.CampaignStatusFinished),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "resume_running_campaign",
+			err:      fmt.Errorf("%w: current status is %s", interactor.ErrCannotBeResumed, campaign.CampaignStatusStarted),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "retry_running_campaign",
+			err:      fmt.Errorf("%w: current status is %s", interactor.ErrCannotRetryFailed, campaign.CampaignStatusStarted),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "retry_without_failed_numbers",
+			err:      interactor.ErrNoFailedNumbers,
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "concurrency_limit_reached",
+			err:      fmt.Errorf("%w: %d of %d", interactor.ErrConcurrencyLimitReached, 10, 10),
+			expected: http.StatusTooManyRequests,
+		},
+		{
+			name:     "invalid_sending_window",
+			err:      fmt.Errorf("%w: start and end must differ", campaign.ErrInvalidSendingWindow),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid_message_variants",
+			err:      fmt.Errorf("variant B: %w", fmt.Errorf("%w: variant percents must sum to 100, got 90", campaign.ErrInvalidMessageVariants)),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "variant_message_too_long",
+			err:      interactor.ErrMessageTooLong,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "phone_file_too_many_rows",
+			err:      fmt.Errorf("failed to parse phone numbers: %w", fmt.Errorf("%w: maximum %d rows", campaign.ErrPhoneFileTooManyRows, 500000)),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "pause_without_id",
+			err:      interactor.ErrPauseCampaignIDRequired,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "wrapped_entity_error",
+			err:      fmt.Errorf("failed to start: %w", campaign.ErrCannotStartCampaign),
+			expected: http.StatusConflict,
+		},
+		{
+			name:     "pause_unknown_campaign",
+			err:      fmt.Errorf("%w: %w", interactor.ErrPauseCampaignNotFound, campaign.ErrCampaignNotFound),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "resume_unknown_campaign",
+			err:      fmt.Errorf("%w: %w", interactor.ErrResumeCampaignNotFound, campaign.ErrCampaignNotFound),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "retry_unknown_campaign",
+			err:      fmt.Errorf("%w: %w", interactor.ErrRetryCampaignNotFound, campaign.ErrCampaignNotFound),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "stats_of_unknown_campaign",
+			err:      fmt.Errorf("%w: %w", interactor.ErrStatsCampaignNotFound, campaign.ErrCampaignNotFound),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "retry_campaign_lookup_failed",
+			err:      fmt.Errorf("%w: %w", interactor.ErrRetryCampaignNotFound, errors.New("connection refused")),
+			expected: http.StatusInternalServerError,
+		},
+		{
+			name:     "unknown_error",
+			err:      errors.New("connection refused"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, presenter.mapErrorToStatusCode(tc.err))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package presenters
+
+import (
+	"errors"
+	"net/http"
+	"whatsapp-service/internal/adapters/converter"
+	"whatsapp-service/internal/delivery/http/response"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/interactor"
+)
+
+// OptOutPresenterInterface определяет интерфейс для presenter стоп-листа
+type OptOutPresenterInterface interface {
+	// UseCase responses
+	PresentAddOptOutSuccess(w http.ResponseWriter, ucResponse *dto.AddOptOutResponse)
+	PresentRemoveOptOutSuccess(w http.ResponseWriter)
+	PresentListOptOutsSuccess(w http.ResponseWriter, ucResponse *dto.ListOptOutsResponse)
+	PresentImportOptOutsSuccess(w http.ResponseWriter, ucResponse *dto.ImportOptOutsResponse)
+
+	// Error responses
+	PresentValidationError(w http.ResponseWriter, err error)
+	PresentError(w http.ResponseWriter, statusCode int, message string)
+	PresentUseCaseError(w http.ResponseWriter, err error)
+}
+
+// OptOutPresenter обрабатывает представление данных стоп-листа
+type OptOutPresenter struct {
+	converter converter.OptOutConverter
+}
+
+// NewOptOutPresenter создает новый экземпляр presenter
+func NewOptOutPresenter(converter converter.OptOutConverter) *OptOutPresenter {
+	return &OptOutPresenter{
+		converter: converter,
+	}
+}
+
+// PresentAddOptOutSuccess представляет успешный ответ на добавление номера.
+// Новый номер отдается со статусом 201, уже существующий — 200
+func (p *OptOutPresenter) PresentAddOptOutSuccess(w http.ResponseWriter, ucResponse *dto.AddOptOutResponse) {
+	statusCode := http.StatusOK
+	if ucResponse.Added {
+		statusCode = http.StatusCreated
+	}
+	response.WriteJSON(w, statusCode, p.converter.ToAddOptOutResponse(ucResponse))
+}
+
+// PresentRemoveOptOutSuccess представляет успешный ответ на удаление номера
+func (p *OptOutPresenter) PresentRemoveOptOutSuccess(w http.ResponseWriter) {
+	responseData := map[string]interface{}{
+		"message": "Номер удален из стоп-листа",
+	}
+	response.WriteJSON(w, http.StatusOK, responseData)
+}
+
+// PresentListOptOutsSuccess представляет страницу стоп-листа
+func (p *OptOutPresenter) PresentListOptOutsSuccess(w http.ResponseWriter, ucResponse *dto.ListOptOutsResponse) {
+	response.WriteJSON(w, http.StatusOK, p.converter.ToListOptOutsResponse(ucResponse))
+}
+
+// PresentImportOptOutsSuccess представляет результат загрузки номеров
+func (p *OptOutPresenter) PresentImportOptOutsSuccess(w http.ResponseWriter, ucResponse *dto.ImportOptOutsResponse) {
+	response.WriteJSON(w, http.StatusOK, p.converter.ToImportOptOutsResponse(ucResponse))
+}
+
+// PresentValidationError представляет ошибку валидации
+func (p *OptOutPresenter) PresentValidationError(w http.ResponseWriter, err error) {
+	response.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+// PresentError представляет общую ошибку
+func (p *OptOutPresenter) PresentError(w http.ResponseWriter, statusCode int, message string) {
+	response.WriteError(w, statusCode, message)
+}
+
+// PresentUseCaseError представляет ошибку use case
+func (p *OptOutPresenter) PresentUseCaseError(w http.ResponseWriter, err error) {
+	response.WriteError(w, p.mapErrorToStatusCode(err), err.Error())
+}
+
+// mapErrorToStatusCode преобразует ошибку UseCase в HTTP статус код
+func (p *OptOutPresenter) mapErrorToStatusCode(err error) int {
+	switch {
+	case errors.Is(err, campaign.ErrInvalidPhoneNumber),
+		errors.Is(err, campaign.ErrUnsupportedPhoneFile),
+		errors.Is(err, campaign.ErrPhoneFileSheetNotFound),
+		errors.Is(err, campaign.ErrPhoneFileTooManyRows),
+		errors.Is(err, interactor.ErrOptOutReasonTooLong),
+		errors.Is(err, interactor.ErrInvalidOptOutsSearch),
+		errors.Is(err, interactor.ErrInvalidOptOutPagination),
+		errors.Is(err, interactor.ErrNoOptOutNumbers),
+		errors.Is(err, interactor.ErrTooManyOptOutNumbers),
+		errors.Is(err, interactor.ErrParseOptOutFile):
+		return http.StatusBadRequest
+	case errors.Is(err, campaign.ErrPhoneFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, campaign.ErrOptOutNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
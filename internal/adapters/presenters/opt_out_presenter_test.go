@@ -0,0 +1,65 @@
+package presenters
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/interactor"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestOptOutPresenter_MapErrorToStatusCode проверяет статусы ошибок загрузки и ведения стоп-листа
+func TestOptOutPresenter_MapErrorToStatusCode(t *testing.T) {
+	presenter := &OptOutPresenter{}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			name:     "file_too_many_rows",
+			err:      fmt.Errorf("%w: maximum %d rows", campaign.ErrPhoneFileTooManyRows, interactor.MaxOptOutImportFileNumbers),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "file_too_large",
+			err:      campaign.ErrPhoneFileTooLarge,
+			expected: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "unparsable_file",
+			err:      fmt.Errorf("%w: %s", interactor.ErrParseOptOutFile, "no valid phone numbers found"),
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "too_many_numbers",
+			err:      interactor.ErrTooManyOptOutNumbers,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid_phone",
+			err:      campaign.ErrInvalidPhoneNumber,
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "not_found",
+			err:      campaign.ErrOptOutNotFound,
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unknown_error",
+			err:      errors.New("connection refused"),
+			expected: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, presenter.mapErrorToStatusCode(tc.err))
+		})
+	}
+}
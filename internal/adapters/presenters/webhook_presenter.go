@@ -0,0 +1,53 @@
+package presenters
+
+import (
+	"net/http"
+	"whatsapp-service/internal/adapters/converter"
+	"whatsapp-service/internal/delivery/http/response"
+	ucDTO "whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// WebhookPresenterInterface определяет интерфейс для presenter входящих webhook
+type WebhookPresenterInterface interface {
+	// UseCase responses
+	PresentWhatsgateWebhookSuccess(w http.ResponseWriter, receipts *ucDTO.ProcessDeliveryReceiptsResponse, inbound *ucDTO.ProcessInboundMessagesResponse)
+
+	// Error responses
+	PresentValidationError(w http.ResponseWriter, err error)
+	PresentError(w http.ResponseWriter, statusCode int, message string)
+	PresentUseCaseError(w http.ResponseWriter, err error)
+}
+
+// WebhookPresenter обрабатывает представление ответов на webhook
+type WebhookPresenter struct {
+	converter converter.WebhookConverter
+}
+
+// NewWebhookPresenter создает новый экземпляр presenter
+func NewWebhookPresenter(converter converter.WebhookConverter) *WebhookPresenter {
+	return &WebhookPresenter{
+		converter: converter,
+	}
+}
+
+// PresentWhatsgateWebhookSuccess представляет успешный ответ на webhook WhatsGate
+func (p *WebhookPresenter) PresentWhatsgateWebhookSuccess(w http.ResponseWriter, receipts *ucDTO.ProcessDeliveryReceiptsResponse, inbound *ucDTO.ProcessInboundMessagesResponse) {
+	responseDTO := p.converter.ToWhatsgateWebhookResponse(receipts, inbound)
+	response.WriteJSON(w, http.StatusOK, responseDTO)
+}
+
+// PresentValidationError представляет ошибку валидации
+func (p *WebhookPresenter) PresentValidationError(w http.ResponseWriter, err error) {
+	response.WriteError(w, http.StatusBadRequest, err.Error())
+}
+
+// PresentError представляет общую ошибку
+func (p *WebhookPresenter) PresentError(w http.ResponseWriter, statusCode int, message string) {
+	response.WriteError(w, statusCode, message)
+}
+
+// PresentUseCaseError представляет ошибку use case.
+// Все ошибки отдаются как 500, чтобы провайдер повторил доставку webhook
+func (p *WebhookPresenter) PresentUseCaseError(w http.ResponseWriter, err error) {
+	response.WriteError(w, http.StatusInternalServerError, err.Error())
+}
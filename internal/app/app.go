@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"whatsapp-service/internal/entities/campaign"
 	campaignRepository "whatsapp-service/internal/entities/campaign/repository"
 	settingsRepository "whatsapp-service/internal/entities/settings/repository"
 	"whatsapp-service/internal/interfaces"
@@ -15,16 +16,22 @@ import (
 	"whatsapp-service/internal/delivery/http/handlers"
 	"whatsapp-service/internal/infrastructure/database/postgres"
 	"whatsapp-service/internal/infrastructure/dispatcher/messaging"
+	"whatsapp-service/internal/infrastructure/exporters"
 	"whatsapp-service/internal/infrastructure/gateways/retailcrm/client"
 	retailcrmPorts "whatsapp-service/internal/infrastructure/gateways/retailcrm/ports"
 	retailcrmService "whatsapp-service/internal/infrastructure/gateways/retailcrm/service"
 	"whatsapp-service/internal/infrastructure/gateways/whatsapp/dynamic/whatsgate"
 	zaplogger "whatsapp-service/internal/infrastructure/logger/zap"
+	"whatsapp-service/internal/infrastructure/parsers"
+	csvParser "whatsapp-service/internal/infrastructure/parsers/csv"
 	"whatsapp-service/internal/infrastructure/parsers/excel"
 	"whatsapp-service/internal/infrastructure/registry"
 	campaignRepositoryImpl "whatsapp-service/internal/infrastructure/repositories/campaign"
 	settingsRepositoryImpl "whatsapp-service/internal/infrastructure/repositories/settings"
+	"whatsapp-service/internal/infrastructure/scheduler"
 	"whatsapp-service/internal/infrastructure/services/ratelimiter"
+	localStorage "whatsapp-service/internal/infrastructure/storage/local"
+	s3Storage "whatsapp-service/internal/infrastructure/storage/s3"
 	campaignInteractor "whatsapp-service/internal/usecases/campaigns/interactor"
 	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
 	campaignPorts "whatsapp-service/internal/usecases/campaigns/ports"
@@ -43,9 +50,14 @@ type Infrastructure struct {
 	Database              *pgxpool.Pool
 	Logger                interfaces.Logger
 	CampaignRepo          campaignRepository.CampaignRepository
+	CampaignStatsRepo     campaignRepository.CampaignStatsRepository
+	OptOutRepo            campaignRepository.OptOutRepository
 	WhatsgateSettingsRepo settingsRepository.WhatsGateSettingsRepository
 	RetailCRMSettingsRepo settingsRepository.RetailCRMSettingsRepository
-	FileParser            campaignPorts.FileParser
+	PhoneNormalizer       *campaign.PhoneNormalizer
+	FileParsers           campaignPorts.FileParserSelector
+	ResultsExporter       campaignPorts.ResultsExporter
+	MediaStorage          campaignPorts.MediaStorage
 	MessageGateway        interfaces.MessageGateway
 	GlobalRateLimiter     messaging.GlobalRateLimiter
 	Dispatcher            campaignPorts.Dispatcher
@@ -56,6 +68,9 @@ type Infrastructure struct {
 // UseCases содержит все use case зависимости
 type UseCases struct {
 	Campaign          campaignInterfaces.CampaignUseCase
+	CampaignStats     campaignInterfaces.CampaignStatsUseCase
+	CampaignDelivery  campaignInterfaces.CampaignDeliveryUseCase
+	OptOut            campaignInterfaces.OptOutUseCase
 	WhatsgateSettings settingsInterfaces.WhatsgateSettingsUseCase
 	RetailCRMSettings settingsInterfaces.RetailCRMSettingsUseCase
 	Message           messagingInterfaces.MessageUseCase
@@ -69,11 +84,15 @@ type Adapters struct {
 	RetailCRMSettingsConverter converter.RetailCRMSettingsConverter
 	MessagingConverter         converter.MessagingConverter
 	RetailCRMConverter         converter.RetailCRMConverter
+	WebhookConverter           converter.WebhookConverter
+	OptOutConverter            converter.OptOutConverter
 	CampaignPresenter          presenters.CampaignPresenterInterface
 	WhatsgateSettingsPresenter presenters.WhatsgateSettingsPresenterInterface
 	RetailCRMSettingsPresenter presenters.RetailCRMSettingsPresenterInterface
 	MessagingPresenter         presenters.MessagingPresenterInterface
 	RetailCRMPresenter         presenters.RetailCRMPresenterInterface
+	WebhookPresenter           presenters.WebhookPresenterInterface
+	OptOutPresenter            presenters.OptOutPresenterInterface
 }
 
 // Handlers содержит все HTTP обработчики
@@ -84,12 +103,16 @@ type Handlers struct {
 	Messaging         *handlers.MessagingHandler
 	Health            *handlers.HealthHandler
 	RetailCRM         *handlers.RetailCRMHandler
+	Webhooks          *handlers.WebhooksHandler
+	OptOuts           *handlers.OptOutsHandler
 }
 
 // App инкапсулирует все зависимости и умеет запускаться/останавливаться.
 type App struct {
 	cfg            *config.Config
 	infrastructure *Infrastructure
+	useCases       *UseCases
+	scheduler      *scheduler.CampaignScheduler
 	server         *http.HTTPServer
 }
 
@@ -101,6 +124,18 @@ func NewInfrastructure(cfg *config.Config) (*Infrastructure, error) {
 		return nil, fmt.Errorf("init logger: %w", err)
 	}
 
+	// Нормализация номеров телефонов
+	phoneNormalizer, err := campaign.NewPhoneNormalizer(cfg.Phone.DefaultCountry)
+	if err != nil {
+		return nil, fmt.Errorf("init phone normalizer: %w", err)
+	}
+
+	// Хранилище медиафайлов
+	mediaStorage, err := newMediaStorage(cfg.Media)
+	if err != nil {
+		return nil, fmt.Errorf("init media storage: %w", err)
+	}
+
 	// БД
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -111,12 +146,15 @@ func NewInfrastructure(cfg *config.Config) (*Infrastructure, error) {
 
 	// Репозитории
 	var campaignRepo campaignRepository.CampaignRepository = campaignRepositoryImpl.NewPostgresCampaignRepository(pool, sharedLogger)
+	var campaignStatsRepo campaignRepository.CampaignStatsRepository = campaignRepositoryImpl.NewPostgresCampaignStatsRepository(pool, sharedLogger)
+	var optOutRepo campaignRepository.OptOutRepository = campaignRepositoryImpl.NewPostgresOptOutRepository(pool, sharedLogger)
 	var whatsgateSettingsRepo settingsRepository.WhatsGateSettingsRepository = settingsRepositoryImpl.NewPostgresWhatsGateSettingsRepository(pool, sharedLogger)
 	var retailCRMSettingsRepo settingsRepository.RetailCRMSettingsRepository = settingsRepositoryImpl.NewPostgresRetailCRMSettingsRepository(pool, sharedLogger)
 
 	// Утилитарные сервисы
 	var globalRateLimiter messaging.GlobalRateLimiter = ratelimiter.NewGlobalMemoryRateLimiter()
-	var fileParser campaignPorts.FileParser = excel.NewExcelParser()
+	var fileParsers campaignPorts.FileParserSelector = parsers.NewSelector(excel.NewExcelParser(phoneNormalizer), csvParser.NewCSVParser(phoneNormalizer))
+	var resultsExporter campaignPorts.ResultsExporter = exporters.NewResultsExporter()
 	var messageGateway interfaces.MessageGateway = whatsgate.NewSettingsAwareGateway(whatsgateSettingsRepo)
 	var dispatcherSvc campaignPorts.Dispatcher = messaging.NewDispatcher(messageGateway, globalRateLimiter, sharedLogger)
 	var campaignRegistry campaignPorts.CampaignRegistry = registry.NewInMemoryCampaignRegistry()
@@ -129,9 +167,14 @@ func NewInfrastructure(cfg *config.Config) (*Infrastructure, error) {
 		Database:              pool,
 		Logger:                sharedLogger,
 		CampaignRepo:          campaignRepo,
+		CampaignStatsRepo:     campaignStatsRepo,
+		OptOutRepo:            optOutRepo,
 		WhatsgateSettingsRepo: whatsgateSettingsRepo,
 		RetailCRMSettingsRepo: retailCRMSettingsRepo,
-		FileParser:            fileParser,
+		PhoneNormalizer:       phoneNormalizer,
+		FileParsers:           fileParsers,
+		ResultsExporter:       resultsExporter,
+		MediaStorage:          mediaStorage,
 		MessageGateway:        messageGateway,
 		GlobalRateLimiter:     globalRateLimiter,
 		Dispatcher:            dispatcherSvc,
@@ -140,8 +183,23 @@ func NewInfrastructure(cfg *config.Config) (*Infrastructure, error) {
 	}, nil
 }
 
+// newMediaStorage создает хранилище медиафайлов выбранного в конфигурации типа
+func newMediaStorage(cfg config.MediaConfig) (campaignPorts.MediaStorage, error) {
+	if cfg.Storage == "s3" {
+		return s3Storage.NewStorage(s3Storage.Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Bucket:    cfg.S3.Bucket,
+			Region:    cfg.S3.Region,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+			Timeout:   cfg.S3.Timeout,
+		})
+	}
+	return localStorage.NewStorage(cfg.LocalPath)
+}
+
 // NewUseCases создает все use case зависимости
-func NewUseCases(infra *Infrastructure) *UseCases {
+func NewUseCases(cfg *config.Config, infra *Infrastructure) *UseCases {
 	// Сначала создаем RetailCRM usecase
 	var retailCRMUseCase retailcrmInterfaces.RetailCRMUseCase = retailcrmInteractor.NewRetailCRMInteractor(
 		infra.RetailCRMGateway,
@@ -149,12 +207,50 @@ func NewUseCases(infra *Infrastructure) *UseCases {
 	)
 
 	// Use Cases
+	var campaignStatsUseCase campaignInterfaces.CampaignStatsUseCase = campaignInteractor.NewCampaignStatsInteractor(
+		infra.CampaignRepo,
+		infra.CampaignStatsRepo,
+		infra.Logger,
+	)
+
 	var campaignUseCase campaignInterfaces.CampaignUseCase = campaignInteractor.NewCampaignInteractor(
 		infra.CampaignRepo,
+		infra.OptOutRepo,
 		infra.Dispatcher,
 		infra.CampaignRegistry,
-		infra.FileParser,
+		infra.FileParsers,
+		infra.PhoneNormalizer,
+		infra.ResultsExporter,
+		infra.MediaStorage,
 		retailCRMUseCase, // Используем RetailCRM usecase
+		campaignStatsUseCase,
+		campaignInteractor.CampaignOptions{
+			MaxConcurrentCampaigns: cfg.Campaigns.MaxConcurrentCampaigns,
+			FrequencyCapMessages:   cfg.Campaigns.FrequencyCapMessages,
+			FrequencyCapWindow:     cfg.Campaigns.FrequencyCapWindow,
+			PhoneFileMaxSize:       cfg.Campaigns.PhoneFileMaxSize,
+			PhoneFileMaxRows:       cfg.Campaigns.PhoneFileMaxRows,
+		},
+		infra.Logger,
+	)
+
+	var campaignDeliveryUseCase campaignInterfaces.CampaignDeliveryUseCase = campaignInteractor.NewCampaignDeliveryInteractor(
+		infra.CampaignRepo,
+		campaignStatsUseCase,
+		infra.Logger,
+	)
+
+	var optOutUseCase campaignInterfaces.OptOutUseCase = campaignInteractor.NewOptOutInteractor(
+		infra.OptOutRepo,
+		infra.CampaignRepo,
+		infra.Dispatcher,
+		infra.MessageGateway,
+		infra.FileParsers,
+		infra.PhoneNormalizer,
+		campaignInteractor.OptOutOptions{
+			Keywords:            cfg.OptOut.Keywords,
+			ConfirmationMessage: cfg.OptOut.ConfirmationMessage,
+		},
 		infra.Logger,
 	)
 
@@ -175,6 +271,9 @@ func NewUseCases(infra *Infrastructure) *UseCases {
 
 	return &UseCases{
 		Campaign:          campaignUseCase,
+		CampaignStats:     campaignStatsUseCase,
+		CampaignDelivery:  campaignDeliveryUseCase,
+		OptOut:            optOutUseCase,
 		WhatsgateSettings: whatsgateSettingsUseCase,
 		RetailCRMSettings: retailCRMSettingsUseCase,
 		Message:           testMessageUseCase,
@@ -190,6 +289,8 @@ func NewAdapters() *Adapters {
 	var retailCRMSettingsConverter converter.RetailCRMSettingsConverter = converter.NewRetailCRMSettingsConverter()
 	var messagingConverter converter.MessagingConverter = converter.NewMessagingConverter()
 	var retailCRMConverter converter.RetailCRMConverter = converter.NewRetailCRMConverter()
+	var webhookConverter converter.WebhookConverter = converter.NewWebhookConverter()
+	var optOutConverter converter.OptOutConverter = converter.NewOptOutConverter()
 
 	// Presenters
 	var campaignPresenter presenters.CampaignPresenterInterface = presenters.NewCampaignPresenter(campaignConverter)
@@ -197,6 +298,8 @@ func NewAdapters() *Adapters {
 	var retailCRMSettingsPresenter presenters.RetailCRMSettingsPresenterInterface = presenters.NewRetailCRMSettingsPresenter(retailCRMSettingsConverter)
 	var messagingPresenter presenters.MessagingPresenterInterface = presenters.NewMessagingPresenter(messagingConverter)
 	var retailCRMPresenter presenters.RetailCRMPresenterInterface = presenters.NewRetailCRMPresenter(retailCRMConverter)
+	var webhookPresenter presenters.WebhookPresenterInterface = presenters.NewWebhookPresenter(webhookConverter)
+	var optOutPresenter presenters.OptOutPresenterInterface = presenters.NewOptOutPresenter(optOutConverter)
 
 	return &Adapters{
 		CampaignConverter:          campaignConverter,
@@ -204,19 +307,24 @@ func NewAdapters() *Adapters {
 		RetailCRMSettingsConverter: retailCRMSettingsConverter,
 		MessagingConverter:         messagingConverter,
 		RetailCRMConverter:         retailCRMConverter,
+		WebhookConverter:           webhookConverter,
+		OptOutConverter:            optOutConverter,
 		CampaignPresenter:          campaignPresenter,
 		WhatsgateSettingsPresenter: whatsgateSettingsPresenter,
 		RetailCRMSettingsPresenter: retailCRMSettingsPresenter,
 		MessagingPresenter:         messagingPresenter,
 		RetailCRMPresenter:         retailCRMPresenter,
+		WebhookPresenter:           webhookPresenter,
+		OptOutPresenter:            optOutPresenter,
 	}
 }
 
 // NewHandlers создает все HTTP обработчики
-func NewHandlers(useCases *UseCases, adapters *Adapters, infra *Infrastructure) *Handlers {
+func NewHandlers(cfg *config.Config, useCases *UseCases, adapters *Adapters, infra *Infrastructure) *Handlers {
 	// Handlers
 	campaignHandler := handlers.NewCampaignsHandler(
 		useCases.Campaign,
+		useCases.CampaignStats,
 		adapters.CampaignPresenter,
 		adapters.CampaignConverter,
 		infra.Logger,
@@ -250,6 +358,22 @@ func NewHandlers(useCases *UseCases, adapters *Adapters, infra *Infrastructure)
 		infra.Logger,
 	)
 
+	webhooksHandler := handlers.NewWebhooksHandler(
+		useCases.CampaignDelivery,
+		useCases.OptOut,
+		adapters.WebhookPresenter,
+		adapters.WebhookConverter,
+		cfg.Webhooks.WhatsgateSecret,
+		infra.Logger,
+	)
+
+	optOutsHandler := handlers.NewOptOutsHandler(
+		useCases.OptOut,
+		adapters.OptOutPresenter,
+		adapters.OptOutConverter,
+		infra.Logger,
+	)
+
 	// Health Handler
 	healthHandler := handlers.NewHealthHandler(
 		infra.Logger,
@@ -264,6 +388,8 @@ func NewHandlers(useCases *UseCases, adapters *Adapters, infra *Infrastructure)
 		Messaging:         messagingHandler,
 		RetailCRM:         retailCRMHandler,
 		Health:            healthHandler,
+		Webhooks:          webhooksHandler,
+		OptOuts:           optOutsHandler,
 	}
 }
 
@@ -276,13 +402,13 @@ func New(cfg *config.Config) (*App, error) {
 	}
 
 	// Use Cases
-	useCases := NewUseCases(infra)
+	useCases := NewUseCases(cfg, infra)
 
 	// Adapters
 	adapters := NewAdapters()
 
 	// Handlers
-	h := NewHandlers(useCases, adapters, infra)
+	h := NewHandlers(cfg, useCases, adapters, infra)
 
 	// HTTP сервер
 	httpSrv := createHTTPServer(
@@ -293,12 +419,19 @@ func New(cfg *config.Config) (*App, error) {
 		h.RetailCRMSettings,
 		h.RetailCRM,
 		h.Health,
+		h.Webhooks,
+		h.OptOuts,
 		infra.Logger,
 	)
 
+	// Планировщик отложенного запуска кампаний
+	campaignScheduler := scheduler.NewCampaignScheduler(useCases.Campaign, cfg.Campaigns.SchedulerInterval, infra.Logger)
+
 	return &App{
 		cfg:            cfg,
 		infrastructure: infra,
+		useCases:       useCases,
+		scheduler:      campaignScheduler,
 		server:         httpSrv,
 	}, nil
 }
@@ -312,6 +445,8 @@ func createHTTPServer(
 	retailCRMSettingsHandler *handlers.RetailCRMSettingsHandler,
 	retailCRMHandler *handlers.RetailCRMHandler,
 	healthHandler *handlers.HealthHandler,
+	webhooksHandler *handlers.WebhooksHandler,
+	optOutsHandler *handlers.OptOutsHandler,
 	logger interfaces.Logger,
 ) *http.HTTPServer {
 	return http.NewHTTPServer(
@@ -322,6 +457,8 @@ func createHTTPServer(
 		retailCRMSettingsHandler,
 		retailCRMHandler,
 		healthHandler,
+		webhooksHandler,
+		optOutsHandler,
 		logger,
 	)
 }
@@ -335,6 +472,9 @@ func (a *App) Start(ctx context.Context) error {
 		a.infrastructure.Logger.Error("failed to recover orphaned campaigns", "error", err)
 	}
 
+	a.infrastructure.Logger.Info("starting campaign scheduler")
+	a.scheduler.Start(ctx)
+
 	a.infrastructure.Logger.Info("HTTP server starting", "port", a.cfg.HTTP.Port)
 	return a.server.Start()
 }
@@ -343,6 +483,10 @@ func (a *App) Start(ctx context.Context) error {
 func (a *App) Stop(ctx context.Context) error {
 	a.infrastructure.Logger.Info("stopping application")
 
+	if err := a.scheduler.Stop(ctx); err != nil {
+		a.infrastructure.Logger.Error("failed to stop campaign scheduler", "error", err)
+	}
+
 	if err := a.gracefulShutdownCampaigns(ctx); err != nil {
 		a.infrastructure.Logger.Error("failed to gracefully shutdown campaigns", "error", err)
 	}
@@ -364,7 +508,7 @@ func (a *App) Stop(ctx context.Context) error {
 	return nil
 }
 
-// gracefulShutdownCampaigns останавливает активные кампании
+// gracefulShutdownCampaigns помечает запущенные кампании как прерванные, чтобы возобновить их после перезапуска
 func (a *App) gracefulShutdownCampaigns(ctx context.Context) error {
 	a.infrastructure.Logger.Info("gracefully shutting down campaigns")
 
@@ -373,10 +517,10 @@ func (a *App) gracefulShutdownCampaigns(ctx context.Context) error {
 		return fmt.Errorf("failed to get active campaigns: %w", err)
 	}
 
-	for _, campaign := range activeCampaigns {
-		if err := a.updateCampaignStatusOnShutdown(ctx, campaign.ID()); err != nil {
+	for _, c := range activeCampaigns {
+		if err := a.interruptCampaign(ctx, c); err != nil {
 			a.infrastructure.Logger.Error("failed to update campaign status on shutdown",
-				"campaign_id", campaign.ID(), "error", err)
+				"campaign_id", c.ID(), "error", err)
 		}
 	}
 
@@ -384,41 +528,64 @@ func (a *App) gracefulShutdownCampaigns(ctx context.Context) error {
 	return nil
 }
 
-// updateCampaignStatusOnShutdown обновляет статус кампании при остановке
-func (a *App) updateCampaignStatusOnShutdown(ctx context.Context, campaignID string) error {
-	campaign, err := a.infrastructure.CampaignRepo.GetByID(ctx, campaignID)
-	if err != nil {
-		return fmt.Errorf("failed to get campaign: %w", err)
+// interruptCampaign переводит запущенную кампанию в статус "прервана"
+func (a *App) interruptCampaign(ctx context.Context, c *campaign.Campaign) error {
+	if c.Status() != campaign.CampaignStatusStarted {
+		return nil
 	}
 
-	if campaign.Status() == "started" {
-		if err := a.infrastructure.CampaignRepo.UpdateStatus(ctx, campaignID, "stopped"); err != nil {
-			return fmt.Errorf("failed to update campaign status: %w", err)
-		}
-		a.infrastructure.Logger.Info("campaign marked as stopped", "campaign_id", campaignID)
+	if err := c.Interrupt(); err != nil {
+		return err
 	}
 
+	if err := a.infrastructure.CampaignRepo.UpdateStatus(ctx, c.ID(), c.Status()); err != nil {
+		return fmt.Errorf("failed to update campaign status: %w", err)
+	}
+
+	a.infrastructure.Logger.Info("campaign marked as interrupted", "campaign_id", c.ID())
 	return nil
 }
 
-// recoverOrphanedCampaigns восстанавливает "orphaned" кампании
+// recoverOrphanedCampaigns помечает прерванными кампании, оставшиеся в статусе "started" после
+// аварийного завершения. Прерванные кампании возобновляет планировщик: первая проверка выполняется
+// сразу после старта, а кампании, не уместившиеся в лимит одновременных рассылок, — при следующих проверках
 func (a *App) recoverOrphanedCampaigns(ctx context.Context) error {
 	a.infrastructure.Logger.Info("recovering orphaned campaigns")
 
-	startedCampaigns, err := a.infrastructure.CampaignRepo.ListByStatus(ctx, "started", 100, 0)
+	startedCampaigns, err := a.listCampaignsByStatus(ctx, campaign.CampaignStatusStarted)
 	if err != nil {
 		return fmt.Errorf("failed to get started campaigns: %w", err)
 	}
 
-	for _, campaign := range startedCampaigns {
-		if err := a.infrastructure.CampaignRepo.UpdateStatus(ctx, campaign.ID(), "stopped"); err != nil {
-			a.infrastructure.Logger.Error("failed to mark orphaned campaign as stopped",
-				"campaign_id", campaign.ID(), "error", err)
-		} else {
-			a.infrastructure.Logger.Info("orphaned campaign marked as stopped", "campaign_id", campaign.ID())
+	interrupted := 0
+	for _, c := range startedCampaigns {
+		if err := a.interruptCampaign(ctx, c); err != nil {
+			a.infrastructure.Logger.Error("failed to mark orphaned campaign as interrupted",
+				"campaign_id", c.ID(), "error", err)
+			continue
 		}
+		interrupted++
 	}
 
-	a.infrastructure.Logger.Info("orphaned campaigns recovery completed", "count", len(startedCampaigns))
+	a.infrastructure.Logger.Info("orphaned campaigns recovery completed",
+		"orphaned", len(startedCampaigns), "interrupted", interrupted)
 	return nil
 }
+
+// listCampaignsByStatus возвращает все кампании со статусом status, читая список постранично.
+// Список читается целиком до изменения статусов, чтобы смещение страниц не сдвигалось
+func (a *App) listCampaignsByStatus(ctx context.Context, status campaign.CampaignStatus) ([]*campaign.Campaign, error) {
+	const pageSize = 100
+
+	var campaigns []*campaign.Campaign
+	for offset := 0; ; offset += pageSize {
+		page, err := a.infrastructure.CampaignRepo.ListByStatus(ctx, string(status), pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, page...)
+		if len(page) < pageSize {
+			return campaigns, nil
+		}
+	}
+}
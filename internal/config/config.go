@@ -17,6 +17,11 @@ type Config struct {
 	Database  DatabaseConfig  `yaml:"database" validate:"required"`
 	Logging   LoggingConfig   `yaml:"logging" validate:"required"`
 	RetailCRM RetailCRMConfig `yaml:"retailcrm"`
+	Campaigns CampaignsConfig `yaml:"campaigns"`
+	Webhooks  WebhooksConfig  `yaml:"webhooks"`
+	OptOut    OptOutConfig    `yaml:"opt_out"`
+	Phone     PhoneConfig     `yaml:"phone"`
+	Media     MediaConfig     `yaml:"media"`
 }
 
 type HTTPConfig struct {
@@ -70,6 +75,53 @@ type RetailCRMConfig struct {
 	RequestTimeout        time.Duration `yaml:"request_timeout" validate:"gt=0"`
 }
 
+// CampaignsConfig задает настройки кампаний.
+// Нулевой frequency_cap_messages отключает лимит частоты отправки на номер
+type CampaignsConfig struct {
+	MaxConcurrentCampaigns int           `yaml:"max_concurrent_campaigns" validate:"gte=1"`
+	SchedulerInterval      time.Duration `yaml:"scheduler_interval" validate:"gt=0"`
+	FrequencyCapMessages   int           `yaml:"frequency_cap_messages" validate:"gte=0"`
+	FrequencyCapWindow     time.Duration `yaml:"frequency_cap_window" validate:"gte=0"`
+	PhoneFileMaxSize       int64         `yaml:"phone_file_max_size" validate:"gte=1"`
+	PhoneFileMaxRows       int           `yaml:"phone_file_max_rows" validate:"gte=1"`
+}
+
+// WebhooksConfig задает настройки входящих webhook от провайдеров.
+// Пустой секрет отключает прием webhook WhatsGate
+type WebhooksConfig struct {
+	WhatsgateSecret string `yaml:"whatsgate_secret"`
+}
+
+// OptOutConfig задает настройки автоматической отписки по входящим сообщениям.
+// Пустое подтверждение отключает ответ отписавшемуся получателю
+type OptOutConfig struct {
+	Keywords            []string `yaml:"keywords" validate:"dive,required"`
+	ConfirmationMessage string   `yaml:"confirmation_message"`
+}
+
+// PhoneConfig задает правила разбора номеров телефонов.
+// Номера без кода страны считаются номерами DefaultCountry (код ISO 3166-1 alpha-2)
+type PhoneConfig struct {
+	DefaultCountry string `yaml:"default_country" validate:"required,len=2"`
+}
+
+// MediaConfig задает хранилище медиафайлов кампаний: локальная директория или S3-совместимое хранилище
+type MediaConfig struct {
+	Storage   string        `yaml:"storage" validate:"oneof=local s3"`
+	LocalPath string        `yaml:"local_path" validate:"required_if=Storage local"`
+	S3        MediaS3Config `yaml:"s3"`
+}
+
+// MediaS3Config задает подключение к S3-совместимому хранилищу (AWS S3, MinIO и т.д.)
+type MediaS3Config struct {
+	Endpoint  string        `yaml:"endpoint"`
+	Bucket    string        `yaml:"bucket"`
+	Region    string        `yaml:"region"`
+	AccessKey string        `yaml:"access_key"`
+	SecretKey string        `yaml:"secret_key"`
+	Timeout   time.Duration `yaml:"timeout" validate:"gte=0"`
+}
+
 // LoadConfig читает файл YAML, применяет дефолтные значения, перекрывает часть
 // настроек переменными окружения и валидирует итоговую структуру.
 // Если path пустой, пытается взять CONFIG_PATH, иначе "config.dev.yaml".
@@ -152,6 +204,85 @@ func overrideWithEnv(cfg *Config) {
 		cfg.Logging.Service = v
 	}
 
+	// Настройки кампаний
+	if v := os.Getenv("CAMPAIGNS_MAX_CONCURRENT"); v != "" {
+		if n, _ := strconv.Atoi(v); n > 0 {
+			cfg.Campaigns.MaxConcurrentCampaigns = n
+		}
+	}
+	if v := os.Getenv("CAMPAIGNS_SCHEDULER_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.Campaigns.SchedulerInterval = d
+		}
+	}
+	if v := os.Getenv("CAMPAIGNS_FREQUENCY_CAP_MESSAGES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			cfg.Campaigns.FrequencyCapMessages = n
+		}
+	}
+	if v := os.Getenv("CAMPAIGNS_FREQUENCY_CAP_WINDOW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.Campaigns.FrequencyCapWindow = d
+		}
+	}
+	if v := os.Getenv("CAMPAIGNS_PHONE_FILE_MAX_SIZE"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n > 0 {
+			cfg.Campaigns.PhoneFileMaxSize = n
+		}
+	}
+	if v := os.Getenv("CAMPAIGNS_PHONE_FILE_MAX_ROWS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Campaigns.PhoneFileMaxRows = n
+		}
+	}
+
+	// Настройки webhook
+	if v := os.Getenv("WHATSGATE_WEBHOOK_SECRET"); v != "" {
+		cfg.Webhooks.WhatsgateSecret = v
+	}
+
+	// Настройки отписки
+	if v := os.Getenv("OPT_OUT_KEYWORDS"); v != "" {
+		var keywords []string
+		for _, keyword := range strings.Split(v, ",") {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		cfg.OptOut.Keywords = keywords
+	}
+	if v := os.Getenv("OPT_OUT_CONFIRMATION_MESSAGE"); v != "" {
+		cfg.OptOut.ConfirmationMessage = v
+	}
+
+	// Настройки номеров телефонов
+	if v := os.Getenv("PHONE_DEFAULT_COUNTRY"); v != "" {
+		cfg.Phone.DefaultCountry = strings.ToUpper(v)
+	}
+
+	// Настройки хранилища медиафайлов
+	if v := os.Getenv("MEDIA_STORAGE"); v != "" {
+		cfg.Media.Storage = strings.ToLower(v)
+	}
+	if v := os.Getenv("MEDIA_LOCAL_PATH"); v != "" {
+		cfg.Media.LocalPath = v
+	}
+	if v := os.Getenv("MEDIA_S3_ENDPOINT"); v != "" {
+		cfg.Media.S3.Endpoint = v
+	}
+	if v := os.Getenv("MEDIA_S3_BUCKET"); v != "" {
+		cfg.Media.S3.Bucket = v
+	}
+	if v := os.Getenv("MEDIA_S3_REGION"); v != "" {
+		cfg.Media.S3.Region = v
+	}
+	if v := os.Getenv("MEDIA_S3_ACCESS_KEY"); v != "" {
+		cfg.Media.S3.AccessKey = v
+	}
+	if v := os.Getenv("MEDIA_S3_SECRET_KEY"); v != "" {
+		cfg.Media.S3.SecretKey = v
+	}
+
 	// Автоматическое определение окружения
 	if v := os.Getenv("ENV"); v != "" {
 		cfg.Logging.Env = strings.ToLower(v)
@@ -223,6 +354,47 @@ func (c *Config) setDefaults() {
 	if c.RetailCRM.RequestTimeout == 0 {
 		c.RetailCRM.RequestTimeout = 60 * time.Second
 	}
+
+	// Дефолты кампаний
+	if c.Campaigns.MaxConcurrentCampaigns == 0 {
+		c.Campaigns.MaxConcurrentCampaigns = 10
+	}
+	if c.Campaigns.SchedulerInterval == 0 {
+		c.Campaigns.SchedulerInterval = 30 * time.Second
+	}
+	if c.Campaigns.PhoneFileMaxSize == 0 {
+		c.Campaigns.PhoneFileMaxSize = 50 << 20
+	}
+	if c.Campaigns.PhoneFileMaxRows == 0 {
+		c.Campaigns.PhoneFileMaxRows = 500000
+	}
+	if c.Campaigns.FrequencyCapMessages > 0 && c.Campaigns.FrequencyCapWindow == 0 {
+		c.Campaigns.FrequencyCapWindow = 7 * 24 * time.Hour
+	}
+
+	// Дефолты отписки
+	if len(c.OptOut.Keywords) == 0 {
+		c.OptOut.Keywords = []string{"stop", "unsubscribe", "стоп", "отписаться"}
+	}
+
+	// Дефолты номеров телефонов
+	if c.Phone.DefaultCountry == "" {
+		c.Phone.DefaultCountry = "RU"
+	}
+
+	// Дефолты хранилища медиафайлов
+	if c.Media.Storage == "" {
+		c.Media.Storage = "local"
+	}
+	if c.Media.LocalPath == "" {
+		c.Media.LocalPath = "./data/media"
+	}
+	if c.Media.S3.Region == "" {
+		c.Media.S3.Region = "us-east-1"
+	}
+	if c.Media.S3.Timeout == 0 {
+		c.Media.S3.Timeout = 30 * time.Second
+	}
 }
 
 // HTTPListenAddress возвращает host:port строку.
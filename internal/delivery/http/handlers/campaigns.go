@@ -3,22 +3,36 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"whatsapp-service/internal/adapters/converter"
 	httpDTO "whatsapp-service/internal/adapters/dto/campaign"
 	"whatsapp-service/internal/adapters/presenters"
+	"whatsapp-service/internal/delivery/http/response"
 	"whatsapp-service/internal/interfaces"
 	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 )
 
+// exportWriteTimeout таймаут записи для выгрузки результатов кампании
+const exportWriteTimeout = 10 * time.Minute
+
+// exportContentTypes MIME-типы поддерживаемых форматов выгрузки
+var exportContentTypes = map[string]string{
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"csv":  "text/csv; charset=utf-8",
+}
+
 // CampaignsHandler обрабатывает все HTTP запросы связанные с кампаниями
 type CampaignsHandler struct {
 	campaignUseCase campaignInterfaces.CampaignUseCase
+	statsUseCase    campaignInterfaces.CampaignStatsUseCase
 	presenter       presenters.CampaignPresenterInterface
 	converter       converter.CampaignConverter
 	logger          interfaces.Logger
@@ -27,12 +41,14 @@ type CampaignsHandler struct {
 // NewCampaignsHandler создает новый обработчик кампаний
 func NewCampaignsHandler(
 	campaignUseCase campaignInterfaces.CampaignUseCase,
+	statsUseCase campaignInterfaces.CampaignStatsUseCase,
 	presenter presenters.CampaignPresenterInterface,
 	converter converter.CampaignConverter,
 	logger interfaces.Logger,
 ) *CampaignsHandler {
 	return &CampaignsHandler{
 		campaignUseCase: campaignUseCase,
+		statsUseCase:    statsUseCase,
 		presenter:       presenter,
 		converter:       converter,
 		logger:          logger,
@@ -74,6 +90,70 @@ func (h *CampaignsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	h.presenter.PresentCreateCampaignSuccess(w, ucResp)
 }
 
+// PreviewFile анализирует файл с номерами (поле numbers_file) до создания кампании:
+// листы, колонки, предлагаемую колонку с номерами и первые строки листа sheet
+func (h *CampaignsHandler) PreviewFile(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		h.presenter.PresentValidationError(w, errors.New("invalid multipart form"))
+		return
+	}
+
+	phoneFile, phoneHeader, err := r.FormFile("numbers_file")
+	if err != nil {
+		h.presenter.PresentValidationError(w, errors.New("phone file is required"))
+		return
+	}
+	phoneFile.Close()
+
+	ucReq := h.converter.ToPreviewPhoneFileRequest(phoneHeader, strings.TrimSpace(r.FormValue("sheet")))
+
+	analysis, err := h.campaignUseCase.PreviewPhoneFile(r.Context(), ucReq)
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentPhoneFilePreviewSuccess(w, analysis)
+}
+
+// PreviewAudience рассчитывает итоговую аудиторию кампании по тем же полям формы, что и Create,
+// без создания кампании: файл numbers_file не обязателен, если переданы additional_numbers
+func (h *CampaignsHandler) PreviewAudience(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		h.presenter.PresentValidationError(w, errors.New("invalid multipart form"))
+		return
+	}
+
+	httpReq := httpDTO.PreviewAudienceRequest{
+		AdditionalPhones:     parseArrayParam(r, "additional_numbers"),
+		ExcludePhones:        parseArrayParam(r, "exclude_numbers"),
+		SelectedCategoryName: r.FormValue("selected_category_name"),
+		PhoneSheet:           strings.TrimSpace(r.FormValue("numbers_sheet")),
+		PhoneAllSheets:       r.FormValue("numbers_all_sheets") == "on",
+		PhoneColumn:          strings.TrimSpace(r.FormValue("numbers_column")),
+	}
+	if httpReq.PhoneSheet != "" && httpReq.PhoneAllSheets {
+		h.presenter.PresentValidationError(w, NewCampaignValidationError("numbers_sheet", "Sheet cannot be combined with numbers_all_sheets"))
+		return
+	}
+
+	var phoneHeader *multipart.FileHeader
+	if phoneFile, header, err := r.FormFile("numbers_file"); err == nil {
+		phoneFile.Close()
+		phoneHeader = header
+	}
+
+	ucReq := h.converter.ToPreviewAudienceRequest(httpReq, phoneHeader)
+
+	ucResp, err := h.campaignUseCase.PreviewAudience(r.Context(), ucReq)
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentPreviewAudienceSuccess(w, ucResp)
+}
+
 // Start запускает кампанию
 func (h *CampaignsHandler) Start(w http.ResponseWriter, r *http.Request) {
 	campaignID := chi.URLParam(r, "id")
@@ -93,6 +173,69 @@ func (h *CampaignsHandler) Start(w http.ResponseWriter, r *http.Request) {
 	h.presenter.PresentStartCampaignSuccess(w, ucResp)
 }
 
+// Pause приостанавливает запущенную кампанию
+func (h *CampaignsHandler) Pause(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToPauseCampaignRequest(campaignID)
+
+	ucResp, err := h.campaignUseCase.Pause(r.Context(), ucReq)
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentPauseCampaignSuccess(w, ucResp)
+}
+
+// Resume возобновляет приостановленную или прерванную кампанию
+func (h *CampaignsHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToResumeCampaignRequest(campaignID)
+
+	ucResp, err := h.campaignUseCase.Resume(r.Context(), ucReq)
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentResumeCampaignSuccess(w, ucResp)
+}
+
+// RetryFailed повторно отправляет сообщения на неудачные номера завершенной кампании
+func (h *CampaignsHandler) RetryFailed(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	errorContains, err := h.parseRetryErrorFilter(r)
+	if err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToRetryFailedRequest(campaignID, errorContains)
+
+	ucResp, err := h.campaignUseCase.RetryFailed(r.Context(), ucReq)
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentRetryFailedSuccess(w, ucResp)
+}
+
 // Cancel отменяет кампанию
 func (h *CampaignsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 	campaignID := chi.URLParam(r, "id")
@@ -163,6 +306,204 @@ func (h *CampaignsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	h.presenter.PresentGetCampaignByIDSuccess(w, ucResp)
 }
 
+// Stats возвращает дневную статистику отправки кампании за период
+func (h *CampaignsHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+
+	h.logger.Info("get campaign stats request started",
+		"campaign_id", campaignID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	from, to, err := h.parseStatsPeriod(r)
+	if err != nil {
+		h.logger.Warn("get campaign stats validation failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToGetCampaignStatsRequest(campaignID, from, to)
+
+	ucResp, err := h.statsUseCase.GetStats(r.Context(), ucReq)
+	if err != nil {
+		h.logger.Error("get campaign stats usecase failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.logger.Info("get campaign stats request completed successfully",
+		"campaign_id", campaignID,
+		"from", ucResp.From,
+		"to", ucResp.To,
+		"sent", ucResp.Total.Sent,
+	)
+
+	h.presenter.PresentCampaignStatsSuccess(w, ucResp)
+}
+
+// Recipients возвращает страницу номеров кампании с фильтром по статусу и поиском по номеру
+func (h *CampaignsHandler) Recipients(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+
+	h.logger.Info("list campaign recipients request started",
+		"campaign_id", campaignID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query_params", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	params, err := h.parseRecipientsParams(r)
+	if err != nil {
+		h.logger.Warn("list campaign recipients validation failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToListRecipientsRequest(campaignID, params)
+
+	ucResp, err := h.campaignUseCase.ListRecipients(r.Context(), ucReq)
+	if err != nil {
+		h.logger.Error("list campaign recipients usecase failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.logger.Info("list campaign recipients request completed successfully",
+		"campaign_id", campaignID,
+		"status", params.Status,
+		"total", ucResp.Total,
+		"returned", len(ucResp.Recipients),
+	)
+
+	h.presenter.PresentListRecipientsSuccess(w, ucResp)
+}
+
+// Errors возвращает неудачные номера кампании, сгруппированные по причине ошибки
+func (h *CampaignsHandler) Errors(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+
+	h.logger.Info("get campaign errors request started",
+		"campaign_id", campaignID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query_params", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	limit, offset, reason, err := h.parseErrorsParams(r)
+	if err != nil {
+		h.logger.Warn("get campaign errors validation failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucReq := h.converter.ToGetCampaignErrorsRequest(campaignID, reason, limit, offset)
+
+	ucResp, err := h.campaignUseCase.GetErrors(r.Context(), ucReq)
+	if err != nil {
+		h.logger.Error("get campaign errors usecase failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.logger.Info("get campaign errors request completed successfully",
+		"campaign_id", campaignID,
+		"total_failed", ucResp.TotalFailed,
+		"groups", len(ucResp.Groups),
+	)
+
+	h.presenter.PresentCampaignErrorsSuccess(w, ucResp)
+}
+
+// Export выгружает номера кампании с результатами отправки в XLSX или CSV
+func (h *CampaignsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	campaignID := chi.URLParam(r, "id")
+
+	h.logger.Info("export campaign request started",
+		"campaign_id", campaignID,
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query_params", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	if err := h.validateCampaignID(campaignID); err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	format, status, err := h.parseExportParams(r)
+	if err != nil {
+		h.logger.Warn("export campaign validation failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	// Большая выгрузка может не уложиться в общий таймаут записи сервера
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+
+	fileWriter := response.NewFileWriter(w, exportContentTypes[format], "campaign-"+campaignID+"."+format)
+	ucReq := h.converter.ToExportCampaignRequest(campaignID, format, status)
+
+	ucResp, err := h.campaignUseCase.Export(r.Context(), ucReq, fileWriter)
+	if err != nil {
+		h.logger.Error("export campaign usecase failed",
+			"campaign_id", campaignID,
+			"error", err.Error(),
+		)
+		// Если файл уже начал отправляться, ответ изменить нельзя — клиент получит оборванный файл
+		if !fileWriter.Started() {
+			h.presenter.PresentUseCaseError(w, err)
+		}
+		return
+	}
+
+	h.logger.Info("export campaign request completed successfully",
+		"campaign_id", campaignID,
+		"format", format,
+		"rows", ucResp.Rows,
+	)
+}
+
 // List получает список кампаний с пагинацией и фильтрацией
 func (h *CampaignsHandler) List(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info("list campaigns request started",
@@ -220,6 +561,25 @@ func (h *CampaignsHandler) parseCreateRequest(r *http.Request) (httpDTO.CreateCa
 	selectedCategoryName := r.FormValue("selected_category_name")
 	autoStartAfterFilter := r.FormValue("auto_start_after_filter") == "on"
 
+	var scheduledAt *time.Time
+	if value := strings.TrimSpace(r.FormValue("scheduled_at")); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return httpDTO.CreateCampaignRequest{}, NewCampaignValidationError("scheduled_at", "Scheduled time must be in RFC3339 format")
+		}
+		scheduledAt = &parsed
+	}
+
+	sendingWindow, err := parseSendingWindow(r)
+	if err != nil {
+		return httpDTO.CreateCampaignRequest{}, err
+	}
+
+	variants, err := parseMessageVariants(r)
+	if err != nil {
+		return httpDTO.CreateCampaignRequest{}, err
+	}
+
 	return httpDTO.CreateCampaignRequest{
 		Name:                 r.FormValue("name"),
 		Message:              r.FormValue("message"),
@@ -229,6 +589,77 @@ func (h *CampaignsHandler) parseCreateRequest(r *http.Request) (httpDTO.CreateCa
 		Initiator:            r.FormValue("initiator"),
 		SelectedCategoryName: selectedCategoryName,
 		AutoStartAfterFilter: autoStartAfterFilter,
+		ScheduledAt:          scheduledAt,
+		SendingWindow:        sendingWindow,
+		Variants:             variants,
+		PhoneSheet:           strings.TrimSpace(r.FormValue("numbers_sheet")),
+		PhoneAllSheets:       r.FormValue("numbers_all_sheets") == "on",
+		PhoneColumn:          strings.TrimSpace(r.FormValue("numbers_column")),
+	}, nil
+}
+
+// parseMessageVariants парсит варианты сообщения для A/B тестирования (опционально).
+// Варианты передаются JSON-массивом в поле variants, медиа варианта — файлом в поле variant_media_<индекс>.
+// Количество вариантов, имена и доли проверяются в сущности кампании
+func parseMessageVariants(r *http.Request) ([]httpDTO.MessageVariant, error) {
+	value := strings.TrimSpace(r.FormValue("variants"))
+	if value == "" {
+		return nil, nil
+	}
+
+	var variants []httpDTO.MessageVariant
+	if err := json.Unmarshal([]byte(value), &variants); err != nil {
+		return nil, NewCampaignValidationError("variants", "Variants must be a JSON array of {name, message, percent}")
+	}
+
+	if r.MultipartForm != nil {
+		for i := range variants {
+			if files := r.MultipartForm.File["variant_media_"+strconv.Itoa(i)]; len(files) > 0 {
+				variants[i].MediaFile = files[0]
+			}
+		}
+	}
+
+	return variants, nil
+}
+
+// parseSendingWindow парсит окно отправки из multipart form (опционально)
+func parseSendingWindow(r *http.Request) (*httpDTO.SendingWindow, error) {
+	start := strings.TrimSpace(r.FormValue("send_window_start"))
+	end := strings.TrimSpace(r.FormValue("send_window_end"))
+	if start == "" && end == "" {
+		return nil, nil
+	}
+
+	if _, err := time.Parse("15:04", start); err != nil {
+		return nil, NewCampaignValidationError("send_window_start", "Sending window start must be in HH:MM format")
+	}
+	if _, err := time.Parse("15:04", end); err != nil {
+		return nil, NewCampaignValidationError("send_window_end", "Sending window end must be in HH:MM format")
+	}
+	if start == end {
+		return nil, NewCampaignValidationError("send_window_end", "Sending window start and end must differ")
+	}
+
+	timezone := strings.TrimSpace(r.FormValue("send_window_timezone"))
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, NewCampaignValidationError("send_window_timezone", "Unknown sending window timezone")
+	}
+
+	var weekdays []int
+	for _, value := range parseArrayParam(r, "send_window_weekdays") {
+		day, err := strconv.Atoi(value)
+		if err != nil || day < 0 || day > 6 {
+			return nil, NewCampaignValidationError("send_window_weekdays", "Weekdays must be numbers from 0 (Sunday) to 6 (Saturday)")
+		}
+		weekdays = append(weekdays, day)
+	}
+
+	return &httpDTO.SendingWindow{
+		Start:    start,
+		End:      end,
+		Timezone: timezone,
+		Weekdays: weekdays,
 	}, nil
 }
 
@@ -254,6 +685,10 @@ func (h *CampaignsHandler) validateCreateRequest(req httpDTO.CreateCampaignReque
 		return NewCampaignValidationError("messages_per_hour", "Messages per hour must be between 0 and 3600")
 	}
 
+	if req.PhoneSheet != "" && req.PhoneAllSheets {
+		return NewCampaignValidationError("numbers_sheet", "Sheet cannot be combined with numbers_all_sheets")
+	}
+
 	return nil
 }
 
@@ -301,6 +736,53 @@ func (h *CampaignsHandler) parseCancelReason(r *http.Request) (string, error) {
 	return reason, nil
 }
 
+// parseRetryErrorFilter парсит фильтр по тексту ошибки из body (опционально).
+// Пустое body означает повтор всех неудачных номеров, длина фильтра проверяется в use case
+func (h *CampaignsHandler) parseRetryErrorFilter(r *http.Request) (string, error) {
+	var requestBody map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		if errors.Is(err, io.EOF) {
+			return "", nil
+		}
+		return "", NewCampaignValidationError("body", "Invalid JSON format")
+	}
+
+	return requestBody["error_contains"], nil
+}
+
+// parseStatsPeriod парсит период статистики из query параметров from и to (формат YYYY-MM-DD, опционально)
+func (h *CampaignsHandler) parseStatsPeriod(r *http.Request) (from, to time.Time, err error) {
+	query := r.URL.Query()
+
+	if value := strings.TrimSpace(query.Get("from")); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			return time.Time{}, time.Time{}, NewCampaignValidationError("from", "Date must be in YYYY-MM-DD format")
+		}
+	}
+
+	if value := strings.TrimSpace(query.Get("to")); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			return time.Time{}, time.Time{}, NewCampaignValidationError("to", "Date must be in YYYY-MM-DD format")
+		}
+	}
+
+	if !from.IsZero() {
+		end := to
+		if end.IsZero() {
+			now := time.Now()
+			end = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		if from.After(end) {
+			return time.Time{}, time.Time{}, NewCampaignValidationError("from", "Period start must not be after its end")
+		}
+		if end.Sub(from) >= 366*24*time.Hour {
+			return time.Time{}, time.Time{}, NewCampaignValidationError("from", "Period must not exceed 366 days")
+		}
+	}
+
+	return from, to, nil
+}
+
 // parseListParams парсит параметры пагинации и фильтрации
 func (h *CampaignsHandler) parseListParams(r *http.Request) (limit, offset int, status string, err error) {
 	limitStr := r.URL.Query().Get("limit")
@@ -331,7 +813,7 @@ func (h *CampaignsHandler) parseListParams(r *http.Request) (limit, offset int,
 
 	status = r.URL.Query().Get("status")
 	if status != "" {
-		validStatuses := []string{"pending", "started", "finished", "failed", "cancelled"}
+		validStatuses := []string{"pending", "started", "finished", "failed", "cancelled", "interrupted", "paused", "scheduled"}
 		isValid := false
 		for _, validStatus := range validStatuses {
 			if status == validStatus {
@@ -347,6 +829,125 @@ func (h *CampaignsHandler) parseListParams(r *http.Request) (limit, offset int,
 	return limit, offset, status, nil
 }
 
+// parseRecipientsParams парсит фильтры и пагинацию списка номеров.
+// Вместо offset можно передать after — ID последнего номера предыдущей страницы (next_cursor)
+func (h *CampaignsHandler) parseRecipientsParams(r *http.Request) (httpDTO.ListRecipientsParams, error) {
+	query := r.URL.Query()
+	params := httpDTO.ListRecipientsParams{
+		Status: query.Get("status"),
+		Search: strings.TrimSpace(query.Get("search")),
+		After:  query.Get("after"),
+	}
+
+	var err error
+	if limitStr := query.Get("limit"); limitStr != "" {
+		params.Limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return params, errors.New("invalid limit parameter")
+		}
+		if params.Limit < 1 || params.Limit > 1000 {
+			return params, errors.New("limit must be between 1 and 1000")
+		}
+	}
+
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		params.Offset, err = strconv.Atoi(offsetStr)
+		if err != nil {
+			return params, errors.New("invalid offset parameter")
+		}
+		if params.Offset < 0 {
+			return params, errors.New("offset must be non-negative")
+		}
+	}
+
+	if params.Status != "" {
+		validStatuses := []string{"pending", "sent", "delivered", "read", "failed", "cancelled"}
+		isValid := false
+		for _, validStatus := range validStatuses {
+			if params.Status == validStatus {
+				isValid = true
+				break
+			}
+		}
+		if !isValid {
+			return params, errors.New("invalid status parameter")
+		}
+	}
+
+	search := strings.TrimPrefix(params.Search, "+")
+	if len(search) > 20 || strings.Trim(search, "0123456789") != "" {
+		return params, errors.New("search must be a part of phone number: up to 20 digits")
+	}
+
+	if params.After != "" {
+		if _, err := uuid.Parse(params.After); err != nil {
+			return params, errors.New("invalid after parameter")
+		}
+	}
+
+	return params, nil
+}
+
+// parseErrorsParams парсит пагинацию и фильтр по причине ошибки (reason, опционально)
+func (h *CampaignsHandler) parseErrorsParams(r *http.Request) (limit, offset int, reason string, err error) {
+	limitStr := r.URL.Query().Get("limit")
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return 0, 0, "", errors.New("invalid limit parameter")
+		}
+		if limit < 1 || limit > 1000 {
+			return 0, 0, "", errors.New("limit must be between 1 and 1000")
+		}
+	}
+
+	offsetStr := r.URL.Query().Get("offset")
+	if offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil {
+			return 0, 0, "", errors.New("invalid offset parameter")
+		}
+		if offset < 0 {
+			return 0, 0, "", errors.New("offset must be non-negative")
+		}
+	}
+
+	reason = strings.TrimSpace(r.URL.Query().Get("reason"))
+	if len(reason) > 255 {
+		return 0, 0, "", errors.New("reason filter too long: maximum 255 characters")
+	}
+
+	return limit, offset, reason, nil
+}
+
+// parseExportParams парсит формат (xlsx по умолчанию) и фильтр по статусу номеров
+func (h *CampaignsHandler) parseExportParams(r *http.Request) (format, status string, err error) {
+	format = strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" {
+		format = "xlsx"
+	}
+	if _, ok := exportContentTypes[format]; !ok {
+		return "", "", errors.New("invalid format parameter: expected xlsx or csv")
+	}
+
+	status = r.URL.Query().Get("status")
+	if status != "" {
+		validStatuses := []string{"pending", "sent", "delivered", "read", "failed", "cancelled"}
+		isValid := false
+		for _, validStatus := range validStatuses {
+			if status == validStatus {
+				isValid = true
+				break
+			}
+		}
+		if !isValid {
+			return "", "", errors.New("invalid status parameter")
+		}
+	}
+
+	return format, status, nil
+}
+
 // Утилитарные функции
 
 func parseIntDefault(str string, defaultVal int) int {
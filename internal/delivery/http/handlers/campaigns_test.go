@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryErrorFilter(t *testing.T) {
+	h := &CampaignsHandler{}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty body", body: "", want: ""},
+		{name: "filter", body: `{"error_contains":"timeout"}`, want: "timeout"},
+		{name: "no filter", body: `{}`, want: ""},
+		{name: "long filter passed to use case", body: `{"error_contains":"` + strings.Repeat("a", 300) + `"}`, want: strings.Repeat("a", 300)},
+		{name: "malformed json", body: `{"error_contains":`, wantErr: true},
+		{name: "wrong type", body: `{"error_contains":42}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/campaigns/id/retry-failed", strings.NewReader(tt.body))
+
+			got, err := h.parseRetryErrorFilter(r)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
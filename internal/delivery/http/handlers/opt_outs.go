@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"whatsapp-service/internal/adapters/converter"
+	httpDTO "whatsapp-service/internal/adapters/dto/optout"
+	"whatsapp-service/internal/adapters/presenters"
+	"whatsapp-service/internal/interfaces"
+	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	maxOptOutBodySize   = 64 << 10 // 64KB
+	maxOptOutUploadSize = 32 << 20 // 32MB, как у файла номеров кампании
+)
+
+// OptOutsHandler обрабатывает HTTP запросы глобального стоп-листа номеров
+type OptOutsHandler struct {
+	optOutUseCase campaignInterfaces.OptOutUseCase
+	presenter     presenters.OptOutPresenterInterface
+	converter     converter.OptOutConverter
+	logger        interfaces.Logger
+}
+
+// NewOptOutsHandler создает новый обработчик стоп-листа
+func NewOptOutsHandler(
+	optOutUseCase campaignInterfaces.OptOutUseCase,
+	presenter presenters.OptOutPresenterInterface,
+	converter converter.OptOutConverter,
+	logger interfaces.Logger,
+) *OptOutsHandler {
+	return &OptOutsHandler{
+		optOutUseCase: optOutUseCase,
+		presenter:     presenter,
+		converter:     converter,
+		logger:        logger,
+	}
+}
+
+// List возвращает страницу стоп-листа
+func (h *OptOutsHandler) List(w http.ResponseWriter, r *http.Request) {
+	params, err := h.parseListParams(r)
+	if err != nil {
+		h.presenter.PresentValidationError(w, err)
+		return
+	}
+
+	ucResp, err := h.optOutUseCase.List(r.Context(), h.converter.ToListOptOutsRequest(params))
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentListOptOutsSuccess(w, ucResp)
+}
+
+// Add добавляет номер в стоп-лист
+func (h *OptOutsHandler) Add(w http.ResponseWriter, r *http.Request) {
+	var httpReq httpDTO.AddOptOutRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOptOutBodySize)).Decode(&httpReq); err != nil {
+		h.presenter.PresentValidationError(w, errors.New("invalid JSON body"))
+		return
+	}
+
+	if strings.TrimSpace(httpReq.PhoneNumber) == "" {
+		h.presenter.PresentValidationError(w, errors.New("phone_number is required"))
+		return
+	}
+	if len(httpReq.Reason) > 500 {
+		h.presenter.PresentValidationError(w, errors.New("reason too long (max 500 characters)"))
+		return
+	}
+
+	ucResp, err := h.optOutUseCase.Add(r.Context(), h.converter.ToAddOptOutRequest(httpReq))
+	if err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentAddOptOutSuccess(w, ucResp)
+}
+
+// Remove удаляет номер из стоп-листа
+func (h *OptOutsHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	phoneNumber := strings.TrimSpace(chi.URLParam(r, "phone"))
+	if phoneNumber == "" {
+		h.presenter.PresentValidationError(w, errors.New("phone number is required"))
+		return
+	}
+
+	if err := h.optOutUseCase.Remove(r.Context(), h.converter.ToRemoveOptOutRequest(phoneNumber)); err != nil {
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentRemoveOptOutSuccess(w)
+}
+
+// Import загружает номера в стоп-лист из файла Excel или CSV (поле file) и/или списка (поле phone_numbers)
+func (h *OptOutsHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(maxOptOutUploadSize); err != nil {
+		h.presenter.PresentValidationError(w, errors.New("invalid multipart form"))
+		return
+	}
+
+	var fileHeader *multipart.FileHeader
+	if _, header, err := r.FormFile("file"); err == nil {
+		fileHeader = header
+	}
+
+	phoneNumbers := parseArrayParam(r, "phone_numbers")
+	if fileHeader == nil && len(phoneNumbers) == 0 {
+		h.presenter.PresentValidationError(w, errors.New("file or phone_numbers is required"))
+		return
+	}
+	if len(phoneNumbers) > 1000 {
+		h.presenter.PresentValidationError(w, errors.New("too many phone numbers (max 1000)"))
+		return
+	}
+
+	reason := strings.TrimSpace(r.FormValue("reason"))
+	if len(reason) > 500 {
+		h.presenter.PresentValidationError(w, errors.New("reason too long (max 500 characters)"))
+		return
+	}
+
+	ucReq := h.converter.ToImportOptOutsRequest(fileHeader, phoneNumbers, reason)
+
+	ucResp, err := h.optOutUseCase.Import(r.Context(), ucReq)
+	if err != nil {
+		h.logger.Error("opt-out import failed", "error", err.Error())
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentImportOptOutsSuccess(w, ucResp)
+}
+
+// parseListParams парсит query параметры списка стоп-листа
+func (h *OptOutsHandler) parseListParams(r *http.Request) (httpDTO.ListOptOutsParams, error) {
+	query := r.URL.Query()
+	params := httpDTO.ListOptOutsParams{
+		Search: strings.TrimSpace(query.Get("search")),
+	}
+
+	var err error
+	if limitStr := query.Get("limit"); limitStr != "" {
+		params.Limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			return params, errors.New("invalid limit parameter")
+		}
+		if params.Limit < 1 || params.Limit > 1000 {
+			return params, errors.New("limit must be between 1 and 1000")
+		}
+	}
+
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		params.Offset, err = strconv.Atoi(offsetStr)
+		if err != nil {
+			return params, errors.New("invalid offset parameter")
+		}
+		if params.Offset < 0 {
+			return params, errors.New("offset must be non-negative")
+		}
+	}
+
+	search := strings.TrimPrefix(params.Search, "+")
+	if len(search) > 20 || strings.Trim(search, "0123456789") != "" {
+		return params, errors.New("search must be a part of phone number: up to 20 digits")
+	}
+
+	return params, nil
+}
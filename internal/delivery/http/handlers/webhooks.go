@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"whatsapp-service/internal/interfaces"
+
+	"whatsapp-service/internal/adapters/converter"
+	httpDTO "whatsapp-service/internal/adapters/dto/webhook"
+	"whatsapp-service/internal/adapters/presenters"
+	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
+)
+
+const (
+	// WhatsgateSignatureHeader — заголовок с HMAC-SHA256 подписью тела webhook в hex (допускается префикс "sha256=")
+	WhatsgateSignatureHeader = "X-Whatsgate-Signature"
+
+	maxWebhookBodySize = 1 << 20 // 1MB
+	maxWebhookStatuses = 1000    // Совпадает с лимитом use case на количество подтверждений
+	maxWebhookMessages = 1000    // Совпадает с лимитом use case на количество входящих сообщений
+)
+
+// WebhooksHandler обрабатывает входящие webhook от провайдеров
+type WebhooksHandler struct {
+	deliveryUseCase campaignInterfaces.CampaignDeliveryUseCase
+	optOutUseCase   campaignInterfaces.OptOutUseCase
+	presenter       presenters.WebhookPresenterInterface
+	converter       converter.WebhookConverter
+	whatsgateSecret string
+	logger          interfaces.Logger
+}
+
+// NewWebhooksHandler создает новый обработчик webhook.
+// whatsgateSecret — общий секрет для проверки подписи WhatsGate (пустая строка отключает прием webhook)
+func NewWebhooksHandler(
+	deliveryUseCase campaignInterfaces.CampaignDeliveryUseCase,
+	optOutUseCase campaignInterfaces.OptOutUseCase,
+	presenter presenters.WebhookPresenterInterface,
+	converter converter.WebhookConverter,
+	whatsgateSecret string,
+	logger interfaces.Logger,
+) *WebhooksHandler {
+	return &WebhooksHandler{
+		deliveryUseCase: deliveryUseCase,
+		optOutUseCase:   optOutUseCase,
+		presenter:       presenter,
+		converter:       converter,
+		whatsgateSecret: whatsgateSecret,
+		logger:          logger,
+	}
+}
+
+// Whatsgate принимает от WhatsGate подтверждения доставки и прочтения сообщений,
+// а также входящие сообщения получателей для автоматической отписки по стоп-словам
+func (h *WebhooksHandler) Whatsgate(w http.ResponseWriter, r *http.Request) {
+	if h.whatsgateSecret == "" {
+		h.logger.Warn("whatsgate webhook rejected: secret is not configured")
+		h.presenter.PresentError(w, http.StatusServiceUnavailable, "Webhook is not configured")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize+1))
+	if err != nil {
+		h.presenter.PresentValidationError(w, errors.New("failed to read request body"))
+		return
+	}
+	if len(body) > maxWebhookBodySize {
+		h.presenter.PresentError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+		return
+	}
+
+	if !validWebhookSignature(body, r.Header.Get(WhatsgateSignatureHeader), h.whatsgateSecret) {
+		h.logger.Warn("whatsgate webhook rejected: invalid signature", "remote_addr", r.RemoteAddr)
+		h.presenter.PresentError(w, http.StatusUnauthorized, "Invalid webhook signature")
+		return
+	}
+
+	var httpRequest httpDTO.WhatsgateWebhookRequest
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(&httpRequest); err != nil {
+		h.logger.Warn("whatsgate webhook validation failed", "error", err.Error())
+		h.presenter.PresentValidationError(w, errors.New("invalid JSON body"))
+		return
+	}
+
+	if len(httpRequest.Statuses) > maxWebhookStatuses {
+		h.presenter.PresentValidationError(w, errors.New("too many statuses: maximum 1000 per request"))
+		return
+	}
+	if len(httpRequest.Messages) > maxWebhookMessages {
+		h.presenter.PresentValidationError(w, errors.New("too many messages: maximum 1000 per request"))
+		return
+	}
+
+	h.logger.Debug("whatsgate webhook parsed", "statuses", len(httpRequest.Statuses), "messages", len(httpRequest.Messages))
+
+	receipts, err := h.deliveryUseCase.ProcessReceipts(r.Context(), h.converter.ToProcessDeliveryReceiptsRequest(httpRequest))
+	if err != nil {
+		h.logger.Error("whatsgate webhook use case failed", "error", err.Error())
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	inbound, err := h.optOutUseCase.ProcessInbound(r.Context(), h.converter.ToProcessInboundMessagesRequest(httpRequest))
+	if err != nil {
+		h.logger.Error("whatsgate webhook inbound messages failed", "error", err.Error())
+		h.presenter.PresentUseCaseError(w, err)
+		return
+	}
+
+	h.presenter.PresentWhatsgateWebhookSuccess(w, receipts, inbound)
+}
+
+// validWebhookSignature проверяет HMAC-SHA256 подпись тела запроса общим секретом
+func validWebhookSignature(body []byte, signature, secret string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	received, err := hex.DecodeString(signature)
+	if err != nil || len(received) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(received, mac.Sum(nil))
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestValidWebhookSignature(t *testing.T) {
+	const secret = "webhook-secret"
+	body := []byte(`{"type":"status","id":"msg-1","status":"delivered"}`)
+	signature := sign(body, secret)
+
+	tests := []struct {
+		name      string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid hex signature", body: body, signature: signature, want: true},
+		{name: "sha256 prefix", body: body, signature: "sha256=" + signature, want: true},
+		{name: "surrounding spaces", body: body, signature: " sha256=" + signature + " ", want: true},
+		{name: "wrong secret", body: body, signature: sign(body, "other-secret"), want: false},
+		{name: "empty header", body: body, signature: "", want: false},
+		{name: "prefix only", body: body, signature: "sha256=", want: false},
+		{name: "non-hex header", body: body, signature: "not-a-hex-signature", want: false},
+		{name: "truncated signature", body: body, signature: signature[:32], want: false},
+		{name: "tampered body", body: []byte(`{"type":"status","id":"msg-1","status":"read"}`), signature: signature, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, validWebhookSignature(tt.body, tt.signature, secret))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package response
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -79,3 +80,33 @@ func WriteHealth(w http.ResponseWriter) {
 		Service: "whatsapp-service",
 	})
 }
+
+// FileWriter отдает файл как вложение. Заголовки выставляются при первой записи,
+// поэтому до нее вместо файла еще можно отправить JSON ошибку
+type FileWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+// NewFileWriter создает writer для скачивания файла filename
+func NewFileWriter(w http.ResponseWriter, contentType, filename string) *FileWriter {
+	return &FileWriter{w: w, contentType: contentType, filename: filename}
+}
+
+// Write выставляет заголовки файла при первом вызове и пишет данные в ответ
+func (f *FileWriter) Write(p []byte) (int, error) {
+	if !f.started {
+		f.started = true
+		f.w.Header().Set("Content-Type", f.contentType)
+		f.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, f.filename))
+		f.w.WriteHeader(http.StatusOK)
+	}
+	return f.w.Write(p)
+}
+
+// Started сообщает, начата ли уже отправка файла
+func (f *FileWriter) Started() bool {
+	return f.started
+}
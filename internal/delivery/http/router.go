@@ -21,6 +21,8 @@ type Router struct {
 	retailcrmSettings *handlers.RetailCRMSettingsHandler
 	health            *handlers.HealthHandler
 	retailcrm         *handlers.RetailCRMHandler
+	webhooks          *handlers.WebhooksHandler
+	optOuts           *handlers.OptOutsHandler
 	logger            interfaces.Logger
 }
 
@@ -32,6 +34,8 @@ func NewRouter(
 	retailcrmSettingsHandler *handlers.RetailCRMSettingsHandler,
 	healthHandler *handlers.HealthHandler,
 	retailcrmHandler *handlers.RetailCRMHandler,
+	webhooksHandler *handlers.WebhooksHandler,
+	optOutsHandler *handlers.OptOutsHandler,
 	logger interfaces.Logger,
 ) *Router {
 	return &Router{
@@ -41,6 +45,8 @@ func NewRouter(
 		retailcrmSettings: retailcrmSettingsHandler,
 		health:            healthHandler,
 		retailcrm:         retailcrmHandler,
+		webhooks:          webhooksHandler,
+		optOuts:           optOutsHandler,
 		logger:            logger,
 	}
 }
@@ -75,16 +81,35 @@ func (rt *Router) SetupRoutes() http.Handler {
 			// Создание новой кампании
 			r.Post("/", rt.campaigns.Create)
 
+			// Анализ файла с номерами перед созданием кампании
+			r.Post("/file-preview", rt.campaigns.PreviewFile)
+			r.Post("/preview-audience", rt.campaigns.PreviewAudience)
+
 			r.Route("/{id}", func(r chi.Router) {
 				// Получение кампании по ID
 				r.Get("/", rt.campaigns.GetByID)
+				r.Get("/stats", rt.campaigns.Stats)
+				r.Get("/recipients", rt.campaigns.Recipients)
+				r.Get("/errors", rt.campaigns.Errors)
+				r.Get("/export", rt.campaigns.Export)
 
 				// Операции с кампанией
 				r.Post("/start", rt.campaigns.Start)
+				r.Post("/pause", rt.campaigns.Pause)
+				r.Post("/resume", rt.campaigns.Resume)
+				r.Post("/retry-failed", rt.campaigns.RetryFailed)
 				r.Post("/cancel", rt.campaigns.Cancel)
 			})
 		})
 
+		// Глобальный стоп-лист номеров
+		r.Route("/opt-outs", func(r chi.Router) {
+			r.Get("/", rt.optOuts.List)
+			r.Post("/", rt.optOuts.Add)
+			r.Post("/import", rt.optOuts.Import)
+			r.Delete("/{phone}", rt.optOuts.Remove)
+		})
+
 		// Messaging
 		r.Post("/test-message", rt.messaging.SendTestMessage)
 
@@ -108,6 +133,11 @@ func (rt *Router) SetupRoutes() http.Handler {
 			r.Post("/filter-customers", rt.retailcrm.FilterCustomersByCategory)
 			r.Get("/test-connection", rt.retailcrm.TestConnection)
 		})
+
+		// Входящие webhook провайдеров
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Post("/whatsgate", rt.webhooks.Whatsgate)
+		})
 	})
 
 	// 404 handler
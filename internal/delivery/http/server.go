@@ -27,9 +27,11 @@ func NewHTTPServer(
 	retailCRMSettingsHandler *handlers.RetailCRMSettingsHandler,
 	retailCRMHandler *handlers.RetailCRMHandler,
 	healthHandler *handlers.HealthHandler,
+	webhooksHandler *handlers.WebhooksHandler,
+	optOutsHandler *handlers.OptOutsHandler,
 	logger interfaces.Logger,
 ) *HTTPServer {
-	router := NewRouter(campaignHandler, messagingHandler, whatsgateSettingsHandler, retailCRMSettingsHandler, healthHandler, retailCRMHandler, logger)
+	router := NewRouter(campaignHandler, messagingHandler, whatsgateSettingsHandler, retailCRMSettingsHandler, healthHandler, retailCRMHandler, webhooksHandler, optOutsHandler, logger)
 
 	return &HTTPServer{
 		router: router,
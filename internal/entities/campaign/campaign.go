@@ -20,12 +20,20 @@ const (
 	CampaignStatusFinished  CampaignStatus = "finished"
 	CampaignStatusFailed    CampaignStatus = "failed"
 	CampaignStatusCancelled CampaignStatus = "cancelled"
+	// CampaignStatusInterrupted — рассылка прервана остановкой сервиса и ожидает возобновления
+	CampaignStatusInterrupted CampaignStatus = "interrupted"
+	// CampaignStatusPaused — рассылка приостановлена пользователем
+	CampaignStatusPaused CampaignStatus = "paused"
+	// CampaignStatusScheduled — рассылка ожидает запуска в запланированное время
+	CampaignStatusScheduled CampaignStatus = "scheduled"
 )
 
 type TargetAudience struct {
 	Primary    []*PhoneNumber
 	Additional []*PhoneNumber
 	Excluded   []*PhoneNumber
+	Blocked    []*PhoneNumber // Номера из глобального стоп-листа
+	Capped     []*PhoneNumber // Номера, превысившие лимит частоты отправки
 }
 
 func (a *TargetAudience) AllTargets() []*PhoneNumber {
@@ -33,6 +41,12 @@ func (a *TargetAudience) AllTargets() []*PhoneNumber {
 	for _, phone := range a.Excluded {
 		excludeMap[phone.Value()] = struct{}{}
 	}
+	for _, phone := range a.Blocked {
+		excludeMap[phone.Value()] = struct{}{}
+	}
+	for _, phone := range a.Capped {
+		excludeMap[phone.Value()] = struct{}{}
+	}
 
 	allNumbers := append([]*PhoneNumber{}, a.Primary...)
 	allNumbers = append(allNumbers, a.Additional...)
@@ -57,6 +71,8 @@ type CampaignMetrics struct {
 	Total     int
 	Processed int
 	Errors    int
+	Delivered int
+	Read      int
 }
 
 func (m *CampaignMetrics) Progress() float64 {
@@ -66,6 +82,12 @@ func (m *CampaignMetrics) Progress() float64 {
 	return float64(m.Processed) / float64(m.Total)
 }
 
+// ResetFailed возвращает заданное количество неудачных отправок в очередь
+func (m *CampaignMetrics) ResetFailed(count int) {
+	m.Processed = max(m.Processed-count, 0)
+	m.Errors = max(m.Errors-count, 0)
+}
+
 func (m *CampaignMetrics) MarkProcessed() { m.Processed++ }
 func (m *CampaignMetrics) MarkError()     { m.Errors++ }
 func (m *CampaignMetrics) IsCompleted() bool {
@@ -110,7 +132,7 @@ func (d *DeliveryStatus) Failed() []*CampaignPhoneStatus {
 func (d *DeliveryStatus) Sent() []*CampaignPhoneStatus {
 	var sent []*CampaignPhoneStatus
 	for _, status := range d.records {
-		if status.Status() == CampaignStatusTypeSent {
+		if status.IsSuccessful() {
 			sent = append(sent, status)
 		}
 	}
@@ -131,6 +153,9 @@ type Campaign struct {
 	initiator       string
 	categoryName    string
 	createdAt       time.Time
+	scheduledAt     *time.Time
+	sendingWindow   *SendingWindow
+	variants        []*MessageVariant
 	audience        *TargetAudience
 	metrics         *CampaignMetrics
 	delivery        *DeliveryStatus
@@ -189,6 +214,9 @@ func (c *Campaign) Media() *Media          { return c.media }
 func (c *Campaign) MessagesPerHour() int   { return c.messagesPerHour }
 func (c *Campaign) CategoryName() string   { return c.categoryName }
 
+// ScheduledAt возвращает время запланированного запуска (nil, если запуск не запланирован)
+func (c *Campaign) ScheduledAt() *time.Time { return c.scheduledAt }
+
 func (c *Campaign) Audience() *TargetAudience { return c.audience }
 func (c *Campaign) Metrics() *CampaignMetrics { return c.metrics }
 func (c *Campaign) Delivery() *DeliveryStatus { return c.delivery }
@@ -209,6 +237,16 @@ func (c *Campaign) AddExcludedNumbers(numbers []*PhoneNumber) {
 	c.audience.Excluded = append(c.audience.Excluded, numbers...)
 }
 
+// AddBlockedNumbers исключает из рассылки номера глобального стоп-листа
+func (c *Campaign) AddBlockedNumbers(numbers []*PhoneNumber) {
+	c.audience.Blocked = append(c.audience.Blocked, numbers...)
+}
+
+// AddCappedNumbers исключает из рассылки номера, превысившие лимит частоты отправки
+func (c *Campaign) AddCappedNumbers(numbers []*PhoneNumber) {
+	c.audience.Capped = append(c.audience.Capped, numbers...)
+}
+
 // SetInitiator устанавливает инициатора кампании
 func (c *Campaign) SetInitiator(initiator string) {
 	c.initiator = initiator
@@ -219,17 +257,100 @@ func (c *Campaign) SetMedia(media *Media) {
 	c.media = media
 }
 
+// SendingWindow возвращает разрешенное окно отправки (nil, если отправка не ограничена по времени)
+func (c *Campaign) SendingWindow() *SendingWindow { return c.sendingWindow }
+
+// SetSendingWindow устанавливает разрешенное окно отправки
+func (c *Campaign) SetSendingWindow(window *SendingWindow) {
+	c.sendingWindow = window
+}
+
+// Variants возвращает варианты сообщения для A/B тестирования (пустой список = один вариант)
+func (c *Campaign) Variants() []*MessageVariant { return c.variants }
+
+// SetVariants устанавливает варианты сообщения после проверки их количества и долей
+func (c *Campaign) SetVariants(variants []*MessageVariant) error {
+	if err := validateVariants(variants); err != nil {
+		return err
+	}
+	c.variants = variants
+	return nil
+}
+
+// RestoreVariants восстанавливает варианты сообщения из хранилища без проверки
+func (c *Campaign) RestoreVariants(variants []*MessageVariant) {
+	c.variants = variants
+}
+
+// VariantAssigner возвращает распределение получателей между вариантами сообщения кампании
+func (c *Campaign) VariantAssigner() *VariantAssigner {
+	return NewVariantAssigner(c.variants)
+}
+
+// MessageFor возвращает текст сообщения для указанного варианта
+func (c *Campaign) MessageFor(variantName string) string {
+	if variant := c.findVariant(variantName); variant != nil && variant.message != "" {
+		return variant.message
+	}
+	return c.message
+}
+
+// MediaFor возвращает медиа сообщения для указанного варианта
+func (c *Campaign) MediaFor(variantName string) *Media {
+	if variant := c.findVariant(variantName); variant != nil && variant.media != nil {
+		return variant.media
+	}
+	return c.media
+}
+
+func (c *Campaign) findVariant(name string) *MessageVariant {
+	if name == "" {
+		return nil
+	}
+	for _, variant := range c.variants {
+		if variant.name == name {
+			return variant
+		}
+	}
+	return nil
+}
+
+// SetScheduledAt устанавливает время запланированного запуска
+func (c *Campaign) SetScheduledAt(scheduledAt *time.Time) {
+	c.scheduledAt = scheduledAt
+}
+
 // SetStatus устанавливает статус кампании
 func (c *Campaign) SetStatus(status CampaignStatus) {
 	c.status = status
 }
 
 func (c *Campaign) CanBeCancelled() bool {
-	return c.status == CampaignStatusPending || c.status == CampaignStatusStarted || c.status == CampaignStatusFiltering
+	return c.status == CampaignStatusPending || c.status == CampaignStatusStarted || c.status == CampaignStatusFiltering ||
+		c.status == CampaignStatusInterrupted || c.status == CampaignStatusPaused || c.status == CampaignStatusScheduled
 }
 
 func (c *Campaign) CanBeStarted() bool {
-	return (c.status == CampaignStatusPending || c.status == CampaignStatusFiltering) && c.metrics.Total > 0
+	return (c.status == CampaignStatusPending || c.status == CampaignStatusFiltering || c.status == CampaignStatusScheduled) &&
+		c.metrics.Total > 0
+}
+
+// Schedule планирует запуск ожидающей кампании на указанное время
+func (c *Campaign) Schedule(at time.Time) error {
+	if c.status != CampaignStatusPending && c.status != CampaignStatusFiltering {
+		return ErrCannotScheduleCampaign
+	}
+	if !at.After(time.Now()) {
+		return ErrScheduledTimeInPast
+	}
+	c.scheduledAt = &at
+	c.status = CampaignStatusScheduled
+	return nil
+}
+
+// IsDue проверяет, наступило ли время запланированного запуска
+func (c *Campaign) IsDue(now time.Time) bool {
+	return c.status == CampaignStatusScheduled && c.scheduledAt != nil && !c.scheduledAt.After(now)
 }
 
 func (c *Campaign) CanBeModified() bool {
@@ -256,6 +377,58 @@ func (c *Campaign) Cancel() error {
 	return nil
 }
 
+// CanBePaused проверяет, можно ли приостановить рассылку
+func (c *Campaign) CanBePaused() bool {
+	return c.status == CampaignStatusStarted
+}
+
+// CanBeResumed проверяет, можно ли продолжить приостановленную или прерванную рассылку
+func (c *Campaign) CanBeResumed() bool {
+	return c.status == CampaignStatusInterrupted || c.status == CampaignStatusPaused
+}
+
+// CanRetryFailed проверяет, можно ли повторить отправку на неудачные номера
+func (c *Campaign) CanRetryFailed() bool {
+	return c.status == CampaignStatusFinished || c.status == CampaignStatusFailed
+}
+
+// RetryFailed перезапускает завершенную кампанию для повторной отправки retriedCount неудачных номеров
+func (c *Campaign) RetryFailed(retriedCount int) error {
+	if !c.CanRetryFailed() {
+		return ErrCannotRetryCampaign
+	}
+	c.status = CampaignStatusStarted
+	c.metrics.ResetFailed(retriedCount)
+	return nil
+}
+
+// Pause приостанавливает запущенную кампанию
+func (c *Campaign) Pause() error {
+	if !c.CanBePaused() {
+		return ErrCannotPauseCampaign
+	}
+	c.status = CampaignStatusPaused
+	return nil
+}
+
+// Interrupt помечает запущенную кампанию как прерванную (например, при остановке сервиса)
+func (c *Campaign) Interrupt() error {
+	if c.status != CampaignStatusStarted {
+		return ErrCannotInterruptCampaign
+	}
+	c.status = CampaignStatusInterrupted
+	return nil
+}
+
+// Resume возвращает приостановленную или прерванную кампанию в статус "запущена"
+func (c *Campaign) Resume() error {
+	if !c.CanBeResumed() {
+		return ErrCannotResumeCampaign
+	}
+	c.status = CampaignStatusStarted
+	return nil
+}
+
 func (c *Campaign) Finish() {
 	if c.status == CampaignStatusStarted {
 		c.status = CampaignStatusFinished
@@ -268,5 +441,6 @@ func (c *Campaign) MarkError()        { c.metrics.MarkError() }
 func (c *Campaign) Progress() float64 { return c.metrics.Progress() }
 func (c *Campaign) IsCompleted() bool { return c.metrics.IsCompleted() }
 func (c *Campaign) IsActive() bool {
-	return c.status == CampaignStatusPending || c.status == CampaignStatusStarted || c.status == CampaignStatusFiltering
+	return c.status == CampaignStatusPending || c.status == CampaignStatusStarted || c.status == CampaignStatusFiltering ||
+		c.status == CampaignStatusInterrupted || c.status == CampaignStatusPaused || c.status == CampaignStatusScheduled
 }
@@ -12,8 +12,17 @@ const (
 	CampaignStatusTypeSent      CampaignStatusType = "sent"
 	CampaignStatusTypeFailed    CampaignStatusType = "failed"
 	CampaignStatusTypeCancelled CampaignStatusType = "cancelled"
+	// CampaignStatusTypeDelivered — WhatsApp подтвердил доставку сообщения получателю
+	CampaignStatusTypeDelivered CampaignStatusType = "delivered"
+	// CampaignStatusTypeRead — получатель прочитал сообщение
+	CampaignStatusTypeRead CampaignStatusType = "read"
 )
 
+// IsSent проверяет, что сообщение было отправлено: статусы доставки и прочтения следуют за отправкой
+func (t CampaignStatusType) IsSent() bool {
+	return t == CampaignStatusTypeSent || t == CampaignStatusTypeDelivered || t == CampaignStatusTypeRead
+}
+
 // CampaignPhoneStatus представляет статус отправки сообщения на конкретный номер
 // Value Object — не изменяется извне, а пересоздается
 type CampaignPhoneStatus struct {
@@ -26,6 +35,8 @@ type CampaignPhoneStatus struct {
 	sentAt            *time.Time
 	deliveredAt       *time.Time
 	readAt            *time.Time
+	variables         map[string]string
+	variant           string
 	createdAt         time.Time
 }
 
@@ -124,6 +135,26 @@ func (cs *CampaignPhoneStatus) CreatedAt() time.Time {
 	return cs.createdAt
 }
 
+// Variables возвращает переменные получателя для подстановки в шаблон сообщения
+func (cs *CampaignPhoneStatus) Variables() map[string]string {
+	return cs.variables
+}
+
+// SetVariables устанавливает переменные получателя для подстановки в шаблон сообщения
+func (cs *CampaignPhoneStatus) SetVariables(variables map[string]string) {
+	cs.variables = variables
+}
+
+// Variant возвращает имя варианта сообщения, назначенного получателю (пустая строка = без A/B теста)
+func (cs *CampaignPhoneStatus) Variant() string {
+	return cs.variant
+}
+
+// SetVariant назначает получателю вариант сообщения
+func (cs *CampaignPhoneStatus) SetVariant(variant string) {
+	cs.variant = variant
+}
+
 // MarkAsSent помечает сообщение как отправленное
 func (cs *CampaignPhoneStatus) MarkAsSent() {
 	cs.status = CampaignStatusTypeSent
@@ -132,6 +163,32 @@ func (cs *CampaignPhoneStatus) MarkAsSent() {
 	cs.error = ""
 }
 
+// MarkAsDelivered помечает отправленное сообщение как доставленное.
+// Возвращает false, если статус не изменился (сообщение не отправлено или уже доставлено/прочитано)
+func (cs *CampaignPhoneStatus) MarkAsDelivered(at time.Time) bool {
+	if cs.status != CampaignStatusTypeSent {
+		return false
+	}
+	cs.status = CampaignStatusTypeDelivered
+	cs.deliveredAt = &at
+	return true
+}
+
+// MarkAsRead помечает отправленное или доставленное сообщение как прочитанное.
+// Прочтение подразумевает доставку, поэтому если подтверждение доставки не приходило, проставляется и время доставки.
+// Возвращает false, если статус не изменился
+func (cs *CampaignPhoneStatus) MarkAsRead(at time.Time) bool {
+	if cs.status != CampaignStatusTypeSent && cs.status != CampaignStatusTypeDelivered {
+		return false
+	}
+	if cs.deliveredAt == nil {
+		cs.deliveredAt = &at
+	}
+	cs.status = CampaignStatusTypeRead
+	cs.readAt = &at
+	return true
+}
+
 // MarkAsFailed помечает сообщение как неудачное
 func (cs *CampaignPhoneStatus) MarkAsFailed(errorMsg string) {
 	cs.status = CampaignStatusTypeFailed
@@ -146,6 +203,14 @@ func (cs *CampaignPhoneStatus) Cancel() {
 	}
 }
 
+// CancelWithReason отменяет отправку сообщения с указанием причины
+func (cs *CampaignPhoneStatus) CancelWithReason(reason string) {
+	if cs.status == CampaignStatusTypePending {
+		cs.status = CampaignStatusTypeCancelled
+		cs.error = reason
+	}
+}
+
 // Retry сбрасывает статус для повторной попытки отправки
 func (cs *CampaignPhoneStatus) Retry() {
 	if cs.CanBeRetried() {
@@ -160,9 +225,9 @@ func (cs *CampaignPhoneStatus) IsProcessed() bool {
 	return cs.status != CampaignStatusTypePending
 }
 
-// IsSuccessful проверяет, была ли отправка успешной
+// IsSuccessful проверяет, была ли отправка успешной (включая доставленные и прочитанные сообщения)
 func (cs *CampaignPhoneStatus) IsSuccessful() bool {
-	return cs.status == CampaignStatusTypeSent
+	return cs.status.IsSent()
 }
 
 // IsFailed проверяет, была ли отправка неуспешной
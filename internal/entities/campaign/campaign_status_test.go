@@ -0,0 +1,74 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func restoreStatus(status CampaignStatusType, deliveredAt, readAt *time.Time) *CampaignPhoneStatus {
+	createdAt := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	sentAt := createdAt.Add(time.Minute)
+	return RestoreCampaignStatusExtended("status-1", "campaign-1", "79161234567", status, "", "msg-1", &sentAt, deliveredAt, readAt, createdAt)
+}
+
+func TestCampaignPhoneStatus_MarkAsDelivered(t *testing.T) {
+	deliveredAt := time.Date(2024, 3, 8, 9, 5, 0, 0, time.UTC)
+	readAt := deliveredAt.Add(time.Minute)
+	at := readAt.Add(time.Minute)
+
+	tests := []struct {
+		name            string
+		status          *CampaignPhoneStatus
+		wantChanged     bool
+		wantStatus      CampaignStatusType
+		wantDeliveredAt *time.Time
+	}{
+		{name: "sent", status: restoreStatus(CampaignStatusTypeSent, nil, nil), wantChanged: true, wantStatus: CampaignStatusTypeDelivered, wantDeliveredAt: &at},
+		{name: "pending", status: restoreStatus(CampaignStatusTypePending, nil, nil), wantStatus: CampaignStatusTypePending},
+		{name: "failed", status: restoreStatus(CampaignStatusTypeFailed, nil, nil), wantStatus: CampaignStatusTypeFailed},
+		{name: "cancelled", status: restoreStatus(CampaignStatusTypeCancelled, nil, nil), wantStatus: CampaignStatusTypeCancelled},
+		{name: "already delivered", status: restoreStatus(CampaignStatusTypeDelivered, &deliveredAt, nil), wantStatus: CampaignStatusTypeDelivered, wantDeliveredAt: &deliveredAt},
+		{name: "read is not downgraded", status: restoreStatus(CampaignStatusTypeRead, &deliveredAt, &readAt), wantStatus: CampaignStatusTypeRead, wantDeliveredAt: &deliveredAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantChanged, tt.status.MarkAsDelivered(at))
+			require.Equal(t, tt.wantStatus, tt.status.Status())
+			require.Equal(t, tt.wantDeliveredAt, tt.status.DeliveredAt())
+		})
+	}
+}
+
+func TestCampaignPhoneStatus_MarkAsRead(t *testing.T) {
+	deliveredAt := time.Date(2024, 3, 8, 9, 5, 0, 0, time.UTC)
+	readAt := deliveredAt.Add(time.Minute)
+	at := readAt.Add(time.Minute)
+
+	tests := []struct {
+		name            string
+		status          *CampaignPhoneStatus
+		wantChanged     bool
+		wantStatus      CampaignStatusType
+		wantDeliveredAt *time.Time
+		wantReadAt      *time.Time
+	}{
+		{name: "sent implies delivery", status: restoreStatus(CampaignStatusTypeSent, nil, nil), wantChanged: true, wantStatus: CampaignStatusTypeRead, wantDeliveredAt: &at, wantReadAt: &at},
+		{name: "delivered", status: restoreStatus(CampaignStatusTypeDelivered, &deliveredAt, nil), wantChanged: true, wantStatus: CampaignStatusTypeRead, wantDeliveredAt: &deliveredAt, wantReadAt: &at},
+		{name: "pending", status: restoreStatus(CampaignStatusTypePending, nil, nil), wantStatus: CampaignStatusTypePending},
+		{name: "failed", status: restoreStatus(CampaignStatusTypeFailed, nil, nil), wantStatus: CampaignStatusTypeFailed},
+		{name: "cancelled", status: restoreStatus(CampaignStatusTypeCancelled, nil, nil), wantStatus: CampaignStatusTypeCancelled},
+		{name: "already read", status: restoreStatus(CampaignStatusTypeRead, &deliveredAt, &readAt), wantStatus: CampaignStatusTypeRead, wantDeliveredAt: &deliveredAt, wantReadAt: &readAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantChanged, tt.status.MarkAsRead(at))
+			require.Equal(t, tt.wantStatus, tt.status.Status())
+			require.Equal(t, tt.wantDeliveredAt, tt.status.DeliveredAt())
+			require.Equal(t, tt.wantReadAt, tt.status.ReadAt())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package campaign
+
+import "time"
+
+// StatsCounter определяет счетчик дневной статистики кампании
+type StatsCounter string
+
+const (
+	StatsCounterSent      StatsCounter = "sent"
+	StatsCounterDelivered StatsCounter = "delivered"
+	StatsCounterRead      StatsCounter = "read"
+	StatsCounterFailed    StatsCounter = "failed"
+)
+
+// DailyStats представляет агрегированную статистику отправки кампании за один день
+type DailyStats struct {
+	CampaignID   string
+	Date         time.Time
+	Sent         int
+	Delivered    int
+	Read         int
+	Failed       int
+	DeliveryRate float64 // Процент доставленных за день от всех отправленных сообщений кампании
+	ReadRate     float64 // Процент прочитанных за день от всех отправленных сообщений кампании
+}
@@ -7,11 +7,24 @@ import (
 var (
 	ErrCannotStartCampaign         = errors.New("campaign cannot be started")
 	ErrCannotCancelCampaign        = errors.New("campaign cannot be cancelled")
+	ErrCannotInterruptCampaign     = errors.New("campaign cannot be interrupted")
+	ErrCannotResumeCampaign        = errors.New("campaign cannot be resumed")
+	ErrCannotPauseCampaign         = errors.New("campaign cannot be paused")
+	ErrCannotScheduleCampaign      = errors.New("only pending campaigns can be scheduled")
+	ErrScheduledTimeInPast         = errors.New("scheduled time must be in the future")
+	ErrInvalidSendingWindow        = errors.New("invalid sending window")
+	ErrInvalidMessageVariants      = errors.New("invalid message variants")
+	ErrCannotRetryCampaign         = errors.New("failed numbers can be retried only for finished campaigns")
 	ErrCannotModifyRunningCampaign = errors.New("cannot modify running campaign")
 	ErrCampaignNotPending          = errors.New("campaign is not in pending status")
 	ErrNoPhoneNumbers              = errors.New("no phone numbers provided")
 	ErrInvalidPhoneNumber          = errors.New("invalid phone number")
+	ErrUnsupportedPhoneFile        = errors.New("unsupported phone file format: expected xlsx, xls, csv or tsv")
+	ErrPhoneFileSheetNotFound      = errors.New("sheet not found in phone file")
+	ErrPhoneFileTooLarge           = errors.New("phone file exceeds maximum allowed size")
+	ErrPhoneFileTooManyRows        = errors.New("phone file exceeds maximum allowed number of rows")
 	ErrPhoneNumberNotFound         = errors.New("phone number not found in campaign")
+	ErrOptOutNotFound              = errors.New("phone number not found in opt-out list")
 	ErrInvalidMessagesPerHour      = errors.New("invalid messages per hour rate")
 	ErrCampaignNotFound            = errors.New("campaign not found")
 	ErrRepositoryError             = errors.New("repository error")
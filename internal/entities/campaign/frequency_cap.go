@@ -0,0 +1,48 @@
+package campaign
+
+import (
+	"fmt"
+	"time"
+)
+
+// FrequencyCap ограничивает количество сообщений одному получателю из всех кампаний
+// за скользящее окно времени
+type FrequencyCap struct {
+	maxMessages int
+	window      time.Duration
+}
+
+// NewFrequencyCap создает лимит частоты отправки. Возвращает nil, если лимит отключен
+func NewFrequencyCap(maxMessages int, window time.Duration) *FrequencyCap {
+	if maxMessages <= 0 || window <= 0 {
+		return nil
+	}
+	return &FrequencyCap{
+		maxMessages: maxMessages,
+		window:      window,
+	}
+}
+
+// MaxMessages возвращает максимальное количество сообщений за окно
+func (f *FrequencyCap) MaxMessages() int {
+	return f.maxMessages
+}
+
+// WindowLabel возвращает окно в коротком виде: "7d" для окон, кратных суткам, иначе как time.Duration
+func (f *FrequencyCap) WindowLabel() string {
+	const day = 24 * time.Hour
+	if f.window%day == 0 {
+		return fmt.Sprintf("%dd", f.window/day)
+	}
+	return f.window.String()
+}
+
+// WindowStart возвращает начало окна, заканчивающегося в момент now
+func (f *FrequencyCap) WindowStart(now time.Time) time.Time {
+	return now.Add(-f.window)
+}
+
+// ErrorMessage возвращает причину исключения номера, сохраняемую в статусе номера кампании
+func (f *FrequencyCap) ErrorMessage() string {
+	return fmt.Sprintf("frequency cap exceeded: %d messages per %s", f.maxMessages, f.WindowLabel())
+}
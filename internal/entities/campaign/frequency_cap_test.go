@@ -0,0 +1,49 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFrequencyCap(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxMessages int
+		window      time.Duration
+		wantEnabled bool
+	}{
+		{name: "enabled", maxMessages: 3, window: 7 * 24 * time.Hour, wantEnabled: true},
+		{name: "single message", maxMessages: 1, window: time.Hour, wantEnabled: true},
+		{name: "zero messages disables", maxMessages: 0, window: 7 * 24 * time.Hour},
+		{name: "negative messages disables", maxMessages: -1, window: 7 * 24 * time.Hour},
+		{name: "zero window disables", maxMessages: 3, window: 0},
+		{name: "negative window disables", maxMessages: 3, window: -time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frequencyCap := NewFrequencyCap(tt.maxMessages, tt.window)
+			if !tt.wantEnabled {
+				require.Nil(t, frequencyCap)
+				return
+			}
+			require.NotNil(t, frequencyCap)
+			require.Equal(t, tt.maxMessages, frequencyCap.MaxMessages())
+		})
+	}
+}
+
+func TestFrequencyCap_Window(t *testing.T) {
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	weekly := NewFrequencyCap(3, 7*24*time.Hour)
+	require.Equal(t, "7d", weekly.WindowLabel())
+	require.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), weekly.WindowStart(now))
+	require.Equal(t, "frequency cap exceeded: 3 messages per 7d", weekly.ErrorMessage())
+
+	hourly := NewFrequencyCap(1, 90*time.Minute)
+	require.Equal(t, "1h30m0s", hourly.WindowLabel())
+	require.Equal(t, time.Date(2024, 3, 8, 10, 30, 0, 0, time.UTC), hourly.WindowStart(now))
+}
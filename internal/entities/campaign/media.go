@@ -1,6 +1,8 @@
 package campaign
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"mime"
 	"path/filepath"
 	"strings"
@@ -17,15 +19,20 @@ const (
 	MessageTypeDoc     MessageType = "doc"
 )
 
-// Media представляет медиа-файл как value object
+// Media представляет медиа-файл как value object.
+// Содержимое может храниться во внешнем хранилище: тогда data пустое,
+// а файл загружается по storagePath только при отправке
 type Media struct {
 	filename    string
 	mimeType    string
 	messageType MessageType
 	data        []byte
+	size        int64
+	checksumMD5 string
+	storagePath string
 }
 
-// NewMedia создает новый медиа-объект
+// NewMedia создает новый медиа-объект и вычисляет контрольную сумму содержимого
 func NewMedia(filename, mimeType string, data []byte) *Media {
 	if mimeType == "" {
 		ext := filepath.Ext(filename)
@@ -35,11 +42,27 @@ func NewMedia(filename, mimeType string, data []byte) *Media {
 		}
 	}
 
+	checksum := md5.Sum(data)
+
 	return &Media{
 		filename:    filename,
 		mimeType:    mimeType,
 		messageType: detectMessageType(mimeType),
 		data:        data,
+		size:        int64(len(data)),
+		checksumMD5: hex.EncodeToString(checksum[:]),
+	}
+}
+
+// RestoreMedia восстанавливает медиа-объект, содержимое которого находится во внешнем хранилище
+func RestoreMedia(filename, mimeType string, messageType MessageType, size int64, checksumMD5, storagePath string) *Media {
+	return &Media{
+		filename:    filename,
+		mimeType:    mimeType,
+		messageType: messageType,
+		size:        size,
+		checksumMD5: checksumMD5,
+		storagePath: storagePath,
 	}
 }
 
@@ -63,14 +86,29 @@ func (m *Media) SetMessageType(mt MessageType) {
 	m.messageType = mt
 }
 
-// Data возвращает данные файла
+// Data возвращает данные файла (nil, если содержимое находится во внешнем хранилище)
 func (m *Media) Data() []byte {
 	return m.data
 }
 
 // Size возвращает размер файла в байтах
 func (m *Media) Size() int64 {
-	return int64(len(m.data))
+	return m.size
+}
+
+// ChecksumMD5 возвращает MD5 содержимого файла в hex
+func (m *Media) ChecksumMD5() string {
+	return m.checksumMD5
+}
+
+// StoragePath возвращает путь файла во внешнем хранилище (пусто, если содержимое хранится в БД)
+func (m *Media) StoragePath() string {
+	return m.storagePath
+}
+
+// SetStoragePath задает путь, по которому содержимое сохранено во внешнем хранилище
+func (m *Media) SetStoragePath(path string) {
+	m.storagePath = path
 }
 
 // IsValid проверяет валидность медиа-файла
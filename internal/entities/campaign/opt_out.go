@@ -0,0 +1,56 @@
+package campaign
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// OptOutSource определяет, откуда номер попал в стоп-лист
+type OptOutSource string
+
+const (
+	OptOutSourceManual  OptOutSource = "manual"  // Добавлен вручную
+	OptOutSourceImport  OptOutSource = "import"  // Загружен списком
+	OptOutSourceInbound OptOutSource = "inbound" // Получатель ответил стоп-словом
+)
+
+// OptOutErrorMessage текст ошибки номера кампании, исключенного из отправки по стоп-листу
+const OptOutErrorMessage = "recipient opted out"
+
+// OptOut представляет номер из глобального стоп-листа: на него не отправляется ни одна кампания
+type OptOut struct {
+	PhoneNumber string
+	Reason      string
+	Source      OptOutSource
+	CreatedAt   time.Time
+}
+
+// NewOptOut создает запись стоп-листа для номера
+func NewOptOut(phone *PhoneNumber, reason string, source OptOutSource) *OptOut {
+	return &OptOut{
+		PhoneNumber: phone.Value(),
+		Reason:      reason,
+		Source:      source,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// IsOptOutKeyword проверяет, что входящее сообщение целиком состоит из стоп-слова.
+// Регистр, пробелы и знаки препинания по краям не учитываются, чтобы "Стоп!" и " STOP " совпадали,
+// а обычные ответы, в которых стоп-слово встречается внутри текста, — нет
+func IsOptOutKeyword(text string, keywords []string) bool {
+	normalized := strings.ToLower(strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+	if normalized == "" {
+		return false
+	}
+
+	for _, keyword := range keywords {
+		if normalized == strings.ToLower(strings.TrimSpace(keyword)) {
+			return true
+		}
+	}
+	return false
+}
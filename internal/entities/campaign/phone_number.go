@@ -1,21 +1,69 @@
 package campaign
 
 import (
-	"regexp"
+	"fmt"
+	"strings"
 )
 
-// PhoneNumber представляет номер телефона как value object
+// PhoneNumber представляет номер телефона как value object.
+// Значение хранится в международном формате E.164 без "+": код страны и национальный номер
 type PhoneNumber struct {
 	value string
 }
 
-// NewPhoneNumber создает новый номер телефона после валидации
-func NewPhoneNumber(phone string) (*PhoneNumber, error) {
-	normalized := normalizePhone(phone)
-	if !isValidPhone(normalized) {
+// PhoneCorrection описывает исправление, примененное к номеру при нормализации
+type PhoneCorrection string
+
+const (
+	PhoneCorrectionFormatting          PhoneCorrection = "formatting_removed"           // Удалены пробелы, скобки, дефисы и "+"
+	PhoneCorrectionInternationalPrefix PhoneCorrection = "international_prefix_removed" // Удален международный префикс 00
+	PhoneCorrectionTrunkPrefix         PhoneCorrection = "trunk_prefix_replaced"        // Префикс 8 заменен кодом страны
+	PhoneCorrectionCountryCode         PhoneCorrection = "country_code_added"           // Добавлен код страны по умолчанию
+)
+
+// PhoneNormalizer приводит номера к международному формату.
+// Номер без кода страны (например, 8XXXXXXXXXX или XXXXXXXXXX) считается номером страны по умолчанию
+type PhoneNormalizer struct {
+	country PhoneCountry
+}
+
+// NewPhoneNormalizer создает нормализатор номеров со страной по умолчанию isoCode (ISO 3166-1 alpha-2)
+func NewPhoneNormalizer(isoCode string) (*PhoneNormalizer, error) {
+	country, ok := PhoneCountryByISO(isoCode)
+	if !ok {
+		return nil, fmt.Errorf("unsupported phone country: %s", isoCode)
+	}
+	return &PhoneNormalizer{country: country}, nil
+}
+
+// Country возвращает страну по умолчанию для номеров без кода страны
+func (n *PhoneNormalizer) Country() PhoneCountry {
+	return n.country
+}
+
+// NewPhoneNumber создает новый номер телефона после нормализации и валидации
+func (n *PhoneNormalizer) NewPhoneNumber(phone string) (*PhoneNumber, error) {
+	number, _, err := n.Normalize(phone)
+	return number, err
+}
+
+// Normalize приводит номер к международному формату и возвращает
+// примененные исправления в порядке применения. Пустой список означает, что номер уже был нормализован
+func (n *PhoneNormalizer) Normalize(phone string) (*PhoneNumber, []PhoneCorrection, error) {
+	normalized, corrections, ok := normalizePhone(phone, n.country)
+	if !ok {
+		return nil, nil, ErrInvalidPhoneNumber
+	}
+	return &PhoneNumber{value: normalized}, corrections, nil
+}
+
+// RestorePhoneNumber восстанавливает уже нормализованный номер (например, из хранилища).
+// Принимается только международный формат без "+", страна по умолчанию не применяется
+func RestorePhoneNumber(value string) (*PhoneNumber, error) {
+	if !IsValidPhoneNumber(value) {
 		return nil, ErrInvalidPhoneNumber
 	}
-	return &PhoneNumber{value: normalized}, nil
+	return &PhoneNumber{value: value}, nil
 }
 
 // Equal проверяет равенство двух номеров телефонов
@@ -36,15 +84,132 @@ func (p *PhoneNumber) String() string {
 	return p.value
 }
 
-// normalizePhone удаляет все нецифровые символы из строки
-func normalizePhone(s string) string {
-	re := regexp.MustCompile(`\D`)
-	return re.ReplaceAllString(s, "")
+// PhoneCountry описывает правила нумерации страны
+type PhoneCountry struct {
+	ISOCode         string // Код страны ISO 3166-1 alpha-2
+	CallingCode     string // Телефонный код страны
+	TrunkPrefix     string // Префикс междугородной связи в национальном формате (8 для России)
+	NationalLengths []int  // Допустимая длина национального номера
+}
+
+// phoneCountries поддерживаемые страны. Россия и Казахстан делят код 7
+var phoneCountries = []PhoneCountry{
+	{ISOCode: "RU", CallingCode: "7", TrunkPrefix: "8", NationalLengths: []int{10}},
+	{ISOCode: "KZ", CallingCode: "7", TrunkPrefix: "8", NationalLengths: []int{10}},
+	{ISOCode: "BY", CallingCode: "375", TrunkPrefix: "80", NationalLengths: []int{9}},
+	{ISOCode: "UZ", CallingCode: "998", TrunkPrefix: "8", NationalLengths: []int{9}},
+	{ISOCode: "KG", CallingCode: "996", TrunkPrefix: "0", NationalLengths: []int{9}},
+	{ISOCode: "TJ", CallingCode: "992", TrunkPrefix: "8", NationalLengths: []int{9}},
+	{ISOCode: "AM", CallingCode: "374", TrunkPrefix: "0", NationalLengths: []int{8}},
+	{ISOCode: "AZ", CallingCode: "994", TrunkPrefix: "0", NationalLengths: []int{9}},
+	{ISOCode: "GE", CallingCode: "995", TrunkPrefix: "0", NationalLengths: []int{9}},
+	{ISOCode: "MD", CallingCode: "373", TrunkPrefix: "0", NationalLengths: []int{8}},
+	{ISOCode: "UA", CallingCode: "380", TrunkPrefix: "0", NationalLengths: []int{9}},
+	{ISOCode: "TR", CallingCode: "90", TrunkPrefix: "0", NationalLengths: []int{10}},
+	{ISOCode: "AE", CallingCode: "971", TrunkPrefix: "0", NationalLengths: []int{8, 9}},
+}
+
+// PhoneCountryByISO возвращает правила нумерации страны по коду ISO
+func PhoneCountryByISO(isoCode string) (PhoneCountry, bool) {
+	isoCode = strings.ToUpper(strings.TrimSpace(isoCode))
+	for _, country := range phoneCountries {
+		if country.ISOCode == isoCode {
+			return country, true
+		}
+	}
+	return PhoneCountry{}, false
+}
+
+// IsValidPhoneNumber проверяет, что строка — номер в международном формате без "+"
+// (код поддерживаемой страны и национальный номер допустимой длины)
+func IsValidPhoneNumber(s string) bool {
+	if !isDigits(s) {
+		return false
+	}
+	_, ok := matchInternational(s)
+	return ok
 }
 
-var phoneRegexp = regexp.MustCompile(`^7\d{10}$`)
+// normalizePhone приводит номер к международному формату без "+".
+// Номер с "+" или "00" разбирается только как международный, остальные сначала
+// пробуются как национальные номера страны по умолчанию
+func normalizePhone(s string, country PhoneCountry) (string, []PhoneCorrection, bool) {
+	s = strings.TrimSpace(s)
+	international := strings.HasPrefix(s, "+")
 
-// isValidPhone проверяет, что строка — валидный российский номер (11 цифр, начинается с 7)
-func isValidPhone(s string) bool {
-	return phoneRegexp.MatchString(s)
+	var corrections []PhoneCorrection
+	digits := extractDigits(s)
+	if digits == "" {
+		return "", nil, false
+	}
+	if digits != s {
+		corrections = append(corrections, PhoneCorrectionFormatting)
+	}
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+		corrections = append(corrections, PhoneCorrectionInternationalPrefix)
+	}
+
+	if international {
+		normalized, ok := matchInternational(digits)
+		return normalized, corrections, ok
+	}
+
+	if national, ok := strings.CutPrefix(digits, country.TrunkPrefix); ok && country.TrunkPrefix != "" &&
+		country.hasNationalLength(len(national)) {
+		return country.CallingCode + national, append(corrections, PhoneCorrectionTrunkPrefix), true
+	}
+	if country.hasNationalLength(len(digits)) {
+		return country.CallingCode + digits, append(corrections, PhoneCorrectionCountryCode), true
+	}
+
+	normalized, ok := matchInternational(digits)
+	return normalized, corrections, ok
+}
+
+// matchInternational проверяет номер с кодом страны по правилам нумерации страны
+func matchInternational(digits string) (string, bool) {
+	for _, country := range phoneCountries {
+		national, ok := strings.CutPrefix(digits, country.CallingCode)
+		if ok && country.hasNationalLength(len(national)) {
+			return digits, true
+		}
+	}
+	return "", false
+}
+
+// hasNationalLength проверяет допустимость длины национального номера
+func (c PhoneCountry) hasNationalLength(length int) bool {
+	for _, allowed := range c.NationalLengths {
+		if allowed == length {
+			return true
+		}
+	}
+	return false
+}
+
+// extractDigits удаляет все нецифровые символы из строки
+func extractDigits(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isDigits проверяет, что строка непустая и состоит только из цифр
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -2,13 +2,39 @@ package repository
 
 import (
 	"context"
+	"iter"
+	"time"
 	"whatsapp-service/internal/entities/campaign"
 )
 
+// PhoneStatusFilter задает фильтр статусов номеров кампании
+type PhoneStatusFilter struct {
+	Statuses []campaign.CampaignStatusType // Фильтр по статусам (пусто = все)
+	Search   string                        // Подстрока номера телефона (пусто = все)
+}
+
+// PhoneStatusPage задает страницу статусов номеров кампании.
+// Номера упорядочены по порядку добавления; если задан AfterID, страница читается по ключу (keyset), иначе по Offset
+type PhoneStatusPage struct {
+	PhoneStatusFilter
+	AfterID string // ID последнего номера предыдущей страницы (пусто = с начала)
+	Offset  int    // Смещение, если AfterID не задан
+	Limit   int    // Размер страницы
+}
+
+// PhoneStatusCount количество номеров кампании с данным вариантом сообщения и статусом
+type PhoneStatusCount struct {
+	Variant string // Пустая строка для кампаний без вариантов
+	Status  campaign.CampaignStatusType
+	Count   int
+}
+
 // CampaignRepository определяет интерфейс для работы с хранилищем кампаний
 type CampaignRepository interface {
 	// Основные операции с кампаниями
 	Save(ctx context.Context, campaign *campaign.Campaign) error
+	// SaveWithPhoneStatuses сохраняет кампанию и пакеты статусов ее номеров в одной транзакции
+	SaveWithPhoneStatuses(ctx context.Context, campaign *campaign.Campaign, batches iter.Seq[[]*campaign.CampaignPhoneStatus]) error
 	GetByID(ctx context.Context, id string) (*campaign.Campaign, error)
 	Update(ctx context.Context, campaign *campaign.Campaign) error
 	Delete(ctx context.Context, id string) error
@@ -19,9 +45,12 @@ type CampaignRepository interface {
 	UpdateProcessedCount(ctx context.Context, id string, processedCount int) error
 	IncrementProcessedCount(ctx context.Context, id string) error
 	IncrementErrorCount(ctx context.Context, id string) error
+	IncrementDeliveredCount(ctx context.Context, id string) error
+	IncrementReadCount(ctx context.Context, id string) error
 
 	// Активные кампании
 	GetActiveCampaigns(ctx context.Context) ([]*campaign.Campaign, error)
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]*campaign.Campaign, error)
 
 	// Дополнительные методы для List операции
 	ListByStatus(ctx context.Context, status string, limit, offset int) ([]*campaign.Campaign, error)
@@ -30,15 +59,41 @@ type CampaignRepository interface {
 
 	// Операции со статусами номеров телефонов
 	SavePhoneStatus(ctx context.Context, status *campaign.CampaignPhoneStatus) error
+	// SavePhoneStatusBatches сохраняет пакеты статусов номеров в одной транзакции: каждый пакет
+	// одним запросом, при ошибке любого пакета не сохраняется ни один
+	SavePhoneStatusBatches(ctx context.Context, batches iter.Seq[[]*campaign.CampaignPhoneStatus]) error
 	GetPhoneStatusByID(ctx context.Context, id string) (*campaign.CampaignPhoneStatus, error)
+	GetPhoneStatusByMessageID(ctx context.Context, whatsappMessageID string) (*campaign.CampaignPhoneStatus, error)
 	UpdatePhoneStatus(ctx context.Context, status *campaign.CampaignPhoneStatus) error
+	// RetryFailedPhoneStatuses в одной транзакции возвращает в pending неудачные номера phoneNumbers
+	// и сохраняет статус и счетчики кампании. Если часть номеров уже не в статусе failed, изменения откатываются
+	RetryFailedPhoneStatuses(ctx context.Context, campaign *campaign.Campaign, phoneNumbers []string) error
+	// UpdatePhoneDeliveryStatus сохраняет статус доставки, только если в БД номер еще в статусе previousStatus.
+	// Возвращает false, если статус уже был изменен (например, повторным подтверждением)
+	UpdatePhoneDeliveryStatus(ctx context.Context, status *campaign.CampaignPhoneStatus, previousStatus campaign.CampaignStatusType) (bool, error)
 	UpdatePhoneStatusByNumber(ctx context.Context, campaignID, phoneNumber string, newStatus campaign.CampaignStatusType, errorMessage string) error
+	// CancelPendingPhoneStatusesByNumber отменяет ожидающие отправки записи номера во всех кампаниях.
+	// Возвращает количество отмененных записей
+	CancelPendingPhoneStatusesByNumber(ctx context.Context, phoneNumber, errorMessage string) (int, error)
+	// FilterFrequencyCapped возвращает номера из phoneNumbers, которым во всех кампаниях
+	// отправлено не меньше maxMessages сообщений начиная с since
+	FilterFrequencyCapped(ctx context.Context, phoneNumbers []string, since time.Time, maxMessages int) ([]string, error)
+	// UpdatePhoneSendResultByNumber сохраняет результат отправки: статус, ошибку, ID сообщения провайдера и время отправки.
+	// Обновляется только номер в статусе "pending": у отмененного номера сохраняются лишь время отправки и ID сообщения.
+	// Возвращает количество номеров, перешедших из "pending"
+	UpdatePhoneSendResultByNumber(ctx context.Context, campaignID, phoneNumber string, newStatus campaign.CampaignStatusType, errorMessage, whatsappMessageID string, sentAt *time.Time) (int64, error)
 	ListPhoneStatusesByCampaignID(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error)
+	// ListPhoneStatusesPage возвращает страницу статусов номеров, не загружая кампанию целиком
+	ListPhoneStatusesPage(ctx context.Context, campaignID string, page PhoneStatusPage) ([]*campaign.CampaignPhoneStatus, error)
+	CountPhoneStatusesByFilter(ctx context.Context, campaignID string, filter PhoneStatusFilter) (int, error)
+	// CountPhoneStatusesGrouped возвращает количество номеров кампании в разрезе варианта сообщения и статуса
+	CountPhoneStatusesGrouped(ctx context.Context, campaignID string) ([]PhoneStatusCount, error)
 	UpdatePhoneStatusesByCampaignID(ctx context.Context, campaignID string, oldStatus, newStatus campaign.CampaignStatusType) error
 	MarkPhoneAsSent(ctx context.Context, id string) error
 	MarkPhoneAsFailed(ctx context.Context, id string, errorMsg string) error
 	MarkPhoneAsCancelled(ctx context.Context, id string) error
 	GetSentPhoneNumbers(ctx context.Context, campaignID string) ([]string, error)
 	GetFailedPhoneStatuses(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error)
+	GetPendingPhoneStatuses(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error)
 	CountPhoneStatusesByCampaignID(ctx context.Context, campaignID string, status campaign.CampaignStatusType) (int, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+)
+
+// CampaignStatsRepository определяет операции с дневной статистикой кампаний
+type CampaignStatsRepository interface {
+	// Increment увеличивает счетчик статистики кампании за день date на единицу
+	Increment(ctx context.Context, campaignID string, date time.Time, counter campaign.StatsCounter) error
+
+	// ListByPeriod возвращает дневную статистику кампании за период [from, to] по возрастанию даты
+	ListByPeriod(ctx context.Context, campaignID string, from, to time.Time) ([]*campaign.DailyStats, error)
+}
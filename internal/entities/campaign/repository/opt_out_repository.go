@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"whatsapp-service/internal/entities/campaign"
+)
+
+// OptOutRepository определяет операции с глобальным стоп-листом номеров
+type OptOutRepository interface {
+	// Add добавляет номер в стоп-лист. Возвращает false, если номер уже был в списке
+	Add(ctx context.Context, optOut *campaign.OptOut) (bool, error)
+
+	// AddBatch добавляет номера в стоп-лист, пропуская уже существующие. Возвращает количество добавленных
+	AddBatch(ctx context.Context, optOuts []*campaign.OptOut) (int, error)
+
+	// Delete удаляет номер из стоп-листа. Возвращает campaign.ErrOptOutNotFound, если номера нет в списке
+	Delete(ctx context.Context, phoneNumber string) error
+
+	// List возвращает номера стоп-листа, содержащие search, от новых к старым
+	List(ctx context.Context, search string, limit, offset int) ([]*campaign.OptOut, error)
+
+	// Count возвращает количество номеров стоп-листа, содержащих search
+	Count(ctx context.Context, search string) (int, error)
+
+	// FilterOptedOut возвращает номера из phoneNumbers, находящиеся в стоп-листе
+	FilterOptedOut(ctx context.Context, phoneNumbers []string) ([]string, error)
+}
@@ -0,0 +1,63 @@
+package campaign
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Максимальная длина нормализованной причины ошибки
+const maxSendErrorReasonLength = 120
+
+// retryableSendErrors — фрагменты текста ошибок отправки, при которых имеет смысл повторить попытку
+var retryableSendErrors = []string{
+	"network error",
+	"timeout",
+	"connection refused",
+	"server error",
+	"temporary failure",
+}
+
+var (
+	sendErrorAttemptPrefix = regexp.MustCompile(`^attempt \d+ failed:\s*`)
+	sendErrorURL           = regexp.MustCompile(`https?://\S+`)
+	sendErrorQuoted        = regexp.MustCompile(`"[^"]*"`)
+	sendErrorAddress       = regexp.MustCompile(`\d{1,3}(\.\d{1,3}){3}(:\d+)?`)
+	sendErrorLongNumber    = regexp.MustCompile(`\d{5,}`)
+)
+
+// IsRetryableSendError определяет, стоит ли повторять отправку при данной ошибке
+func IsRetryableSendError(errorMsg string) bool {
+	lower := strings.ToLower(errorMsg)
+	for _, retryable := range retryableSendErrors {
+		if strings.Contains(lower, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeSendError приводит текст ошибки отправки к причине, общей для всех номеров:
+// убирает номер попытки, адреса, URL, тело ответа сервера и длинные числа (номера телефонов, ID)
+func NormalizeSendError(errorMsg string) string {
+	reason := strings.ToLower(strings.TrimSpace(errorMsg))
+	reason = sendErrorAttemptPrefix.ReplaceAllString(reason, "")
+
+	// Тело ответа сервера после " - " уникально для каждого запроса
+	if idx := strings.Index(reason, " - "); idx > 0 {
+		reason = reason[:idx]
+	}
+
+	reason = sendErrorURL.ReplaceAllString(reason, "<url>")
+	reason = sendErrorQuoted.ReplaceAllString(reason, `"…"`)
+	reason = sendErrorAddress.ReplaceAllString(reason, "<addr>")
+	reason = sendErrorLongNumber.ReplaceAllString(reason, "<n>")
+	reason = strings.Join(strings.Fields(reason), " ")
+
+	if runes := []rune(reason); len(runes) > maxSendErrorReasonLength {
+		reason = string(runes[:maxSendErrorReasonLength]) + "…"
+	}
+	if reason == "" {
+		return "unknown error"
+	}
+	return reason
+}
@@ -0,0 +1,177 @@
+package campaign
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SendingWindow представляет разрешенное окно отправки сообщений как value object.
+// Окно задается временем начала и окончания в выбранном часовом поясе и, опционально, днями недели.
+// Если время окончания меньше времени начала, окно переходит через полночь (например, 22:00–02:00),
+// а день недели определяется по дню открытия окна
+type SendingWindow struct {
+	startMinute int
+	endMinute   int
+	location    *time.Location
+	weekdays    []time.Weekday
+}
+
+// NewSendingWindow создает окно отправки.
+// start и end задаются в формате "15:04", timezone — имя из базы IANA (пустая строка = UTC),
+// weekdays — разрешенные дни недели (пустой список = все дни)
+func NewSendingWindow(start, end, timezone string, weekdays []time.Weekday) (*SendingWindow, error) {
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return nil, fmt.Errorf("%w: start: %s", ErrInvalidSendingWindow, err.Error())
+	}
+
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return nil, fmt.Errorf("%w: end: %s", ErrInvalidSendingWindow, err.Error())
+	}
+
+	if startMinute == endMinute {
+		return nil, fmt.Errorf("%w: start and end must differ", ErrInvalidSendingWindow)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSendingWindow, timezone)
+	}
+
+	days := make([]time.Weekday, 0, len(weekdays))
+	seen := make(map[time.Weekday]struct{}, len(weekdays))
+	for _, day := range weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("%w: invalid weekday %d", ErrInvalidSendingWindow, day)
+		}
+		if _, exists := seen[day]; exists {
+			continue
+		}
+		seen[day] = struct{}{}
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+
+	return &SendingWindow{
+		startMinute: startMinute,
+		endMinute:   endMinute,
+		location:    location,
+		weekdays:    days,
+	}, nil
+}
+
+// parseClock переводит время "15:04" в минуты от начала суток
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Start возвращает время открытия окна в формате "15:04"
+func (w *SendingWindow) Start() string { return formatClock(w.startMinute) }
+
+// End возвращает время закрытия окна в формате "15:04"
+func (w *SendingWindow) End() string { return formatClock(w.endMinute) }
+
+// Timezone возвращает имя часового пояса окна
+func (w *SendingWindow) Timezone() string { return w.location.String() }
+
+// Weekdays возвращает разрешенные дни недели (пустой список = все дни)
+func (w *SendingWindow) Weekdays() []time.Weekday { return w.weekdays }
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// Contains проверяет, попадает ли момент времени в окно отправки
+func (w *SendingWindow) Contains(t time.Time) bool {
+	local := t.In(w.location)
+	minute := local.Hour()*60 + local.Minute()
+
+	if w.startMinute < w.endMinute {
+		return minute >= w.startMinute && minute < w.endMinute && w.allowsDay(local.Weekday())
+	}
+
+	// Окно через полночь: вечерняя часть относится к текущему дню, утренняя — к предыдущему
+	if minute >= w.startMinute {
+		return w.allowsDay(local.Weekday())
+	}
+	if minute < w.endMinute {
+		return w.allowsDay(local.AddDate(0, 0, -1).Weekday())
+	}
+	return false
+}
+
+// NextOpen возвращает ближайший момент открытия окна, начиная с t.
+// Если t уже внутри окна, возвращается t
+func (w *SendingWindow) NextOpen(t time.Time) time.Time {
+	if w.Contains(t) {
+		return t
+	}
+
+	local := t.In(w.location)
+
+	// Неделя + 1 день покрывает любой набор разрешенных дней
+	for day := 0; day <= 7; day++ {
+		open := w.clockAt(local, day, w.startMinute)
+		if open.After(t) && w.allowsDay(open.Weekday()) {
+			return open
+		}
+	}
+
+	return t
+}
+
+// closeAfter возвращает момент закрытия окна, открытого в момент t (t должен быть внутри окна)
+func (w *SendingWindow) closeAfter(t time.Time) time.Time {
+	local := t.In(w.location)
+	closeAt := w.clockAt(local, 0, w.endMinute)
+
+	if !closeAt.After(t) {
+		closeAt = w.clockAt(local, 1, w.endMinute)
+	}
+	return closeAt
+}
+
+// clockAt возвращает момент minute минут от начала суток через days дней после дня local.
+// Время собирается по часам и минутам, а не прибавлением длительности к полуночи,
+// чтобы в дни перехода на летнее/зимнее время окно открывалось по местным часам
+func (w *SendingWindow) clockAt(local time.Time, days, minute int) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day()+days, minute/60, minute%60, 0, 0, w.location)
+}
+
+// CompletionTime оценивает момент завершения отправки, которая требует sendingDuration
+// чистого времени внутри окна, если отправка начинается в момент from
+func (w *SendingWindow) CompletionTime(from time.Time, sendingDuration time.Duration) time.Time {
+	current := from
+	remaining := sendingDuration
+
+	// Ограничиваем количество итераций, чтобы некорректное окно не привело к бесконечному циклу
+	for i := 0; remaining > 0 && i < 10000; i++ {
+		current = w.NextOpen(current)
+		available := w.closeAfter(current).Sub(current)
+		if available >= remaining {
+			return current.Add(remaining)
+		}
+		remaining -= available
+		current = current.Add(available)
+	}
+
+	return current.Add(remaining)
+}
+
+func (w *SendingWindow) allowsDay(day time.Weekday) bool {
+	if len(w.weekdays) == 0 {
+		return true
+	}
+	for _, allowed := range w.weekdays {
+		if allowed == day {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package campaign
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// weekdaysOnly будние дни. В тестах 8 марта 2024 — пятница, 11 марта — понедельник
+var weekdaysOnly = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+func mustSendingWindow(t *testing.T, start, end, timezone string, weekdays []time.Weekday) *SendingWindow {
+	t.Helper()
+	window, err := NewSendingWindow(start, end, timezone, weekdays)
+	require.NoError(t, err)
+	return window
+}
+
+func TestSendingWindow_Contains(t *testing.T) {
+	normal := mustSendingWindow(t, "09:00", "18:00", "", nil)
+	overnight := mustSendingWindow(t, "22:00", "02:00", "", weekdaysOnly)
+	moscow := mustSendingWindow(t, "09:00", "18:00", "Europe/Moscow", nil)
+
+	testCases := []struct {
+		name     string
+		window   *SendingWindow
+		at       time.Time
+		expected bool
+	}{
+		{"normal_inside", normal, time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC), true},
+		{"normal_at_start", normal, time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC), true},
+		{"normal_at_end", normal, time.Date(2024, 3, 8, 18, 0, 0, 0, time.UTC), false},
+		{"normal_before_start", normal, time.Date(2024, 3, 8, 8, 59, 0, 0, time.UTC), false},
+		{"overnight_friday_evening", overnight, time.Date(2024, 3, 8, 23, 0, 0, 0, time.UTC), true},
+		{"overnight_saturday_morning_after_friday", overnight, time.Date(2024, 3, 9, 1, 0, 0, 0, time.UTC), true},
+		{"overnight_saturday_evening", overnight, time.Date(2024, 3, 9, 23, 0, 0, 0, time.UTC), false},
+		{"overnight_monday_morning_after_sunday", overnight, time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC), false},
+		{"overnight_daytime", overnight, time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC), false},
+		{"moscow_before_open", moscow, time.Date(2024, 3, 8, 5, 30, 0, 0, time.UTC), false},
+		{"moscow_open", moscow, time.Date(2024, 3, 8, 6, 0, 0, 0, time.UTC), true},
+		{"moscow_closed_utc_daytime", moscow, time.Date(2024, 3, 8, 16, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.window.Contains(tc.at))
+		})
+	}
+}
+
+func TestSendingWindow_NextOpen(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		window   *SendingWindow
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "inside_window",
+			window:   mustSendingWindow(t, "09:00", "18:00", "", nil),
+			from:     time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "after_close_opens_next_day",
+			window:   mustSendingWindow(t, "09:00", "18:00", "", nil),
+			from:     time.Date(2024, 3, 8, 19, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 9, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "overnight_morning_after_disallowed_day",
+			window:   mustSendingWindow(t, "22:00", "02:00", "", weekdaysOnly),
+			from:     time.Date(2024, 3, 11, 1, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 11, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays_skip_weekend",
+			window:   mustSendingWindow(t, "09:00", "18:00", "", weekdaysOnly),
+			from:     time.Date(2024, 3, 8, 18, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "non_utc_zone",
+			window:   mustSendingWindow(t, "09:00", "18:00", "Europe/Moscow", nil),
+			from:     time.Date(2024, 3, 8, 16, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 9, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			// 10 марта 2024 в Нью-Йорке часы переводятся с 02:00 на 03:00
+			name:     "dst_spring_forward",
+			window:   mustSendingWindow(t, "09:00", "17:00", "America/New_York", nil),
+			from:     time.Date(2024, 3, 10, 0, 30, 0, 0, newYork),
+			expected: time.Date(2024, 3, 10, 9, 0, 0, 0, newYork),
+		},
+		{
+			// 3 ноября 2024 в Нью-Йорке часы переводятся с 02:00 на 01:00
+			name:     "dst_fall_back",
+			window:   mustSendingWindow(t, "09:00", "17:00", "America/New_York", nil),
+			from:     time.Date(2024, 11, 3, 0, 30, 0, 0, newYork),
+			expected: time.Date(2024, 11, 3, 9, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.True(t, tc.expected.Equal(tc.window.NextOpen(tc.from)),
+				"expected %s, got %s", tc.expected, tc.window.NextOpen(tc.from))
+		})
+	}
+}
+
+func TestSendingWindow_CompletionTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		window   *SendingWindow
+		from     time.Time
+		duration time.Duration
+		expected time.Time
+	}{
+		{
+			name:     "fits_in_current_window",
+			window:   mustSendingWindow(t, "09:00", "18:00", "", nil),
+			from:     time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC),
+			duration: 3 * time.Hour,
+			expected: time.Date(2024, 3, 8, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays_across_weekend",
+			window:   mustSendingWindow(t, "09:00", "18:00", "", weekdaysOnly),
+			from:     time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC),
+			duration: 2 * time.Hour,
+			expected: time.Date(2024, 3, 11, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "overnight_window",
+			window:   mustSendingWindow(t, "22:00", "02:00", "", nil),
+			from:     time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC),
+			duration: 6 * time.Hour,
+			expected: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "non_utc_zone",
+			window:   mustSendingWindow(t, "09:00", "18:00", "Europe/Moscow", nil),
+			from:     time.Date(2024, 3, 8, 14, 0, 0, 0, time.UTC),
+			duration: 2 * time.Hour,
+			expected: time.Date(2024, 3, 9, 7, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.window.CompletionTime(tc.from, tc.duration)
+			require.True(t, tc.expected.Equal(got), "expected %s, got %s", tc.expected, got)
+		})
+	}
+}
+
+func TestNewSendingWindow_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		start    string
+		end      string
+		timezone string
+		weekdays []time.Weekday
+	}{
+		{"invalid_start", "9am", "18:00", "", nil},
+		{"invalid_end", "09:00", "25:00", "", nil},
+		{"equal_start_and_end", "09:00", "09:00", "", nil},
+		{"unknown_timezone", "09:00", "18:00", "Mars/Olympus", nil},
+		{"invalid_weekday", "09:00", "18:00", "", []time.Weekday{7}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSendingWindow(tc.start, tc.end, tc.timezone, tc.weekdays)
+			require.True(t, errors.Is(err, ErrInvalidSendingWindow), "unexpected error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package campaign
+
+import (
+	"regexp"
+	"strings"
+)
+
+// templateVariablePattern находит плейсхолдеры вида {{name}} или {{ order_number }}
+var templateVariablePattern = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+
+// NormalizeVariableName приводит имя переменной (или заголовок колонки) к виду, используемому в шаблонах:
+// нижний регистр, пробелы заменены подчеркиваниями
+func NormalizeVariableName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "_"))
+}
+
+// TemplateVariables возвращает уникальные имена переменных, используемых в тексте сообщения, в порядке появления
+func TemplateVariables(message string) []string {
+	matches := templateVariablePattern.FindAllStringSubmatch(message, -1)
+
+	seen := make(map[string]struct{}, len(matches))
+	result := make([]string, 0, len(matches))
+	for _, match := range matches {
+		name := NormalizeVariableName(match[1])
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		result = append(result, name)
+	}
+	return result
+}
+
+// RenderTemplate подставляет переменные получателя в текст сообщения.
+// Переменные, для которых нет значения, заменяются пустой строкой
+func RenderTemplate(message string, variables map[string]string) string {
+	if !strings.Contains(message, "{{") {
+		return message
+	}
+
+	return templateVariablePattern.ReplaceAllStringFunc(message, func(placeholder string) string {
+		name := templateVariablePattern.FindStringSubmatch(placeholder)[1]
+		return variables[NormalizeVariableName(name)]
+	})
+}
@@ -0,0 +1,116 @@
+package campaign
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ограничения для вариантов сообщения
+const (
+	MinMessageVariants = 2
+	MaxMessageVariants = 3
+)
+
+// MessageVariant представляет вариант сообщения кампании для A/B тестирования.
+// Пустой текст или отсутствие медиа означают, что используется текст или медиа кампании
+type MessageVariant struct {
+	name    string
+	message string
+	media   *Media
+	percent int
+}
+
+// NewMessageVariant создает вариант сообщения с долей получателей percent (в процентах)
+func NewMessageVariant(name, message string, media *Media, percent int) (*MessageVariant, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: variant name is required", ErrInvalidMessageVariants)
+	}
+	if percent <= 0 || percent > 100 {
+		return nil, fmt.Errorf("%w: variant %s: percent must be between 1 and 100", ErrInvalidMessageVariants, name)
+	}
+
+	return &MessageVariant{
+		name:    name,
+		message: message,
+		media:   media,
+		percent: percent,
+	}, nil
+}
+
+// Name возвращает имя варианта
+func (v *MessageVariant) Name() string { return v.name }
+
+// Message возвращает текст варианта (пустая строка = текст кампании)
+func (v *MessageVariant) Message() string { return v.message }
+
+// Media возвращает медиа варианта (nil = медиа кампании)
+func (v *MessageVariant) Media() *Media { return v.media }
+
+// Percent возвращает долю получателей варианта в процентах
+func (v *MessageVariant) Percent() int { return v.percent }
+
+// validateVariants проверяет набор вариантов: количество, уникальность имен и сумму долей
+func validateVariants(variants []*MessageVariant) error {
+	if len(variants) < MinMessageVariants || len(variants) > MaxMessageVariants {
+		return fmt.Errorf("%w: expected from %d to %d variants, got %d",
+			ErrInvalidMessageVariants, MinMessageVariants, MaxMessageVariants, len(variants))
+	}
+
+	total := 0
+	names := make(map[string]struct{}, len(variants))
+	for _, variant := range variants {
+		if _, exists := names[variant.name]; exists {
+			return fmt.Errorf("%w: duplicate variant name %s", ErrInvalidMessageVariants, variant.name)
+		}
+		names[variant.name] = struct{}{}
+		total += variant.percent
+	}
+
+	if total != 100 {
+		return fmt.Errorf("%w: variant percents must sum to 100, got %d", ErrInvalidMessageVariants, total)
+	}
+
+	return nil
+}
+
+// VariantAssigner распределяет получателей между вариантами пропорционально долям.
+// Используется плавный взвешенный round-robin: варианты чередуются равномерно,
+// а итоговое количество получателей каждого варианта отличается от доли не более чем на одного.
+// Состояние сохраняется между вызовами Assign, поэтому получателей можно распределять пакетами
+type VariantAssigner struct {
+	variants []*MessageVariant
+	current  []int
+}
+
+// NewVariantAssigner создает распределение по вариантам (без вариантов Assign ничего не делает)
+func NewVariantAssigner(variants []*MessageVariant) *VariantAssigner {
+	return &VariantAssigner{
+		variants: variants,
+		current:  make([]int, len(variants)),
+	}
+}
+
+// Assign назначает варианты очередным статусам номеров
+func (a *VariantAssigner) Assign(statuses []*CampaignPhoneStatus) {
+	if len(a.variants) == 0 {
+		return
+	}
+
+	for _, status := range statuses {
+		best := 0
+		for i, variant := range a.variants {
+			a.current[i] += variant.percent
+			if a.current[i] > a.current[best] {
+				best = i
+			}
+		}
+		a.current[best] -= 100
+		status.SetVariant(a.variants[best].name)
+	}
+}
+
+// assignVariants распределяет варианты по всем статусам номеров за один вызов
+func assignVariants(variants []*MessageVariant, statuses []*CampaignPhoneStatus) {
+	NewVariantAssigner(variants).Assign(statuses)
+}
@@ -0,0 +1,127 @@
+package campaign
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustMessageVariants(t *testing.T, percents ...int) []*MessageVariant {
+	t.Helper()
+	variants := make([]*MessageVariant, 0, len(percents))
+	for i, percent := range percents {
+		variant, err := NewMessageVariant(fmt.Sprintf("v%d", i+1), "", nil, percent)
+		require.NoError(t, err)
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// TestAssignVariants проверяет, что количество получателей каждого варианта
+// отличается от его точной доли не более чем на одного
+func TestAssignVariants(t *testing.T) {
+	splits := [][]int{
+		{50, 50},
+		{34, 33, 33},
+		{90, 10},
+	}
+
+	for _, percents := range splits {
+		for _, total := range []int{1, 2, 3, 7, 10, 99, 100, 101, 333, 1000, 1001} {
+			t.Run(fmt.Sprintf("%v_%d", percents, total), func(t *testing.T) {
+				variants := mustMessageVariants(t, percents...)
+				statuses := make([]*CampaignPhoneStatus, total)
+				for i := range statuses {
+					statuses[i] = NewCampaignStatus("campaign", fmt.Sprintf("7916%07d", i))
+				}
+
+				assignVariants(variants, statuses)
+
+				counts := make(map[string]int, len(variants))
+				for _, status := range statuses {
+					counts[status.Variant()]++
+				}
+
+				assigned := 0
+				for _, variant := range variants {
+					exact := float64(total) * float64(variant.Percent()) / 100
+					require.LessOrEqual(t, math.Abs(float64(counts[variant.Name()])-exact), 1.0,
+						"variant %s: got %d recipients, exact share %.2f", variant.Name(), counts[variant.Name()], exact)
+					assigned += counts[variant.Name()]
+				}
+				require.Equal(t, total, assigned)
+			})
+		}
+	}
+}
+
+// TestVariantAssigner_Batches проверяет, что распределение пакетами совпадает с распределением за один вызов
+func TestVariantAssigner_Batches(t *testing.T) {
+	variants := mustMessageVariants(t, 34, 33, 33)
+
+	newStatuses := func() []*CampaignPhoneStatus {
+		statuses := make([]*CampaignPhoneStatus, 1001)
+		for i := range statuses {
+			statuses[i] = NewCampaignStatus("campaign", fmt.Sprintf("7916%07d", i))
+		}
+		return statuses
+	}
+
+	whole := newStatuses()
+	assignVariants(variants, whole)
+
+	batched := newStatuses()
+	assigner := NewVariantAssigner(variants)
+	for start := 0; start < len(batched); start += 7 {
+		assigner.Assign(batched[start:min(start+7, len(batched))])
+	}
+
+	for i := range whole {
+		require.Equal(t, whole[i].Variant(), batched[i].Variant(), "status %d", i)
+	}
+}
+
+func TestValidateVariants(t *testing.T) {
+	duplicate := mustMessageVariants(t, 50, 50)
+	duplicate[1].name = duplicate[0].name
+
+	testCases := []struct {
+		name     string
+		variants []*MessageVariant
+	}{
+		{"too_few_variants", mustMessageVariants(t, 100)},
+		{"too_many_variants", mustMessageVariants(t, 25, 25, 25, 25)},
+		{"duplicate_names", duplicate},
+		{"percents_below_100", mustMessageVariants(t, 50, 40)},
+		{"percents_above_100", mustMessageVariants(t, 60, 50)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateVariants(tc.variants)
+			require.True(t, errors.Is(err, ErrInvalidMessageVariants), "unexpected error: %v", err)
+		})
+	}
+
+	require.NoError(t, validateVariants(mustMessageVariants(t, 34, 33, 33)))
+}
+
+func TestNewMessageVariant_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		variant string
+		percent int
+	}{
+		{"empty_name", "  ", 50},
+		{"zero_percent", "A", 0},
+		{"percent_above_100", "A", 101},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewMessageVariant(tc.variant, "", nil, tc.percent)
+			require.True(t, errors.Is(err, ErrInvalidMessageVariants), "unexpected error: %v", err)
+		})
+	}
+}
@@ -24,6 +24,9 @@ type Dispatcher struct {
 	activeCampaigns *list.List
 	queues          map[string]*list.List
 	resultsChans    map[string]chan<- *dto.MessageSendResult
+	jobContexts     map[string]context.Context
+	paused          map[string]struct{}
+	windows         map[string]*campaign.SendingWindow
 
 	// Управление
 	jobsChan chan dispatcherJobRequest
@@ -40,6 +43,9 @@ func NewDispatcher(gateway interfaces.MessageGateway, limiter GlobalRateLimiter,
 		activeCampaigns: list.New(),
 		queues:          make(map[string]*list.List),
 		resultsChans:    make(map[string]chan<- *dto.MessageSendResult),
+		jobContexts:     make(map[string]context.Context),
+		paused:          make(map[string]struct{}),
+		windows:         make(map[string]*campaign.SendingWindow),
 		jobsChan:        make(chan dispatcherJobRequest),
 		stopChan:        make(chan struct{}),
 	}
@@ -82,6 +88,7 @@ func (d *Dispatcher) Submit(ctx context.Context, newJob *dto.DispatcherJob) (<-c
 	errChan := make(chan error, 1)
 
 	req := dispatcherJobRequest{
+		ctx:         ctx,
 		job:         newJob,
 		resultsChan: resultsCh,
 		errChan:     errChan,
@@ -136,10 +143,19 @@ func (d *Dispatcher) addJob(req dispatcherJobRequest) {
 		d.queues[id] = list.New()
 		d.activeCampaigns.PushBack(id)
 		d.resultsChans[id] = req.resultsChan
+		d.jobContexts[id] = req.ctx
+		if req.job.SendingWindow != nil {
+			d.windows[id] = req.job.SendingWindow
+		}
 
 		// Устанавливаем лимит для кампании
 		d.limiter.SetRateForCampaign(id, req.job.MessagesPerHour)
 		d.logger.Info("Set rate limit for campaign", zap.String("campaignID", id), zap.Int("messagesPerHour", req.job.MessagesPerHour))
+		if req.job.SendingWindow != nil {
+			d.logger.Info("Set sending window for campaign", zap.String("campaignID", id),
+				zap.String("start", req.job.SendingWindow.Start()), zap.String("end", req.job.SendingWindow.End()),
+				zap.String("timezone", req.job.SendingWindow.Timezone()))
+		}
 	}
 	// Добавляем сообщения в очередь
 	for i := range req.job.Messages {
@@ -149,25 +165,114 @@ func (d *Dispatcher) addJob(req dispatcherJobRequest) {
 	d.logger.Info("Job added to queue", zap.String("campaignID", id), zap.Int("messagesInQueue", d.queues[id].Len()), zap.Int("activeCampaigns", d.activeCampaigns.Len()))
 }
 
+// Pause откладывает очередь кампании: сообщения, их порядок и состояние лимитера сохраняются до Resume
+func (d *Dispatcher) Pause(campaignID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.queues[campaignID]; !exists {
+		return ErrCampaignNotDispatched
+	}
+
+	d.paused[campaignID] = struct{}{}
+	d.logger.Info("Campaign paused in dispatcher", zap.String("campaignID", campaignID), zap.Int("messagesInQueue", d.queues[campaignID].Len()))
+	return nil
+}
+
+// Resume возвращает отложенную очередь кампании в round-robin
+func (d *Dispatcher) Resume(campaignID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.queues[campaignID]; !exists {
+		return ErrCampaignNotDispatched
+	}
+
+	delete(d.paused, campaignID)
+	d.logger.Info("Campaign resumed in dispatcher", zap.String("campaignID", campaignID), zap.Int("messagesInQueue", d.queues[campaignID].Len()))
+	return nil
+}
+
+// DropRecipient удаляет из очередей сообщения на номер, например после отписки получателя.
+// Сообщение, которое уже отправляется, не прерывается
+func (d *Dispatcher) DropRecipient(phoneNumber string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dropped := 0
+	for campaignID, queue := range d.queues {
+		for element := queue.Front(); element != nil; {
+			next := element.Next()
+			if element.Value.(*dto.Message).PhoneNumber == phoneNumber {
+				queue.Remove(element)
+				dropped++
+				d.logger.Info("Recipient dropped from campaign queue", zap.String("campaignID", campaignID), zap.String("phoneNumber", phoneNumber))
+			}
+			element = next
+		}
+	}
+	return dropped
+}
+
+// nextCampaign возвращает первую кампанию в round-robin, которая не стоит на паузе
+// и находится внутри своего окна отправки. Отмененные кампании возвращаются всегда,
+// чтобы их очередь была сброшена без ожидания окна. Должен вызываться под d.mu
+func (d *Dispatcher) nextCampaign(now time.Time) *list.Element {
+	for element := d.activeCampaigns.Front(); element != nil; element = element.Next() {
+		campaignID := element.Value.(string)
+
+		if jobCtx, ok := d.jobContexts[campaignID]; ok && jobCtx.Err() != nil {
+			return element
+		}
+		if _, isPaused := d.paused[campaignID]; isPaused {
+			continue
+		}
+		if window, ok := d.windows[campaignID]; ok && !window.Contains(now) {
+			continue
+		}
+		return element
+	}
+	return nil
+}
+
+// removeCampaign удаляет кампанию из диспетчера и закрывает ее канал результатов.
+// Должен вызываться под d.mu
+func (d *Dispatcher) removeCampaign(element *list.Element) {
+	campaignID := element.Value.(string)
+
+	d.activeCampaigns.Remove(element)
+	if resultsChan, ok := d.resultsChans[campaignID]; ok {
+		close(resultsChan)
+		delete(d.resultsChans, campaignID)
+	}
+	delete(d.queues, campaignID)
+	delete(d.jobContexts, campaignID)
+	delete(d.paused, campaignID)
+	delete(d.windows, campaignID)
+}
+
 func (d *Dispatcher) processNextMessage(ctx context.Context) {
 	d.mu.Lock()
-	if d.activeCampaigns.Len() == 0 {
+	element := d.nextCampaign(time.Now())
+	if element == nil {
 		d.mu.Unlock()
 		return
 	}
 
-	element := d.activeCampaigns.Front()
 	campaignID := element.Value.(string)
 	queue := d.queues[campaignID]
 
+	if jobCtx, ok := d.jobContexts[campaignID]; ok && jobCtx.Err() != nil {
+		// Кампания отменена, оставшиеся сообщения не отправляем
+		d.logger.Info("Campaign cancelled, dropping queue", zap.String("campaignID", campaignID), zap.Int("droppedMessages", queue.Len()))
+		d.removeCampaign(element)
+		d.mu.Unlock()
+		return
+	}
+
 	if queue.Len() == 0 {
 		// Очередь пуста, кампания завершена
-		d.activeCampaigns.Remove(element)
-		if resultsChan, ok := d.resultsChans[campaignID]; ok {
-			close(resultsChan)
-			delete(d.resultsChans, campaignID)
-		}
-		delete(d.queues, campaignID)
+		d.removeCampaign(element)
 		d.logger.Info("Campaign completed, queue empty", zap.String("campaignID", campaignID))
 		d.mu.Unlock()
 		return
@@ -213,6 +318,9 @@ func (d *Dispatcher) handleShutdown() {
 	}
 	// Очищаем очереди
 	d.queues = make(map[string]*list.List)
+	d.jobContexts = make(map[string]context.Context)
+	d.paused = make(map[string]struct{})
+	d.windows = make(map[string]*campaign.SendingWindow)
 	d.activeCampaigns = list.New()
 }
 
@@ -221,8 +329,12 @@ func (d *Dispatcher) send(ctx context.Context, msg dto.Message) *dto.MessageSend
 	var err error
 
 	if msg.Media != nil {
-		mediaReader := bytes.NewReader(msg.Media.Data)
-		result, err = d.gateway.SendMediaMessage(ctx, msg.PhoneNumber, campaign.MessageType(msg.Media.MessageType), msg.Text, msg.Media.Filename, mediaReader, msg.Media.MimeType, false)
+		var data []byte
+		data, err = msg.Media.Content(ctx)
+		if err == nil {
+			mediaReader := bytes.NewReader(data)
+			result, err = d.gateway.SendMediaMessage(ctx, msg.PhoneNumber, campaign.MessageType(msg.Media.MessageType), msg.Text, msg.Media.Filename, mediaReader, msg.Media.MimeType, false)
+		}
 	} else {
 		result, err = d.gateway.SendTextMessage(ctx, msg.PhoneNumber, msg.Text, false)
 	}
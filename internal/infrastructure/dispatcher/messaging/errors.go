@@ -3,5 +3,6 @@ package messaging
 import "errors"
 
 var (
-	ErrDispatcherClosed = errors.New("dispatcher is closed")
+	ErrDispatcherClosed      = errors.New("dispatcher is closed")
+	ErrCampaignNotDispatched = errors.New("campaign is not queued in dispatcher")
 )
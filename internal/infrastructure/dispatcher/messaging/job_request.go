@@ -1,8 +1,12 @@
 package messaging
 
-import "whatsapp-service/internal/usecases/dto"
+import (
+	"context"
+	"whatsapp-service/internal/usecases/dto"
+)
 
 type dispatcherJobRequest struct {
+	ctx         context.Context
 	job         *dto.DispatcherJob
 	resultsChan chan<- *dto.MessageSendResult
 	errChan     chan<- error
@@ -0,0 +1,135 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	xlsxSheetName = "Results"
+	xlsxMaxRows   = excelize.TotalRows
+)
+
+// utf8BOM позволяет Excel правильно определить кодировку CSV с кириллицей
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// Ensure implementation
+var _ ports.ResultsExporter = (*ResultsExporter)(nil)
+
+// ResultsExporter реализация выгрузки результатов кампаний в XLSX и CSV
+type ResultsExporter struct{}
+
+// NewResultsExporter создает новый экспортер результатов
+func NewResultsExporter() *ResultsExporter {
+	return &ResultsExporter{}
+}
+
+// NewWriter открывает потоковую запись таблицы в выбранном формате
+func (e *ResultsExporter) NewWriter(w io.Writer, format ports.ExportFormat) (ports.ResultsWriter, error) {
+	switch format {
+	case ports.ExportFormatXLSX:
+		return newXLSXWriter(w)
+	case ports.ExportFormatCSV:
+		return newCSVWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// xlsxWriter пишет строки через StreamWriter excelize: строки сбрасываются во временный файл,
+// а не держатся в памяти, и весь файл пишется в поток при Close
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), xlsxSheetName); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	stream, err := file.NewStreamWriter(xlsxSheetName)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to create stream writer: %w", err)
+	}
+
+	return &xlsxWriter{out: w, file: file, stream: stream}, nil
+}
+
+// WriteRow записывает строку на следующую позицию листа
+func (x *xlsxWriter) WriteRow(values []string) error {
+	if x.row >= xlsxMaxRows {
+		return fmt.Errorf("too many rows for xlsx: maximum %d", xlsxMaxRows)
+	}
+	x.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+	return x.stream.SetRow(cell, row)
+}
+
+// Close завершает лист и пишет готовый файл в поток
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to flush xlsx rows: %w", err)
+	}
+	if err := x.file.Write(x.out); err != nil {
+		return fmt.Errorf("failed to write xlsx file: %w", err)
+	}
+	return nil
+}
+
+// Abort удаляет временные данные листа, не записывая файл
+func (x *xlsxWriter) Abort() {
+	_ = x.file.Close()
+}
+
+// csvWriter пишет строки сразу в поток через буфер
+type csvWriter struct {
+	buffer *bufio.Writer
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	buffer := bufio.NewWriter(w)
+	if _, err := buffer.Write(utf8BOM); err != nil {
+		return nil, err
+	}
+	return &csvWriter{buffer: buffer, writer: csv.NewWriter(buffer)}, nil
+}
+
+// WriteRow записывает строку CSV
+func (c *csvWriter) WriteRow(values []string) error {
+	return c.writer.Write(values)
+}
+
+// Close сбрасывает буферы в поток
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv rows: %w", err)
+	}
+	return c.buffer.Flush()
+}
+
+// Abort отбрасывает строки, еще не сброшенные в поток
+func (c *csvWriter) Abort() {}
@@ -0,0 +1,82 @@
+package exporters
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var testRows = [][]string{
+	{"Телефон", "Статус", "Ошибка"},
+	{"79991234567", "sent", ""},
+	{"79997654321", "failed", "network error, \"quoted\""},
+}
+
+// writeRows записывает тестовые строки в выбранном формате
+func writeRows(t *testing.T, format ports.ExportFormat) *bytes.Buffer {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	writer, err := NewResultsExporter().NewWriter(buf, format)
+	if err != nil {
+		t.Fatalf("Unexpected error creating writer: %v", err)
+	}
+	for _, row := range testRows {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("Unexpected error writing row: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Unexpected error closing writer: %v", err)
+	}
+	return buf
+}
+
+// TestResultsExporter_XLSX проверяет, что выгрузка XLSX читается excelize и содержит все строки
+func TestResultsExporter_XLSX(t *testing.T) {
+	buf := writeRows(t, ports.ExportFormatXLSX)
+
+	f, err := excelize.OpenReader(buf)
+	if err != nil {
+		t.Fatalf("Failed to open exported xlsx: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(xlsxSheetName)
+	if err != nil {
+		t.Fatalf("Failed to read rows: %v", err)
+	}
+	if len(rows) != len(testRows) {
+		t.Fatalf("Expected %d rows, got %d", len(testRows), len(rows))
+	}
+	if rows[2][2] != testRows[2][2] {
+		t.Errorf("Expected error cell %q, got %q", testRows[2][2], rows[2][2])
+	}
+}
+
+// TestResultsExporter_CSV проверяет BOM и экранирование значений в CSV
+func TestResultsExporter_CSV(t *testing.T) {
+	buf := writeRows(t, ports.ExportFormatCSV)
+
+	if !bytes.HasPrefix(buf.Bytes(), utf8BOM) {
+		t.Errorf("Expected CSV to start with UTF-8 BOM")
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(buf.Bytes()[len(utf8BOM):])), "\n")
+	if len(lines) != len(testRows) {
+		t.Fatalf("Expected %d lines, got %d", len(testRows), len(lines))
+	}
+	if lines[2] != `79997654321,failed,"network error, ""quoted"""` {
+		t.Errorf("Unexpected escaped line: %s", lines[2])
+	}
+}
+
+// TestResultsExporter_UnsupportedFormat проверяет ошибку для неизвестного формата
+func TestResultsExporter_UnsupportedFormat(t *testing.T) {
+	if _, err := NewResultsExporter().NewWriter(new(bytes.Buffer), "pdf"); err == nil {
+		t.Errorf("Expected error for unsupported format")
+	}
+}
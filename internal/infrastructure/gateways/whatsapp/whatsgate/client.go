@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"regexp"
 	"strings"
 	"time"
 	"whatsapp-service/internal/entities/campaign"
@@ -16,9 +15,6 @@ import (
 	"whatsapp-service/internal/usecases/dto"
 )
 
-// Регулярка для проверки номера (российский формат 7XXXXXXXXXX)
-var phoneRegex = regexp.MustCompile(`^7\d{10}$`)
-
 // WhatsGateGateway — «голый» HTTP-клиент WhatsGate.
 // Он не умеет сам добывать ключи: конфиг передаётся при создании.
 // Рекомендуется оборачивать его в SettingsAwareGateway для поддержки
@@ -197,7 +193,7 @@ endRetry:
 	return &dto.MessageSendResult{
 		PhoneNumber: lastResult.PhoneNumber,
 		Success:     lastResult.Success,
-		MessageID:   lastResult.Status,
+		MessageID:   lastResult.MessageID,
 		Error:       lastResult.Error,
 		Timestamp:   ts,
 	}, nil
@@ -258,23 +254,10 @@ endRetry:
 	}, nil
 }
 
-// isRetryableError определяет, стоит ли повторять запрос при данной ошибке
+// isRetryableError определяет, стоит ли повторять запрос при данной ошибке.
+// Правила общие с разбором ошибок кампаний, см. campaign.IsRetryableSendError
 func (g *WhatsGateGateway) isRetryableError(errorMsg string) bool {
-	retryableErrors := []string{
-		"network error",
-		"timeout",
-		"connection refused",
-		"server error",
-		"temporary failure",
-	}
-
-	for _, retryable := range retryableErrors {
-		if strings.Contains(strings.ToLower(errorMsg), retryable) {
-			return true
-		}
-	}
-
-	return false
+	return campaign.IsRetryableSendError(errorMsg)
 }
 
 // validatePhoneNumber валидирует номер телефона
@@ -283,8 +266,8 @@ func (g *WhatsGateGateway) validatePhoneNumber(phoneNumber string) error {
 		return fmt.Errorf("phone number is required")
 	}
 
-	if !phoneRegex.MatchString(phoneNumber) {
-		return fmt.Errorf("phone number must be in format 7XXXXXXXXXX (11 digits starting with 7)")
+	if !campaign.IsValidPhoneNumber(phoneNumber) {
+		return fmt.Errorf("phone number must be in international format without '+' (country code and number)")
 	}
 
 	return nil
@@ -389,6 +372,7 @@ func (g *WhatsGateGateway) sendMessage(ctx context.Context, request types.SendMe
 		PhoneNumber: phoneNumber,
 		Success:     true,
 		Status:      "sent",
+		MessageID:   response.ID,
 		Timestamp:   time.Now().Format(time.RFC3339),
 	}, nil
 }
@@ -36,12 +36,13 @@ func newGateway(baseURL string) *WhatsGateGateway {
 
 func TestSendTextMessage(t *testing.T) {
 	testCases := []struct {
-		name          string
-		phoneNumber   string
-		message       string
-		mockHandler   func(t *testing.T, w http.ResponseWriter, r *http.Request)
-		expectSuccess bool
-		expectErrorIn string
+		name            string
+		phoneNumber     string
+		message         string
+		mockHandler     func(t *testing.T, w http.ResponseWriter, r *http.Request)
+		expectSuccess   bool
+		expectErrorIn   string
+		expectMessageID string
 	}{
 		{
 			name:        "success_text_message",
@@ -53,7 +54,8 @@ func TestSendTextMessage(t *testing.T) {
 				err := json.NewEncoder(w).Encode(map[string]string{"status": "sent", "id": "msg123"})
 				require.NoError(t, err)
 			},
-			expectSuccess: true,
+			expectSuccess:   true,
+			expectMessageID: "msg123",
 		},
 		{
 			name:          "invalid_phone_number",
@@ -63,6 +65,26 @@ func TestSendTextMessage(t *testing.T) {
 			expectSuccess: false,
 			expectErrorIn: "invalid phone number",
 		},
+		{
+			name:        "international_phone_number",
+			phoneNumber: "375291234567",
+			message:     "hello",
+			mockHandler: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				err := json.NewEncoder(w).Encode(map[string]string{"status": "sent", "id": "msg456"})
+				require.NoError(t, err)
+			},
+			expectSuccess:   true,
+			expectMessageID: "msg456",
+		},
+		{
+			name:          "phone_number_not_normalized",
+			phoneNumber:   "+79161234567",
+			message:       "hi",
+			mockHandler:   nil, // Шлюз принимает только нормализованные номера
+			expectSuccess: false,
+			expectErrorIn: "invalid phone number",
+		},
 		{
 			name:        "unauthorized_error_from_server",
 			phoneNumber: "79161234567",
@@ -101,6 +123,7 @@ func TestSendTextMessage(t *testing.T) {
 			if tc.expectErrorIn != "" {
 				require.Contains(t, res.Error, tc.expectErrorIn)
 			}
+			require.Equal(t, tc.expectMessageID, res.MessageID)
 		})
 	}
 }
@@ -7,6 +7,7 @@ type MessageResult struct {
 	PhoneNumber string // Номер телефона получателя
 	Success     bool   // Успешно ли отправлено сообщение
 	Status      string // Статус от шлюза (sent/pending/failed)
+	MessageID   string // ID сообщения, присвоенный WhatsGate (если вернулся в ответе)
 	Error       string // Сообщение об ошибке (если неуспешно)
 	Timestamp   string // Время отправки
 }
@@ -0,0 +1,245 @@
+package csv
+
+import (
+	"bufio"
+	"bytes"
+	stdcsv "encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/infrastructure/parsers/table"
+	"whatsapp-service/internal/usecases/dto"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+// utf8BOM метка порядка байтов, которую добавляет Excel при сохранении CSV в UTF-8
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// sniffSize размер начала файла, по которому определяются кодировка и разделитель
+const sniffSize = 64 << 10
+
+// delimiters поддерживаемые разделители в порядке приоритета при равном количестве
+var delimiters = []rune{';', '\t', ',', '|'}
+
+// CSVParser реализация парсера для CSV и TSV файлов.
+// Кодировка (UTF-8 или Windows-1251) и разделитель определяются автоматически
+type CSVParser struct {
+	phones *campaign.PhoneNormalizer
+}
+
+// NewCSVParser создает новый CSV парсер, номера нормализуются через phones
+func NewCSVParser(phones *campaign.PhoneNormalizer) *CSVParser {
+	return &CSVParser{phones: phones}
+}
+
+// ParsePhoneNumbers парсит номера телефонов из CSV файла (основной метод интерфейса)
+func (p *CSVParser) ParsePhoneNumbers(fileData io.Reader) ([]campaign.PhoneNumber, error) {
+	result, err := p.ParsePhoneNumbersDetailed(fileData, "")
+	if err != nil {
+		return nil, err
+	}
+	return result.ValidPhones, nil
+}
+
+// ParsePhoneNumbersDetailed парсит номера с подробной статистикой
+func (p *CSVParser) ParsePhoneNumbersDetailed(fileData io.Reader, columnName string) (*dto.ParseResult, error) {
+	return p.ParsePhoneNumbersWithOptions(fileData, dto.ParseOptions{Column: columnName})
+}
+
+// ParsePhoneNumbersWithOptions построчно парсит номера с выбором колонки. В CSV нет листов,
+// поэтому выбор листа игнорируется с предупреждением
+func (p *CSVParser) ParsePhoneNumbersWithOptions(fileData io.Reader, options dto.ParseOptions) (*dto.ParseResult, error) {
+	reader, err := p.newReader(fileData)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := readRow(reader)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("csv file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	builder := table.NewBuilder(p.phones, options.MaxRows)
+	if err := builder.StartSheet("", header, options.Column); err != nil {
+		return nil, err
+	}
+	if options.Sheet != "" || options.AllSheets {
+		builder.AddWarning("Sheet selection is ignored for CSV files")
+	}
+
+	for {
+		row, err := readRow(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := builder.AddRow(row); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Result()
+}
+
+// Analyze возвращает колонки, предлагаемую колонку с номерами и первые строки файла
+func (p *CSVParser) Analyze(fileData io.Reader, sheet string) (*dto.FileAnalysis, error) {
+	reader, err := p.newReader(fileData)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzer := table.NewAnalyzer(p.phones)
+	for {
+		row, err := readRow(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		analyzer.AddRow(row)
+	}
+
+	analysis := analyzer.Result()
+	if sheet != "" {
+		analysis.Warnings = append(analysis.Warnings, "Sheet selection is ignored for CSV files")
+	}
+	return analysis, nil
+}
+
+// newReader создает построчный reader с определением кодировки и разделителя.
+// Кодировка и разделитель определяются по первым sniffSize байтам, остальной файл
+// декодируется по мере чтения строк и целиком в память не загружается
+func (p *CSVParser) newReader(fileData io.Reader) (*stdcsv.Reader, error) {
+	buffered := bufio.NewReaderSize(fileData, sniffSize)
+	prefix, err := buffered.Peek(sniffSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read CSV file: %w", err)
+	}
+	complete := errors.Is(err, io.EOF) // Файл целиком поместился в prefix
+
+	if bytes.HasPrefix(prefix, utf8BOM) {
+		prefix = prefix[len(utf8BOM):]
+		if _, err := buffered.Discard(len(utf8BOM)); err != nil {
+			return nil, fmt.Errorf("failed to read CSV file: %w", err)
+		}
+	}
+	if complete && len(bytes.TrimSpace(prefix)) == 0 {
+		return nil, errors.New("csv file is empty")
+	}
+
+	var content io.Reader = buffered
+	sample := string(prefix)
+	if !isUTF8(prefix, complete) {
+		decoder := charmap.Windows1251.NewDecoder()
+		decoded, err := decoder.Bytes(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode CSV file: %w", err)
+		}
+		sample = string(decoded)
+		content = transform.NewReader(buffered, charmap.Windows1251.NewDecoder())
+	}
+
+	reader := stdcsv.NewReader(content)
+	reader.Comma = detectDelimiter(sample)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return reader, nil
+}
+
+// readRow читает очередную строку файла или io.EOF, если строк больше нет
+func readRow(reader *stdcsv.Reader) ([]string, error) {
+	row, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rows from CSV file: %w", err)
+	}
+	return row, nil
+}
+
+// SupportedExtensions возвращает поддерживаемые расширения файлов
+func (p *CSVParser) SupportedExtensions() map[string]struct{} {
+	return map[string]struct{}{
+		".csv": {},
+		".tsv": {},
+	}
+}
+
+// SupportedContentTypes возвращает поддерживаемые MIME-типы файлов
+func (p *CSVParser) SupportedContentTypes() map[string]struct{} {
+	return map[string]struct{}{
+		"text/csv":                  {},
+		"application/csv":           {},
+		"text/tab-separated-values": {},
+	}
+}
+
+// IsSupported проверяет поддерживается ли файл по расширению
+func (p *CSVParser) IsSupported(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	_, supported := p.SupportedExtensions()[ext]
+	return supported
+}
+
+// isUTF8 проверяет, что начало файла — валидный UTF-8. Файлы в другой кодировке
+// считаются сохраненными в Windows-1251 (кодировка CSV из Excel и CRM для русской локали).
+// Если prefix — не весь файл, последний символ может быть обрезан и не учитывается
+func isUTF8(prefix []byte, complete bool) bool {
+	if !complete {
+		start := len(prefix) - 1
+		for start > 0 && len(prefix)-start < utf8.UTFMax && !utf8.RuneStart(prefix[start]) {
+			start--
+		}
+		if start >= 0 && !utf8.FullRune(prefix[start:]) {
+			prefix = prefix[:start]
+		}
+	}
+	return utf8.Valid(prefix)
+}
+
+// detectDelimiter определяет разделитель по первой непустой строке файла:
+// выбирается символ, который чаще всего встречается вне кавычек
+func detectDelimiter(content string) rune {
+	var header string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" {
+			header = line
+			break
+		}
+	}
+
+	counts := make(map[rune]int, len(delimiters))
+	inQuotes := false
+	for _, r := range header {
+		if r == '"' {
+			inQuotes = !inQuotes
+			continue
+		}
+		if !inQuotes {
+			counts[r]++
+		}
+	}
+
+	best := ','
+	bestCount := 0
+	for _, delimiter := range delimiters {
+		if counts[delimiter] > bestCount {
+			best = delimiter
+			bestCount = counts[delimiter]
+		}
+	}
+	return best
+}
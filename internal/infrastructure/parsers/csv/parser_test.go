@@ -0,0 +1,202 @@
+package csv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/infrastructure/parsers/table"
+	"whatsapp-service/internal/usecases/dto"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// newTestParser создает парсер, который считает номера без кода страны российскими
+func newTestParser(tb testing.TB) *CSVParser {
+	tb.Helper()
+	phones, err := campaign.NewPhoneNormalizer("RU")
+	if err != nil {
+		tb.Fatalf("Failed to create phone normalizer: %v", err)
+	}
+	return NewCSVParser(phones)
+}
+
+func TestCSVParser_IsSupported(t *testing.T) {
+	parser := newTestParser(t)
+
+	tests := []struct {
+		filename string
+		expected bool
+	}{
+		{"phones.csv", true},
+		{"phones.CSV", true},
+		{"phones.tsv", true},
+		{"phones.xlsx", false},
+		{"phones.txt", false},
+		{"phones", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filename, func(t *testing.T) {
+			if result := parser.IsSupported(test.filename); result != test.expected {
+				t.Errorf("IsSupported(%s) = %v, expected %v", test.filename, result, test.expected)
+			}
+		})
+	}
+}
+
+// TestCSVParser_ParsePhoneNumbersDetailed_Formats тестирует определение кодировки и разделителя
+func TestCSVParser_ParsePhoneNumbersDetailed_Formats(t *testing.T) {
+	windows1251, err := charmap.Windows1251.NewEncoder().String("Имя;Телефон;Город\nИван;89161234567;Москва\nПетр;+7 916 234-56-78;Казань\n")
+	if err != nil {
+		t.Fatalf("Failed to encode test data: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{
+			name:    "utf8_semicolon",
+			content: []byte("Имя;Телефон;Город\nИван;89161234567;Москва\nПетр;+7 916 234-56-78;Казань\n"),
+		},
+		{
+			name:    "utf8_bom_comma_quoted",
+			content: []byte("\xEF\xBB\xBFИмя,Телефон,Город\r\n\"Иван, мл.\",89161234567,Москва\r\nПетр,\"+7 916 234-56-78\",Казань\r\n"),
+		},
+		{
+			name:    "tsv",
+			content: []byte("Имя\tТелефон\tГород\nИван\t89161234567\tМосква\nПетр\t+7 916 234-56-78\tКазань\n"),
+		},
+		{
+			name:    "windows1251_semicolon",
+			content: []byte(windows1251),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := newTestParser(t).ParsePhoneNumbersDetailed(bytes.NewReader(test.content), "")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Statistics.ValidCount != 2 {
+				t.Fatalf("Expected 2 valid phones, got %d", result.Statistics.ValidCount)
+			}
+			if result.ValidPhones[0].Value() != "79161234567" || result.ValidPhones[1].Value() != "79162345678" {
+				t.Errorf("Unexpected phones: %s, %s", result.ValidPhones[0].Value(), result.ValidPhones[1].Value())
+			}
+			if strings.Join(result.Columns, ",") != "имя,город" {
+				t.Errorf("Expected columns [имя город], got %v", result.Columns)
+			}
+			if city := result.Variables["79161234567"]["город"]; city != "Москва" {
+				t.Errorf("Expected city Москва, got %q", city)
+			}
+		})
+	}
+}
+
+// TestCSVParser_ParsePhoneNumbersDetailed_Statistics тестирует статистику, совпадающую с Excel парсером
+func TestCSVParser_ParsePhoneNumbersDetailed_Statistics(t *testing.T) {
+	content := "Phone;Name\n79161234567;Иван\ninvalid;Петр\n;Мария\n79161234567;Сергей\n"
+
+	result, err := newTestParser(t).ParsePhoneNumbersDetailed(strings.NewReader(content), "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Statistics.TotalRows != 5 || result.Statistics.DataRows != 4 {
+		t.Errorf("Expected 5 total and 4 data rows, got %d and %d", result.Statistics.TotalRows, result.Statistics.DataRows)
+	}
+	if result.Statistics.ValidCount != 1 || result.Statistics.InvalidCount != 1 ||
+		result.Statistics.EmptyRows != 1 || result.Statistics.DuplicateCount != 1 {
+		t.Errorf("Unexpected statistics: %+v", result.Statistics)
+	}
+	if len(result.DuplicatePhones) != 1 || result.DuplicatePhones[0].Row != 5 || result.DuplicatePhones[0].FirstSeenAt != 2 {
+		t.Errorf("Unexpected duplicates: %+v", result.DuplicatePhones)
+	}
+}
+
+func TestCSVParser_ParsePhoneNumbers_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"empty_file", "", "empty"},
+		{"no_phone_column", "Name;Email\nИван;ivan@example.com\n", "phone column"},
+		{"only_invalid_phones", "Телефон\ninvalid1\ninvalid2\n", "no valid phone numbers"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newTestParser(t).ParsePhoneNumbers(strings.NewReader(test.content))
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("Expected error containing %q, got: %v", test.expected, err)
+			}
+		})
+	}
+}
+
+// TestCSVParser_ParsePhoneNumbersWithOptions_MaxRows тестирует лимит строк с данными
+func TestCSVParser_ParsePhoneNumbersWithOptions_MaxRows(t *testing.T) {
+	content := "Телефон\n79161234567\n79162345678\n79163456789\n"
+
+	result, err := newTestParser(t).ParsePhoneNumbersWithOptions(strings.NewReader(content), dto.ParseOptions{MaxRows: 3})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Statistics.ValidCount != 3 {
+		t.Errorf("Expected 3 valid phones, got %d", result.Statistics.ValidCount)
+	}
+
+	_, err = newTestParser(t).ParsePhoneNumbersWithOptions(strings.NewReader(content), dto.ParseOptions{MaxRows: 2})
+	if !errors.Is(err, campaign.ErrPhoneFileTooManyRows) {
+		t.Errorf("Expected ErrPhoneFileTooManyRows, got: %v", err)
+	}
+}
+
+// TestCSVParser_ParsePhoneNumbersWithOptions_LargeFile тестирует потоковое чтение файла больше
+// анализируемого начала в обеих кодировках и ограничение подробных записей о невалидных номерах
+func TestCSVParser_ParsePhoneNumbersWithOptions_LargeFile(t *testing.T) {
+	var content strings.Builder
+	content.WriteString("Имя;Телефон\n")
+	rows := 0
+	for content.Len() < 3*sniffSize {
+		fmt.Fprintf(&content, "Ёжик %d;7916%07d\n", rows, rows)
+		content.WriteString("Неверный;invalid\n")
+		rows++
+	}
+
+	windows1251, err := charmap.Windows1251.NewEncoder().String(content.String())
+	if err != nil {
+		t.Fatalf("Failed to encode test data: %v", err)
+	}
+
+	for name, data := range map[string]string{"utf8": content.String(), "windows1251": windows1251} {
+		t.Run(name, func(t *testing.T) {
+			result, err := newTestParser(t).ParsePhoneNumbersWithOptions(strings.NewReader(data), dto.ParseOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result.Statistics.ValidCount != rows || result.Statistics.InvalidCount != rows {
+				t.Errorf("Expected %d valid and %d invalid phones, got %+v", rows, rows, result.Statistics)
+			}
+			if len(result.InvalidPhones) != table.MaxPhoneDetails {
+				t.Errorf("Expected %d invalid phone details, got %d", table.MaxPhoneDetails, len(result.InvalidPhones))
+			}
+
+			last := fmt.Sprintf("7916%07d", rows-1)
+			if name := result.Variables[last]["имя"]; name != fmt.Sprintf("Ёжик %d", rows-1) {
+				t.Errorf("Unexpected name of the last row: %q", name)
+			}
+		})
+	}
+}
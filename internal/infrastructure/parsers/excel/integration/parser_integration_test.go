@@ -3,6 +3,7 @@ package integration_test
 import (
 	"os"
 	"testing"
+	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/infrastructure/parsers/excel"
 )
 
@@ -18,7 +19,11 @@ func TestExcelParser_EndToEnd(t *testing.T) {
 	}
 	defer file.Close()
 
-	p := excel.NewExcelParser()
+	phones, err := campaign.NewPhoneNormalizer("RU")
+	if err != nil {
+		t.Fatalf("phone normalizer: %v", err)
+	}
+	p := excel.NewExcelParser(phones)
 	res, err := p.ParsePhoneNumbersDetailed(file, "")
 	if err != nil {
 		t.Fatalf("parse error: %v", err)
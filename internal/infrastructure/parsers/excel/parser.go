@@ -7,46 +7,20 @@ import (
 	"path/filepath"
 	"strings"
 	"whatsapp-service/internal/entities/campaign"
-
+	"whatsapp-service/internal/infrastructure/parsers/table"
 	"whatsapp-service/internal/usecases/dto"
 
 	"github.com/xuri/excelize/v2"
 )
 
 // ExcelParser реализация парсера для Excel файлов
-type ExcelParser struct{}
-
-// NewExcelParser создает новый Excel парсер
-func NewExcelParser() *ExcelParser {
-	return &ExcelParser{}
+type ExcelParser struct {
+	phones *campaign.PhoneNormalizer
 }
 
-// findPhoneColumn ищет колонку с номерами телефонов в заголовке
-func (p *ExcelParser) findPhoneColumn(headerRow []string, preferredColumnName string) (int, string) {
-	if preferredColumnName != "" {
-		for i, header := range headerRow {
-			if strings.EqualFold(strings.TrimSpace(header), preferredColumnName) {
-				return i, header
-			}
-		}
-	}
-
-	targetColumns := []string{
-		"телефон", "phone", "номер", "number",
-		"мобильный", "mobile", "тел", "tel",
-		"phone_number", "phoneNumber", "номер_телефона",
-	}
-
-	for i, header := range headerRow {
-		headerLower := strings.ToLower(strings.TrimSpace(header))
-		for _, target := range targetColumns {
-			if strings.Contains(headerLower, target) {
-				return i, header
-			}
-		}
-	}
-
-	return -1, ""
+// NewExcelParser создает новый Excel парсер, номера нормализуются через phones
+func NewExcelParser(phones *campaign.PhoneNormalizer) *ExcelParser {
+	return &ExcelParser{phones: phones}
 }
 
 // ParsePhoneNumbers парсит номера телефонов из Excel файла (основной метод интерфейса)
@@ -58,120 +32,140 @@ func (p *ExcelParser) ParsePhoneNumbers(fileData io.Reader) ([]campaign.PhoneNum
 	return result.ValidPhones, nil
 }
 
-// ParsePhoneNumbersDetailed парсит номера с подробной статистикой
+// ParsePhoneNumbersDetailed парсит номера первого листа с подробной статистикой
 func (p *ExcelParser) ParsePhoneNumbersDetailed(fileData io.Reader, columnName string) (*dto.ParseResult, error) {
+	return p.ParsePhoneNumbersWithOptions(fileData, dto.ParseOptions{Column: columnName})
+}
+
+// ParsePhoneNumbersWithOptions парсит номера выбранного листа или всех листов.
+// В режиме всех листов пустые листы и листы без колонки с номерами пропускаются с предупреждением
+func (p *ExcelParser) ParsePhoneNumbersWithOptions(fileData io.Reader, options dto.ParseOptions) (*dto.ParseResult, error) {
 	file, err := excelize.OpenReader(fileData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open Excel file: %w", err)
 	}
 	defer file.Close()
 
-	sheetName := file.GetSheetName(0)
-	if sheetName == "" {
-		return nil, errors.New("no sheets found in Excel file")
+	sheets, err := p.selectSheets(file, options)
+	if err != nil {
+		return nil, err
 	}
 
-	rows, err := file.GetRows(sheetName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read rows from sheet '%s': %w", sheetName, err)
+	builder := table.NewBuilder(p.phones, options.MaxRows)
+	for _, sheetName := range sheets {
+		if err := p.parseSheet(file, sheetName, builder, options); err != nil {
+			return nil, err
+		}
 	}
 
-	if len(rows) == 0 {
-		return nil, errors.New("excel file is empty")
+	if builder.Sheets() == 0 {
+		return nil, errors.New("no sheet with a phone column found in Excel file")
 	}
 
-	result := &dto.ParseResult{
-		ValidPhones:     make([]campaign.PhoneNumber, 0),
-		InvalidPhones:   make([]dto.InvalidPhone, 0),
-		DuplicatePhones: make([]dto.DuplicatePhone, 0),
-		Warnings:        make([]string, 0),
-		Statistics: dto.ParseStatistics{
-			TotalRows: len(rows),
-			DataRows:  len(rows) - 1,
-		},
+	return builder.Result()
+}
+
+// parseSheet построчно читает лист в builder. В режиме всех листов пустой лист
+// или лист без колонки с номерами пропускается с предупреждением
+func (p *ExcelParser) parseSheet(file *excelize.File, sheetName string, builder *table.Builder, options dto.ParseOptions) error {
+	rows, err := newRowIterator(file, sheetName)
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
-	phoneColumn, foundColumnName := p.findPhoneColumn(rows[0], columnName)
-	if phoneColumn == -1 {
-		if columnName != "" {
-			return nil, fmt.Errorf("column '%s' not found in file header", columnName)
+	header, err := rows.Next()
+	if errors.Is(err, io.EOF) {
+		if !options.AllSheets {
+			return errors.New("excel file is empty")
 		}
-		return nil, errors.New("no phone column found in file header. Expected columns: 'Телефон', 'Phone', 'Номер', etc")
+		builder.AddWarning(fmt.Sprintf("Skipped empty sheet '%s'", sheetName))
+		return nil
 	}
-
-	if columnName != "" && !strings.EqualFold(foundColumnName, columnName) {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Requested column '%s' not found, using '%s' instead", columnName, foundColumnName))
+	if err != nil {
+		return err
 	}
 
-	seenPhones := make(map[string]int)
-
-	for rowIdx, row := range rows[1:] {
-		actualRowNum := rowIdx + 2
-		result.Statistics.ProcessedRows++
-
-		if phoneColumn >= len(row) {
-			result.Statistics.EmptyRows++
-			continue
+	if err := builder.StartSheet(sheetName, header, options.Column); err != nil {
+		if !options.AllSheets {
+			return err
 		}
+		builder.AddWarning(fmt.Sprintf("Skipped sheet '%s': %v", sheetName, err))
+		return nil
+	}
 
-		rawValue := strings.TrimSpace(row[phoneColumn])
-
-		if rawValue == "" {
-			result.Statistics.EmptyRows++
-			continue
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
 		}
-
-		phone, err := campaign.NewPhoneNumber(rawValue)
 		if err != nil {
-			result.InvalidPhones = append(result.InvalidPhones, dto.InvalidPhone{
-				RawValue: rawValue,
-				Row:      actualRowNum,
-				Reason:   err.Error(),
-			})
-			result.Statistics.InvalidCount++
-			continue
+			return err
 		}
-
-		phoneValue := phone.Value()
-		if firstSeenRow, exists := seenPhones[phoneValue]; exists {
-			result.DuplicatePhones = append(result.DuplicatePhones, dto.DuplicatePhone{
-				PhoneNumber: *phone,
-				RawValue:    rawValue,
-				Row:         actualRowNum,
-				FirstSeenAt: firstSeenRow,
-			})
-			result.Statistics.DuplicateCount++
-			continue
+		if err := builder.AddRow(row); err != nil {
+			return err
 		}
+	}
+}
 
-		seenPhones[phoneValue] = actualRowNum
-		result.ValidPhones = append(result.ValidPhones, *phone)
-		result.Statistics.ValidCount++
+// Analyze возвращает листы файла и анализ листа sheet (пусто = первый лист)
+func (p *ExcelParser) Analyze(fileData io.Reader, sheet string) (*dto.FileAnalysis, error) {
+	file, err := excelize.OpenReader(fileData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Excel file: %w", err)
 	}
+	defer file.Close()
 
-	result.Statistics.UniqueCount = len(result.ValidPhones)
+	sheets, err := p.selectSheets(file, dto.ParseOptions{Sheet: sheet})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := newRowIterator(file, sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	if result.Statistics.InvalidCount > 0 {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Found %d invalid phone numbers", result.Statistics.InvalidCount))
+	analyzer := table.NewAnalyzer(p.phones)
+	for {
+		row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		analyzer.AddRow(row)
 	}
 
-	if result.Statistics.DuplicateCount > 0 {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Found %d duplicate phone numbers", result.Statistics.DuplicateCount))
+	analysis := analyzer.Result()
+	analysis.Sheets = file.GetSheetList()
+	analysis.Sheet = sheets[0]
+	return analysis, nil
+}
+
+// selectSheets возвращает листы для чтения: все, выбранный или первый
+func (p *ExcelParser) selectSheets(file *excelize.File, options dto.ParseOptions) ([]string, error) {
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("no sheets found in Excel file")
 	}
 
-	if result.Statistics.EmptyRows > 0 {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Skipped %d empty rows", result.Statistics.EmptyRows))
+	if options.AllSheets {
+		return sheets, nil
 	}
 
-	if len(result.ValidPhones) == 0 {
-		return nil, errors.New("no valid phone numbers found in file")
+	if options.Sheet == "" {
+		return sheets[:1], nil
 	}
 
-	return result, nil
+	for _, sheetName := range sheets {
+		if strings.EqualFold(strings.TrimSpace(sheetName), strings.TrimSpace(options.Sheet)) {
+			return []string{sheetName}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", campaign.ErrPhoneFileSheetNotFound, options.Sheet)
 }
 
 // SupportedExtensions возвращает поддерживаемые расширения файлов
@@ -182,6 +176,14 @@ func (p *ExcelParser) SupportedExtensions() map[string]struct{} {
 	}
 }
 
+// SupportedContentTypes возвращает поддерживаемые MIME-типы файлов
+func (p *ExcelParser) SupportedContentTypes() map[string]struct{} {
+	return map[string]struct{}{
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {},
+		"application/vnd.ms-excel": {},
+	}
+}
+
 // IsSupported проверяет поддерживается ли файл по расширению
 func (p *ExcelParser) IsSupported(filename string) bool {
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -2,13 +2,26 @@ package excel
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/dto"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// newTestParser создает парсер, который считает номера без кода страны российскими
+func newTestParser(tb testing.TB) *ExcelParser {
+	tb.Helper()
+	phones, err := campaign.NewPhoneNormalizer("RU")
+	if err != nil {
+		tb.Fatalf("Failed to create phone normalizer: %v", err)
+	}
+	return NewExcelParser(phones)
+}
+
 // createTestExcelFile создает Excel файл в памяти для тестирования
 func createTestExcelFile(headers []string, data [][]string) (*bytes.Buffer, error) {
 	f := excelize.NewFile()
@@ -36,7 +49,7 @@ func createTestExcelFile(headers []string, data [][]string) (*bytes.Buffer, erro
 
 // TestExcelParser_IsSupported тестирует проверку поддерживаемых форматов
 func TestExcelParser_IsSupported(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	testCases := []struct {
 		name     string
@@ -68,7 +81,7 @@ func TestExcelParser_IsSupported(t *testing.T) {
 
 // TestExcelParser_SupportedExtensions тестирует получение списка поддерживаемых расширений
 func TestExcelParser_SupportedExtensions(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 	extensions := parser.SupportedExtensions()
 
 	expectedExtensions := []string{".xlsx", ".xls"}
@@ -85,7 +98,7 @@ func TestExcelParser_SupportedExtensions(t *testing.T) {
 
 // TestExcelParser_ParsePhoneNumbers_ValidData тестирует парсинг валидных данных
 func TestExcelParser_ParsePhoneNumbers_ValidData(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	headers := []string{"Имя", "Телефон", "Email"}
 	data := [][]string{
@@ -127,7 +140,7 @@ func TestExcelParser_ParsePhoneNumbers_ValidData(t *testing.T) {
 
 // TestExcelParser_ParsePhoneNumbersDetailed_ComplexData тестирует детальный парсинг с различными сценариями
 func TestExcelParser_ParsePhoneNumbersDetailed_ComplexData(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	headers := []string{"Имя", "Телефон", "Email"}
 	data := [][]string{
@@ -194,9 +207,187 @@ func TestExcelParser_ParsePhoneNumbersDetailed_ComplexData(t *testing.T) {
 	}
 }
 
+// TestExcelParser_ParsePhoneNumbersDetailed_Variables тестирует извлечение переменных из дополнительных колонок
+func TestExcelParser_ParsePhoneNumbersDetailed_Variables(t *testing.T) {
+	parser := newTestParser(t)
+
+	headers := []string{"Name", "Телефон", "Order Number"}
+	data := [][]string{
+		{"Иван", "79161234567", "A-100"},
+		{"", "79162345678", "A-101"},
+		{"Сергей", "79161234567", "A-102"}, // Дубликат: переменные берутся из первой строки
+	}
+
+	excelBuf, err := createTestExcelFile(headers, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	result, err := parser.ParsePhoneNumbersDetailed(excelBuf, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expectedColumns := []string{"name", "order_number"}
+	if strings.Join(result.Columns, ",") != strings.Join(expectedColumns, ",") {
+		t.Errorf("Expected columns %v, got %v", expectedColumns, result.Columns)
+	}
+
+	first := result.Variables["79161234567"]
+	if first["name"] != "Иван" || first["order_number"] != "A-100" {
+		t.Errorf("Unexpected variables for first phone: %v", first)
+	}
+
+	second := result.Variables["79162345678"]
+	if _, exists := second["name"]; exists {
+		t.Errorf("Expected empty name to be omitted, got %v", second)
+	}
+	if second["order_number"] != "A-101" {
+		t.Errorf("Expected order_number A-101, got %q", second["order_number"])
+	}
+}
+
+// TestExcelParser_ParsePhoneNumbersDetailed_PhoneFormats тестирует нормализацию локальных и международных форматов
+func TestExcelParser_ParsePhoneNumbersDetailed_PhoneFormats(t *testing.T) {
+	parser := newTestParser(t)
+
+	headers := []string{"Телефон"}
+	data := [][]string{
+		{"8 (916) 123-45-67"},   // Россия, национальный формат
+		{"+7 (916) 234-56-78"},  // Россия, международный формат
+		{"9163456789"},          // Россия, без кода страны
+		{"+7 701 123 45 67"},    // Казахстан
+		{"+375 29 123-45-67"},   // Беларусь
+		{"998901234567"},        // Узбекистан без "+"
+		{"00998 90 765 43 21"},  // Узбекистан с международным префиксом 00
+		{"+375 29 123-45-6"},    // Невалидный: короткий номер Беларуси
+		{"+1 212 555 0100"},     // Невалидный: неподдерживаемая страна
+		{"8 (916) 123-45-67 1"}, // Невалидный: лишняя цифра
+	}
+
+	excelBuf, err := createTestExcelFile(headers, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	result, err := parser.ParsePhoneNumbersDetailed(excelBuf, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"79161234567", "79162345678", "79163456789", "77011234567",
+		"375291234567", "998901234567", "998907654321",
+	}
+	if len(result.ValidPhones) != len(expected) {
+		t.Fatalf("Expected %d valid phones, got %d", len(expected), len(result.ValidPhones))
+	}
+	for i, phone := range result.ValidPhones {
+		if phone.Value() != expected[i] {
+			t.Errorf("Row %d: expected %s, got %s", i+2, expected[i], phone.Value())
+		}
+	}
+
+	if result.Statistics.InvalidCount != 3 {
+		t.Errorf("Expected 3 invalid phones, got %d", result.Statistics.InvalidCount)
+	}
+}
+
+// TestExcelParser_ParsePhoneNumbersDetailed_CorrectedPhones тестирует отчет об автоматически исправленных номерах
+func TestExcelParser_ParsePhoneNumbersDetailed_CorrectedPhones(t *testing.T) {
+	parser := newTestParser(t)
+
+	headers := []string{"Телефон"}
+	data := [][]string{
+		{"79161234567"},      // Уже нормализован
+		{"89162345678"},      // 8 заменяется на 7
+		{"9163456789"},       // Добавляется код страны
+		{"+7-916-456-78-90"}, // Удаляется форматирование
+		{"8916123"},          // Невалидный
+	}
+
+	excelBuf, err := createTestExcelFile(headers, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	result, err := parser.ParsePhoneNumbersDetailed(excelBuf, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Statistics.CorrectedCount != 3 {
+		t.Fatalf("Expected 3 corrected phones, got %d", result.Statistics.CorrectedCount)
+	}
+
+	expected := []struct {
+		row        int
+		phone      string
+		correction campaign.PhoneCorrection
+	}{
+		{3, "79162345678", campaign.PhoneCorrectionTrunkPrefix},
+		{4, "79163456789", campaign.PhoneCorrectionCountryCode},
+		{5, "79164567890", campaign.PhoneCorrectionFormatting},
+	}
+	for i, want := range expected {
+		got := result.CorrectedPhones[i]
+		if got.Row != want.row || got.PhoneNumber.Value() != want.phone {
+			t.Errorf("Expected corrected %s at row %d, got %s at row %d", want.phone, want.row, got.PhoneNumber.Value(), got.Row)
+		}
+		if len(got.Corrections) != 1 || got.Corrections[0] != want.correction {
+			t.Errorf("Row %d: expected correction %s, got %v", want.row, want.correction, got.Corrections)
+		}
+	}
+
+	if len(result.InvalidPhones) != 1 || result.InvalidPhones[0].Row != 6 {
+		t.Errorf("Expected invalid phone at row 6, got %v", result.InvalidPhones)
+	}
+}
+
+// TestExcelParser_ParsePhoneNumbersDetailed_DefaultCountry тестирует разбор номеров без кода страны по стране парсера
+func TestExcelParser_ParsePhoneNumbersDetailed_DefaultCountry(t *testing.T) {
+	phones, err := campaign.NewPhoneNormalizer("BY")
+	if err != nil {
+		t.Fatalf("Failed to create phone normalizer: %v", err)
+	}
+	parser := NewExcelParser(phones)
+
+	headers := []string{"Телефон"}
+	data := [][]string{
+		{"29 123-45-67"},       // Беларусь, без кода страны
+		{"80 29 765-43-21"},    // Беларусь, национальный формат
+		{"+7 (916) 123-45-67"}, // Россия, международный формат
+		{"9163456789"},         // Невалидный: российский номер без кода страны
+	}
+
+	excelBuf, err := createTestExcelFile(headers, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	result, err := parser.ParsePhoneNumbersDetailed(excelBuf, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"375291234567", "375297654321", "79161234567"}
+	if len(result.ValidPhones) != len(expected) {
+		t.Fatalf("Expected %d valid phones, got %d", len(expected), len(result.ValidPhones))
+	}
+	for i, phone := range result.ValidPhones {
+		if phone.Value() != expected[i] {
+			t.Errorf("Row %d: expected %s, got %s", i+2, expected[i], phone.Value())
+		}
+	}
+
+	if _, err := campaign.NewPhoneNormalizer("US"); err == nil {
+		t.Error("Expected error for unsupported country")
+	}
+}
+
 // TestExcelParser_ParsePhoneNumbers_EmptyFile тестирует обработку пустого файла
 func TestExcelParser_ParsePhoneNumbers_EmptyFile(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	f := excelize.NewFile()
 	defer f.Close()
@@ -219,7 +410,7 @@ func TestExcelParser_ParsePhoneNumbers_EmptyFile(t *testing.T) {
 
 // TestExcelParser_ParsePhoneNumbers_NoPhoneColumn тестирует файл без колонки телефонов
 func TestExcelParser_ParsePhoneNumbers_NoPhoneColumn(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	headers := []string{"Имя", "Возраст", "Email"}
 	data := [][]string{
@@ -244,7 +435,7 @@ func TestExcelParser_ParsePhoneNumbers_NoPhoneColumn(t *testing.T) {
 
 // TestExcelParser_ParsePhoneNumbers_OnlyInvalidPhones тестирует файл только с невалидными номерами
 func TestExcelParser_ParsePhoneNumbers_OnlyInvalidPhones(t *testing.T) {
-	parser := NewExcelParser()
+	parser := newTestParser(t)
 
 	headers := []string{"Имя", "Телефон", "Email"}
 	data := [][]string{
@@ -270,7 +461,7 @@ func TestExcelParser_ParsePhoneNumbers_OnlyInvalidPhones(t *testing.T) {
 
 // BenchmarkExcelParser_ParsePhoneNumbers бенчмарк для производительности
 func BenchmarkExcelParser_ParsePhoneNumbers(b *testing.B) {
-	parser := NewExcelParser()
+	parser := newTestParser(b)
 
 	headers := []string{"Имя", "Телефон", "Email"}
 	data := make([][]string, 1000)
@@ -296,3 +487,231 @@ func BenchmarkExcelParser_ParsePhoneNumbers(b *testing.B) {
 		}
 	}
 }
+
+// createMultiSheetExcelFile создает Excel файл с несколькими листами, первая строка листа — заголовок
+func createMultiSheetExcelFile(sheets []string, data map[string][][]string) (*bytes.Buffer, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName("Sheet1", sheet); err != nil {
+				return nil, err
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return nil, err
+		}
+
+		for rowIdx, row := range data[sheet] {
+			for colIdx, value := range row {
+				cellName, _ := excelize.CoordinatesToCellName(colIdx+1, rowIdx+1)
+				f.SetCellValue(sheet, cellName, value)
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := f.Write(buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// TestExcelParser_ParsePhoneNumbersWithOptions тестирует выбор листа, колонки и чтение всех листов
+func TestExcelParser_ParsePhoneNumbersWithOptions(t *testing.T) {
+	sheets := []string{"Москва", "Казань", "Справочник"}
+	data := map[string][][]string{
+		"Москва":     {{"Имя", "Телефон", "Резервный"}, {"Иван", "79161234567", "79160000001"}},
+		"Казань":     {{"Имя", "Phone"}, {"Петр", "79162345678"}, {"Иван", "79161234567"}},
+		"Справочник": {{"Код", "Описание"}, {"1", "Без номеров"}},
+	}
+
+	tests := []struct {
+		name            string
+		options         dto.ParseOptions
+		expectedPhones  []string
+		expectedWarning string
+	}{
+		{
+			name:           "first_sheet_by_default",
+			options:        dto.ParseOptions{},
+			expectedPhones: []string{"79161234567"},
+		},
+		{
+			name:           "selected_sheet",
+			options:        dto.ParseOptions{Sheet: "казань"},
+			expectedPhones: []string{"79162345678", "79161234567"},
+		},
+		{
+			name:           "selected_column",
+			options:        dto.ParseOptions{Column: "Резервный"},
+			expectedPhones: []string{"79160000001"},
+		},
+		{
+			name:            "all_sheets",
+			options:         dto.ParseOptions{AllSheets: true},
+			expectedPhones:  []string{"79161234567", "79162345678"},
+			expectedWarning: "Skipped sheet 'Справочник'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			excelBuf, err := createMultiSheetExcelFile(sheets, data)
+			if err != nil {
+				t.Fatalf("Failed to create test Excel file: %v", err)
+			}
+
+			result, err := newTestParser(t).ParsePhoneNumbersWithOptions(excelBuf, test.options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			phones := make([]string, len(result.ValidPhones))
+			for i, phone := range result.ValidPhones {
+				phones[i] = phone.Value()
+			}
+			if strings.Join(phones, ",") != strings.Join(test.expectedPhones, ",") {
+				t.Errorf("Expected phones %v, got %v", test.expectedPhones, phones)
+			}
+
+			if test.expectedWarning != "" && !strings.Contains(strings.Join(result.Warnings, "\n"), test.expectedWarning) {
+				t.Errorf("Expected warning %q, got %v", test.expectedWarning, result.Warnings)
+			}
+		})
+	}
+
+	t.Run("duplicate_across_sheets", func(t *testing.T) {
+		excelBuf, err := createMultiSheetExcelFile(sheets, data)
+		if err != nil {
+			t.Fatalf("Failed to create test Excel file: %v", err)
+		}
+
+		result, err := newTestParser(t).ParsePhoneNumbersWithOptions(excelBuf, dto.ParseOptions{AllSheets: true})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result.DuplicatePhones) != 1 {
+			t.Fatalf("Expected 1 duplicate, got %d", len(result.DuplicatePhones))
+		}
+		duplicate := result.DuplicatePhones[0]
+		if duplicate.Sheet != "Казань" || duplicate.Row != 3 || duplicate.FirstSeenSheet != "Москва" || duplicate.FirstSeenAt != 2 {
+			t.Errorf("Unexpected duplicate position: %+v", duplicate)
+		}
+	})
+
+	t.Run("sheet_not_found", func(t *testing.T) {
+		excelBuf, err := createMultiSheetExcelFile(sheets, data)
+		if err != nil {
+			t.Fatalf("Failed to create test Excel file: %v", err)
+		}
+
+		_, err = newTestParser(t).ParsePhoneNumbersWithOptions(excelBuf, dto.ParseOptions{Sheet: "Самара"})
+		if !errors.Is(err, campaign.ErrPhoneFileSheetNotFound) {
+			t.Errorf("Expected ErrPhoneFileSheetNotFound, got: %v", err)
+		}
+	})
+}
+
+// TestExcelParser_Analyze тестирует анализ листа перед парсингом
+func TestExcelParser_Analyze(t *testing.T) {
+	sheets := []string{"Клиенты", "Без заголовка"}
+	data := map[string][][]string{
+		"Клиенты": {
+			{"Имя", "Телефон", "Город"},
+			{"Иван", "89161234567", "Москва"},
+			{"Петр", "invalid", "Казань"},
+			{"Анна", "+7 916 345-67-89", "Самара"},
+		},
+		"Без заголовка": {
+			{"Колонка 1", "Колонка 2"},
+			{"Иван", "79161234567"},
+			{"Петр", "79162345678"},
+		},
+	}
+
+	excelBuf, err := createMultiSheetExcelFile(sheets, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	analysis, err := newTestParser(t).Analyze(excelBuf, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Join(analysis.Sheets, ",") != "Клиенты,Без заголовка" || analysis.Sheet != "Клиенты" {
+		t.Errorf("Unexpected sheets: %v, analyzed %q", analysis.Sheets, analysis.Sheet)
+	}
+	if strings.Join(analysis.Columns, ",") != "Имя,Телефон,Город" {
+		t.Errorf("Unexpected columns: %v", analysis.Columns)
+	}
+	if analysis.SuggestedColumn != "Телефон" || analysis.EstimatedPhones != 2 {
+		t.Errorf("Expected suggested column Телефон with 2 phones, got %q with %d", analysis.SuggestedColumn, analysis.EstimatedPhones)
+	}
+	if analysis.TotalRows != 4 || len(analysis.SampleRows) != 3 {
+		t.Errorf("Expected 4 rows and 3 sample rows, got %d and %d", analysis.TotalRows, len(analysis.SampleRows))
+	}
+
+	excelBuf, err = createMultiSheetExcelFile(sheets, data)
+	if err != nil {
+		t.Fatalf("Failed to create test Excel file: %v", err)
+	}
+
+	analysis, err = newTestParser(t).Analyze(excelBuf, "Без заголовка")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if analysis.SuggestedColumn != "Колонка 2" || analysis.EstimatedPhones != 2 {
+		t.Errorf("Expected column suggested by content, got %q with %d phones", analysis.SuggestedColumn, analysis.EstimatedPhones)
+	}
+}
+
+// TestExcelParser_ParsePhoneNumbersWithOptions_Streaming тестирует построчное чтение и лимит строк
+func TestExcelParser_ParsePhoneNumbersWithOptions_Streaming(t *testing.T) {
+	createFile := func(rows int) *bytes.Buffer {
+		f := excelize.NewFile()
+		defer f.Close()
+
+		f.SetCellValue("Sheet1", "A1", "Телефон")
+		for i := 0; i < rows; i++ {
+			f.SetCellValue("Sheet1", fmt.Sprintf("A%d", i+2), fmt.Sprintf("7916%07d", i))
+		}
+		// Строки без ячеек в конце листа (например, только с высотой) не считаются строками с данными
+		f.SetRowHeight("Sheet1", rows+5, 30)
+
+		buf := new(bytes.Buffer)
+		if err := f.Write(buf); err != nil {
+			t.Fatalf("Failed to create test Excel file: %v", err)
+		}
+		return buf
+	}
+
+	t.Run("trailing_empty_rows", func(t *testing.T) {
+		result, err := newTestParser(t).ParsePhoneNumbersWithOptions(createFile(3), dto.ParseOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Statistics.DataRows != 3 || result.Statistics.EmptyRows != 0 || result.Statistics.ValidCount != 3 {
+			t.Errorf("Unexpected statistics: %+v", result.Statistics)
+		}
+	})
+
+	t.Run("within_limit", func(t *testing.T) {
+		result, err := newTestParser(t).ParsePhoneNumbersWithOptions(createFile(5), dto.ParseOptions{MaxRows: 5})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Statistics.ValidCount != 5 {
+			t.Errorf("Expected 5 valid phones, got %d", result.Statistics.ValidCount)
+		}
+	})
+
+	t.Run("too_many_rows", func(t *testing.T) {
+		_, err := newTestParser(t).ParsePhoneNumbersWithOptions(createFile(6), dto.ParseOptions{MaxRows: 5})
+		if !errors.Is(err, campaign.ErrPhoneFileTooManyRows) {
+			t.Errorf("Expected ErrPhoneFileTooManyRows, got: %v", err)
+		}
+	})
+}
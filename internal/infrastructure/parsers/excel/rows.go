@@ -0,0 +1,67 @@
+package excel
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// rowIterator построчно читает лист без загрузки всех строк в память.
+// Повторяет поведение GetRows: пустые строки между данными возвращаются,
+// пустые строки в конце листа отбрасываются
+type rowIterator struct {
+	sheet   string
+	rows    *excelize.Rows
+	pending []string // Непустая строка, которая будет возвращена после пропущенных пустых
+	empty   int      // Количество пустых строк перед pending
+}
+
+// newRowIterator открывает построчное чтение листа
+func newRowIterator(file *excelize.File, sheet string) (*rowIterator, error) {
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rows from sheet '%s': %w", sheet, err)
+	}
+	return &rowIterator{sheet: sheet, rows: rows}, nil
+}
+
+// Next возвращает очередную строку листа или io.EOF, если строк больше нет
+func (it *rowIterator) Next() ([]string, error) {
+	if it.empty > 0 {
+		it.empty--
+		return nil, nil
+	}
+	if it.pending != nil {
+		row := it.pending
+		it.pending = nil
+		return row, nil
+	}
+
+	for it.rows.Next() {
+		row, err := it.rows.Columns()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rows from sheet '%s': %w", it.sheet, err)
+		}
+		if len(row) == 0 {
+			it.empty++
+			continue
+		}
+		if it.empty > 0 {
+			it.pending = row
+			it.empty--
+			return nil, nil
+		}
+		return row, nil
+	}
+
+	if err := it.rows.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read rows from sheet '%s': %w", it.sheet, err)
+	}
+	return nil, io.EOF
+}
+
+// Close освобождает ресурсы чтения листа
+func (it *rowIterator) Close() error {
+	return it.rows.Close()
+}
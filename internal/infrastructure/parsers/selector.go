@@ -0,0 +1,47 @@
+package parsers
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+)
+
+// FormatParser парсер файла с номерами, знающий поддерживаемые форматы
+type FormatParser interface {
+	ports.FileParser
+	SupportedExtensions() map[string]struct{}
+	SupportedContentTypes() map[string]struct{}
+}
+
+// Selector выбирает парсер по расширению файла или MIME-типу
+type Selector struct {
+	parsers []FormatParser
+}
+
+// NewSelector создает выбор парсера из списка поддерживаемых форматов
+func NewSelector(parsers ...FormatParser) *Selector {
+	return &Selector{parsers: parsers}
+}
+
+// ForFile возвращает парсер по расширению файла, а при неизвестном расширении — по MIME-типу
+func (s *Selector) ForFile(filename, contentType string) (ports.FileParser, error) {
+	if ext := strings.ToLower(filepath.Ext(filename)); ext != "" {
+		for _, parser := range s.parsers {
+			if _, ok := parser.SupportedExtensions()[ext]; ok {
+				return parser, nil
+			}
+		}
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		for _, parser := range s.parsers {
+			if _, ok := parser.SupportedContentTypes()[mediaType]; ok {
+				return parser, nil
+			}
+		}
+	}
+
+	return nil, campaign.ErrUnsupportedPhoneFile
+}
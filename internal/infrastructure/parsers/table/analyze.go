@@ -0,0 +1,113 @@
+package table
+
+import (
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/dto"
+)
+
+// SampleRowsLimit количество строк с данными в анализе файла
+const SampleRowsLimit = 10
+
+// Analyzer анализирует таблицу построчно, первая строка — заголовок: колонки, предлагаемую
+// колонку с номерами и первые строки. Хранит только образец строк и счетчики по колонкам,
+// поэтому подходит для больших файлов
+type Analyzer struct {
+	analysis    *dto.FileAnalysis
+	phones      *campaign.PhoneNormalizer
+	header      []string
+	started     bool
+	phoneColumn int   // Колонка, найденная по заголовку (-1, если не найдена)
+	phoneCounts []int // Количество валидных номеров по колонкам
+}
+
+// NewAnalyzer создает анализатор таблицы. phones определяет, какие значения считаются номерами
+func NewAnalyzer(phones *campaign.PhoneNormalizer) *Analyzer {
+	return &Analyzer{
+		phones: phones,
+		analysis: &dto.FileAnalysis{
+			Columns:    make([]string, 0),
+			SampleRows: make([][]string, 0),
+			Warnings:   make([]string, 0),
+		},
+		phoneColumn: -1,
+	}
+}
+
+// AddRow обрабатывает очередную строку таблицы
+func (a *Analyzer) AddRow(row []string) {
+	a.analysis.TotalRows++
+
+	if !a.started {
+		a.started = true
+		a.header = row
+		for _, column := range row {
+			a.analysis.Columns = append(a.analysis.Columns, strings.TrimSpace(column))
+		}
+		a.phoneColumn, _ = FindPhoneColumn(row, "")
+		a.phoneCounts = make([]int, len(row))
+		return
+	}
+
+	if len(a.analysis.SampleRows) < SampleRowsLimit {
+		a.analysis.SampleRows = append(a.analysis.SampleRows, append([]string(nil), row...))
+	}
+
+	// Если колонка найдена по заголовку, остальные колонки проверять не нужно
+	if a.phoneColumn != -1 {
+		if a.isPhone(row, a.phoneColumn) {
+			a.phoneCounts[a.phoneColumn]++
+		}
+		return
+	}
+	for column := range a.phoneCounts {
+		if a.isPhone(row, column) {
+			a.phoneCounts[column]++
+		}
+	}
+}
+
+// Result возвращает анализ таблицы. Если колонку не удалось определить по заголовку,
+// предлагается колонка с наибольшим количеством валидных номеров
+func (a *Analyzer) Result() *dto.FileAnalysis {
+	analysis := a.analysis
+	if !a.started {
+		analysis.Warnings = append(analysis.Warnings, "File is empty")
+		return analysis
+	}
+
+	phoneColumn := a.phoneColumn
+	if phoneColumn == -1 {
+		phoneColumn = a.columnWithMostPhones()
+		if phoneColumn == -1 {
+			analysis.Warnings = append(analysis.Warnings, "No phone column found")
+			return analysis
+		}
+		analysis.Warnings = append(analysis.Warnings,
+			"Phone column not recognized by header, suggested by content")
+	}
+
+	analysis.SuggestedColumn = strings.TrimSpace(a.header[phoneColumn])
+	analysis.EstimatedPhones = a.phoneCounts[phoneColumn]
+	return analysis
+}
+
+// columnWithMostPhones возвращает колонку с наибольшим количеством валидных номеров (-1, если номеров нет)
+func (a *Analyzer) columnWithMostPhones() int {
+	best, bestCount := -1, 0
+	for column, count := range a.phoneCounts {
+		if count > bestCount {
+			best, bestCount = column, count
+		}
+	}
+	return best
+}
+
+// isPhone проверяет, что в колонке строки валидный номер
+func (a *Analyzer) isPhone(row []string, column int) bool {
+	if column >= len(row) {
+		return false
+	}
+	_, err := a.phones.NewPhoneNumber(row[column])
+	return err == nil
+}
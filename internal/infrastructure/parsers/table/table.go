@@ -0,0 +1,279 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/dto"
+)
+
+// MaxPhoneDetails количество невалидных, исправленных и повторных номеров, для которых
+// в результат попадают подробные записи. Счетчики в статистике учитывают все строки файла
+const MaxPhoneDetails = 1000
+
+// phoneColumnNames варианты заголовка колонки с номерами телефонов
+var phoneColumnNames = []string{
+	"телефон", "phone", "номер", "number",
+	"мобильный", "mobile", "тел", "tel",
+	"phone_number", "phoneNumber", "номер_телефона",
+}
+
+// Builder собирает ParseResult из таблиц: для каждого листа заголовок и строки с данными.
+// Используется парсерами всех табличных форматов, чтобы эвристики и статистика совпадали.
+// Строки передаются по одной, поэтому парсер может читать файл потоково
+type Builder struct {
+	result          *dto.ParseResult
+	phones          *campaign.PhoneNormalizer
+	maxRows         int
+	columns         map[string]struct{}
+	seenPhones      map[string]phonePosition
+	sheets          int
+	sheet           string
+	phoneColumn     int
+	variableColumns []variableColumn
+	rowNum          int
+}
+
+// phonePosition положение номера в файле
+type phonePosition struct {
+	sheet string
+	row   int
+}
+
+// variableColumn дополнительная колонка файла, значения которой доступны в шаблоне сообщения
+type variableColumn struct {
+	index int
+	name  string
+}
+
+// NewBuilder создает сборщик результата парсинга. phones нормализует номера, maxRows ограничивает
+// количество строк с данными во всех таблицах (0 = без ограничения)
+func NewBuilder(phones *campaign.PhoneNormalizer, maxRows int) *Builder {
+	return &Builder{
+		phones:  phones,
+		maxRows: maxRows,
+		result: &dto.ParseResult{
+			ValidPhones:     make([]campaign.PhoneNumber, 0),
+			InvalidPhones:   make([]dto.InvalidPhone, 0),
+			CorrectedPhones: make([]dto.CorrectedPhone, 0),
+			DuplicatePhones: make([]dto.DuplicatePhone, 0),
+			Warnings:        make([]string, 0),
+			Columns:         make([]string, 0),
+			Variables:       make(map[string]map[string]string),
+		},
+		columns:    make(map[string]struct{}),
+		seenPhones: make(map[string]phonePosition),
+	}
+}
+
+// StartSheet начинает таблицу листа sheet (пусто для форматов без листов) по строке заголовка.
+// Если columnName пустой или не найден, колонка с номерами определяется по заголовку
+func (b *Builder) StartSheet(sheet string, headerRow []string, columnName string) error {
+	phoneColumn, foundColumnName := FindPhoneColumn(headerRow, columnName)
+	if phoneColumn == -1 {
+		if columnName != "" {
+			return fmt.Errorf("column '%s' not found in file header", columnName)
+		}
+		return errors.New("no phone column found in file header. Expected columns: 'Телефон', 'Phone', 'Номер', etc")
+	}
+
+	b.sheets++
+	b.sheet = sheet
+	b.phoneColumn = phoneColumn
+	b.variableColumns = findVariableColumns(headerRow, phoneColumn)
+	b.rowNum = 1
+	b.result.Statistics.TotalRows++
+
+	for _, column := range b.variableColumns {
+		if _, exists := b.columns[column.name]; !exists {
+			b.columns[column.name] = struct{}{}
+			b.result.Columns = append(b.result.Columns, column.name)
+		}
+	}
+
+	if columnName != "" && !strings.EqualFold(foundColumnName, columnName) {
+		b.AddWarning(fmt.Sprintf("Requested column '%s' not found, using '%s' instead", columnName, foundColumnName))
+	}
+
+	return nil
+}
+
+// Sheets возвращает количество начатых таблиц
+func (b *Builder) Sheets() int {
+	return b.sheets
+}
+
+// AddWarning добавляет предупреждение в результат
+func (b *Builder) AddWarning(warning string) {
+	b.result.Warnings = append(b.result.Warnings, warning)
+}
+
+// FindPhoneColumn ищет колонку с номерами телефонов в заголовке
+func FindPhoneColumn(headerRow []string, preferredColumnName string) (int, string) {
+	if preferredColumnName != "" {
+		for i, header := range headerRow {
+			if strings.EqualFold(strings.TrimSpace(header), preferredColumnName) {
+				return i, header
+			}
+		}
+	}
+
+	for i, header := range headerRow {
+		headerLower := strings.ToLower(strings.TrimSpace(header))
+		for _, target := range phoneColumnNames {
+			if strings.Contains(headerLower, target) {
+				return i, header
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+// AddRow обрабатывает очередную строку с данными текущей таблицы.
+// Возвращает ошибку, если превышен лимит строк: чтение файла нужно прекратить
+func (b *Builder) AddRow(row []string) error {
+	result := b.result
+	if b.maxRows > 0 && result.Statistics.DataRows >= b.maxRows {
+		return fmt.Errorf("%w: maximum %d rows", campaign.ErrPhoneFileTooManyRows, b.maxRows)
+	}
+
+	b.rowNum++
+	result.Statistics.TotalRows++
+	result.Statistics.DataRows++
+	result.Statistics.ProcessedRows++
+
+	if b.phoneColumn >= len(row) {
+		result.Statistics.EmptyRows++
+		return nil
+	}
+
+	rawValue := strings.TrimSpace(row[b.phoneColumn])
+	if rawValue == "" {
+		result.Statistics.EmptyRows++
+		return nil
+	}
+
+	phone, corrections, err := b.phones.Normalize(rawValue)
+	if err != nil {
+		if len(result.InvalidPhones) < MaxPhoneDetails {
+			result.InvalidPhones = append(result.InvalidPhones, dto.InvalidPhone{
+				RawValue: rawValue,
+				Sheet:    b.sheet,
+				Row:      b.rowNum,
+				Reason:   err.Error(),
+			})
+		}
+		result.Statistics.InvalidCount++
+		return nil
+	}
+
+	if len(corrections) > 0 && len(result.CorrectedPhones) < MaxPhoneDetails {
+		result.CorrectedPhones = append(result.CorrectedPhones, dto.CorrectedPhone{
+			PhoneNumber: *phone,
+			RawValue:    rawValue,
+			Sheet:       b.sheet,
+			Row:         b.rowNum,
+			Corrections: corrections,
+		})
+	}
+	if len(corrections) > 0 {
+		result.Statistics.CorrectedCount++
+	}
+
+	phoneValue := phone.Value()
+	if firstSeen, exists := b.seenPhones[phoneValue]; exists {
+		if len(result.DuplicatePhones) < MaxPhoneDetails {
+			result.DuplicatePhones = append(result.DuplicatePhones, dto.DuplicatePhone{
+				PhoneNumber:    *phone,
+				RawValue:       rawValue,
+				Sheet:          b.sheet,
+				Row:            b.rowNum,
+				FirstSeenAt:    firstSeen.row,
+				FirstSeenSheet: firstSeen.sheet,
+			})
+		}
+		result.Statistics.DuplicateCount++
+		return nil
+	}
+
+	b.seenPhones[phoneValue] = phonePosition{sheet: b.sheet, row: b.rowNum}
+	if variables := extractVariables(row, b.variableColumns); len(variables) > 0 {
+		result.Variables[phoneValue] = variables
+	}
+	result.ValidPhones = append(result.ValidPhones, *phone)
+	result.Statistics.ValidCount++
+	return nil
+}
+
+// Result завершает сборку: считает итоговую статистику и добавляет предупреждения.
+// Возвращает ошибку, если в файле нет ни одного валидного номера
+func (b *Builder) Result() (*dto.ParseResult, error) {
+	result := b.result
+	result.Statistics.UniqueCount = len(result.ValidPhones)
+
+	if result.Statistics.InvalidCount > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Found %d invalid phone numbers", result.Statistics.InvalidCount))
+	}
+
+	if result.Statistics.CorrectedCount > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Auto-corrected %d phone numbers to international format", result.Statistics.CorrectedCount))
+	}
+
+	if result.Statistics.DuplicateCount > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Found %d duplicate phone numbers", result.Statistics.DuplicateCount))
+	}
+
+	if result.Statistics.EmptyRows > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("Skipped %d empty rows", result.Statistics.EmptyRows))
+	}
+
+	if len(result.ValidPhones) == 0 {
+		return nil, errors.New("no valid phone numbers found in file")
+	}
+
+	return result, nil
+}
+
+// findVariableColumns возвращает все непустые колонки заголовка, кроме колонки с номером.
+// Имена нормализуются для использования в шаблоне: "Номер заказа" -> {{номер_заказа}}
+func findVariableColumns(headerRow []string, phoneColumn int) []variableColumn {
+	columns := make([]variableColumn, 0, len(headerRow))
+	seen := make(map[string]struct{}, len(headerRow))
+
+	for i, header := range headerRow {
+		if i == phoneColumn {
+			continue
+		}
+		name := campaign.NormalizeVariableName(header)
+		if name == "" {
+			continue
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		columns = append(columns, variableColumn{index: i, name: name})
+	}
+
+	return columns
+}
+
+// extractVariables извлекает значения дополнительных колонок из строки
+func extractVariables(row []string, columns []variableColumn) map[string]string {
+	variables := make(map[string]string, len(columns))
+	for _, column := range columns {
+		if column.index >= len(row) {
+			continue
+		}
+		if value := strings.TrimSpace(row[column.index]); value != "" {
+			variables[column.name] = value
+		}
+	}
+	return variables
+}
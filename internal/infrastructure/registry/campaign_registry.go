@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"whatsapp-service/internal/usecases/campaigns/ports"
 )
 
 // InMemoryCampaignRegistry является потокобезопасной реализацией CampaignRegistry в памяти.
@@ -25,7 +26,7 @@ func (r *InMemoryCampaignRegistry) Register(campaignID string, cancelFunc contex
 	defer r.mutex.Unlock()
 
 	if _, exists := r.activeCampaigns[campaignID]; exists {
-		return fmt.Errorf("campaign %s is already active", campaignID)
+		return fmt.Errorf("%w: %s", ports.ErrCampaignAlreadyActive, campaignID)
 	}
 
 	r.activeCampaigns[campaignID] = cancelFunc
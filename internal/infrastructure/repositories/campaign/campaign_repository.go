@@ -3,6 +3,10 @@ package campaignRepository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
 	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/entities/campaign/repository"
 	"whatsapp-service/internal/infrastructure/repositories/campaign/converter"
@@ -30,8 +34,51 @@ func NewPostgresCampaignRepository(pool *pgxpool.Pool, logger interfaces.Logger)
 	}
 }
 
+// campaignColumns — список колонок кампании, читаемых scanCampaign
+const campaignColumns = `id, name, message, status, total_count, processed_count, error_count,
+	delivered_count, read_count, messages_per_hour, media_file_id, initiator, category_name, scheduled_at,
+	send_window_start, send_window_end, send_window_timezone, send_window_weekdays, created_at, updated_at`
+
+// scanCampaign читает строку кампании, выбранную по списку campaignColumns
+func scanCampaign(row pgx.Row) (*models.CampaignNewModel, error) {
+	var campaignModel models.CampaignNewModel
+	var mediaFileID, initiator, categoryName sql.NullString
+
+	err := row.Scan(
+		&campaignModel.ID, &campaignModel.Name, &campaignModel.Message, &campaignModel.Status,
+		&campaignModel.TotalCount, &campaignModel.ProcessedCount, &campaignModel.ErrorCount,
+		&campaignModel.DeliveredCount, &campaignModel.ReadCount,
+		&campaignModel.MessagesPerHour, &mediaFileID, &initiator, &categoryName,
+		&campaignModel.ScheduledAt, &campaignModel.WindowStart, &campaignModel.WindowEnd,
+		&campaignModel.WindowTimezone, &campaignModel.WindowWeekdays,
+		&campaignModel.CreatedAt, &campaignModel.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Обработка NULL значений
+	if mediaFileID.Valid {
+		campaignModel.MediaFileID = &mediaFileID.String
+	}
+	if initiator.Valid {
+		campaignModel.Initiator = &initiator.String
+	}
+	if categoryName.Valid {
+		campaignModel.CategoryName = &categoryName.String
+	}
+
+	return &campaignModel, nil
+}
+
 // Save сохраняет кампанию в базе данных
 func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *campaign.Campaign) error {
+	return r.SaveWithPhoneStatuses(ctx, campaign, nil)
+}
+
+// SaveWithPhoneStatuses сохраняет кампанию и пакеты статусов ее номеров в одной транзакции.
+// Пакеты читаются по одному, поэтому все статусы кампании не обязаны находиться в памяти одновременно
+func (r *PostgresCampaignRepository) SaveWithPhoneStatuses(ctx context.Context, campaign *campaign.Campaign, batches iter.Seq[[]*campaign.CampaignPhoneStatus]) error {
 	r.logger.Debug("campaign repository Save started",
 		"campaign_id", campaign.ID(),
 		"campaign_name", campaign.Name(),
@@ -48,16 +95,8 @@ func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *campaig
 
 	campaignModel := converter.MapCampaignEntityToNewModel(campaign)
 
-	var mediaFileID *string
 	if campaign.Media() != nil {
-		mediaModel := converter.MapMediaToModel(campaign.Media())
-
-		err = tx.QueryRow(ctx, `
-			INSERT INTO media_files (filename, mime_type, message_type, file_size, file_data, checksum_md5, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, '', NOW(), NOW())
-			RETURNING id
-		`, mediaModel.Filename, mediaModel.MimeType, mediaModel.MessageType, mediaModel.FileSize, mediaModel.FileData).Scan(&mediaFileID)
-
+		mediaFileID, err := r.insertMediaFile(ctx, tx, campaign.Media())
 		if err != nil {
 			r.logger.Error("campaign repository Save: failed to save media file", "error", err)
 			return err
@@ -70,13 +109,16 @@ func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *campaig
 	_, err = tx.Exec(ctx, `
 		INSERT INTO campaigns (
 			id, name, message, status, total_count, processed_count, error_count, 
-			messages_per_hour, media_file_id, initiator, category_name, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+			messages_per_hour, media_file_id, initiator, category_name, scheduled_at,
+			send_window_start, send_window_end, send_window_timezone, send_window_weekdays, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, NOW())
 	`,
 		campaignModel.ID, campaignModel.Name, campaignModel.Message, campaignModel.Status,
 		campaignModel.TotalCount, campaignModel.ProcessedCount, campaignModel.ErrorCount,
 		campaignModel.MessagesPerHour, campaignModel.MediaFileID, campaignModel.Initiator,
-		campaignModel.CategoryName, campaignModel.CreatedAt,
+		campaignModel.CategoryName, campaignModel.ScheduledAt,
+		campaignModel.WindowStart, campaignModel.WindowEnd, campaignModel.WindowTimezone, campaignModel.WindowWeekdays,
+		campaignModel.CreatedAt,
 	)
 
 	if err != nil {
@@ -84,6 +126,22 @@ func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *campaig
 		return err
 	}
 
+	for position, variant := range campaign.Variants() {
+		if err = r.insertVariant(ctx, tx, campaign.ID(), variant, position); err != nil {
+			r.logger.Error("campaign repository Save: failed to save message variant",
+				"campaign_id", campaign.ID(), "variant", variant.Name(), "error", err)
+			return err
+		}
+	}
+
+	if batches != nil {
+		if err = r.insertPhoneStatusBatches(ctx, tx, batches); err != nil {
+			r.logger.Error("campaign repository Save: failed to save phone statuses",
+				"campaign_id", campaign.ID(), "error", err)
+			return err
+		}
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		r.logger.Error("campaign repository Save: failed to commit transaction", "error", err)
 		return err
@@ -101,21 +159,8 @@ func (r *PostgresCampaignRepository) Save(ctx context.Context, campaign *campaig
 func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*campaign.Campaign, error) {
 	r.logger.Debug("campaign repository GetByID started", "campaign_id", id)
 
-	var campaignModel models.CampaignNewModel
-	var mediaFileID sql.NullString
-	var initiator sql.NullString
-	var categoryName sql.NullString
-
-	err := r.pool.QueryRow(ctx, `
-		SELECT id, name, message, status, total_count, processed_count, error_count,
-		       messages_per_hour, media_file_id, initiator, category_name, created_at, updated_at
-		FROM campaigns WHERE id = $1
-	`, id).Scan(
-		&campaignModel.ID, &campaignModel.Name, &campaignModel.Message, &campaignModel.Status,
-		&campaignModel.TotalCount, &campaignModel.ProcessedCount, &campaignModel.ErrorCount,
-		&campaignModel.MessagesPerHour, &mediaFileID, &initiator, &categoryName,
-		&campaignModel.CreatedAt, &campaignModel.UpdatedAt,
-	)
+	campaignModel, err := scanCampaign(r.pool.QueryRow(ctx,
+		`SELECT `+campaignColumns+` FROM campaigns WHERE id = $1`, id))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -126,30 +171,9 @@ func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*c
 		return nil, err
 	}
 
-	// Обработка NULL значений
-	if mediaFileID.Valid {
-		campaignModel.MediaFileID = &mediaFileID.String
-	}
-	if initiator.Valid {
-		campaignModel.Initiator = &initiator.String
-	}
-	if categoryName.Valid {
-		campaignModel.CategoryName = &categoryName.String
-	}
-
 	var mediaModel *models.MediaFileModel
 	if campaignModel.MediaFileID != nil {
-		mediaModel = &models.MediaFileModel{}
-		err = r.pool.QueryRow(ctx, `
-			SELECT id, filename, mime_type, message_type, file_size, storage_path, 
-			       file_data, checksum_md5, created_at, updated_at
-			FROM media_files WHERE id = $1
-		`, *campaignModel.MediaFileID).Scan(
-			&mediaModel.ID, &mediaModel.Filename, &mediaModel.MimeType, &mediaModel.MessageType,
-			&mediaModel.FileSize, &mediaModel.StoragePath, &mediaModel.FileData, &mediaModel.ChecksumMD5,
-			&mediaModel.CreatedAt, &mediaModel.UpdatedAt,
-		)
-
+		mediaModel, err = r.getMediaFile(ctx, *campaignModel.MediaFileID)
 		if err != nil {
 			r.logger.Warn("campaign repository GetByID: failed to load media file",
 				"campaign_id", id, "media_file_id", *campaignModel.MediaFileID, "error", err)
@@ -157,9 +181,15 @@ func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*c
 		}
 	}
 
+	variants, err := r.getVariants(ctx, id)
+	if err != nil {
+		r.logger.Error("campaign repository GetByID: failed to load message variants",
+			"campaign_id", id, "error", err)
+		return nil, err
+	}
+
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
-		       sent_at, delivered_at, read_at, created_at, updated_at
+		SELECT `+phoneStatusColumns+`
 		FROM campaign_phone_numbers WHERE campaign_id = $1
 		ORDER BY created_at
 	`, id)
@@ -173,12 +203,7 @@ func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*c
 
 	var phoneModels []*models.CampaignPhoneNumberModel
 	for rows.Next() {
-		phoneModel := &models.CampaignPhoneNumberModel{}
-		err = rows.Scan(
-			&phoneModel.ID, &phoneModel.CampaignID, &phoneModel.PhoneNumber, &phoneModel.Status,
-			&phoneModel.ErrorMessage, &phoneModel.WhatsappMessageID, &phoneModel.SentAt,
-			&phoneModel.DeliveredAt, &phoneModel.ReadAt, &phoneModel.CreatedAt, &phoneModel.UpdatedAt,
-		)
+		phoneModel, err := scanPhoneStatus(rows)
 		if err != nil {
 			r.logger.Error("campaign repository GetByID: failed to scan phone number",
 				"campaign_id", id, "error", err)
@@ -187,7 +212,8 @@ func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*c
 		phoneModels = append(phoneModels, phoneModel)
 	}
 
-	result := converter.MapCampaignNewModelToEntity(&campaignModel, mediaModel, phoneModels)
+	result := converter.MapCampaignNewModelToEntity(campaignModel, mediaModel, phoneModels)
+	result.RestoreVariants(variants)
 
 	r.logger.Debug("campaign repository GetByID completed successfully",
 		"campaign_id", id, "campaign_name", result.Name(), "status", result.Status())
@@ -195,6 +221,107 @@ func (r *PostgresCampaignRepository) GetByID(ctx context.Context, id string) (*c
 	return result, nil
 }
 
+// insertMediaFile сохраняет медиафайл в рамках транзакции и возвращает его ID
+func (r *PostgresCampaignRepository) insertMediaFile(ctx context.Context, tx pgx.Tx, media *campaign.Media) (*string, error) {
+	mediaModel := converter.MapMediaToModel(media)
+
+	var mediaFileID *string
+	err := tx.QueryRow(ctx, `
+		INSERT INTO media_files (filename, mime_type, message_type, file_size, storage_path, file_data, checksum_md5, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id
+	`, mediaModel.Filename, mediaModel.MimeType, mediaModel.MessageType, mediaModel.FileSize,
+		mediaModel.StoragePath, mediaModel.FileData, mediaModel.ChecksumMD5).Scan(&mediaFileID)
+
+	return mediaFileID, err
+}
+
+// getMediaFile загружает медиафайл по ID
+func (r *PostgresCampaignRepository) getMediaFile(ctx context.Context, id string) (*models.MediaFileModel, error) {
+	mediaModel := &models.MediaFileModel{}
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, filename, mime_type, message_type, file_size, storage_path, 
+		       file_data, checksum_md5, created_at, updated_at
+		FROM media_files WHERE id = $1
+	`, id).Scan(
+		&mediaModel.ID, &mediaModel.Filename, &mediaModel.MimeType, &mediaModel.MessageType,
+		&mediaModel.FileSize, &mediaModel.StoragePath, &mediaModel.FileData, &mediaModel.ChecksumMD5,
+		&mediaModel.CreatedAt, &mediaModel.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mediaModel, nil
+}
+
+// insertVariant сохраняет вариант сообщения кампании вместе с его медиафайлом
+func (r *PostgresCampaignRepository) insertVariant(ctx context.Context, tx pgx.Tx, campaignID string, variant *campaign.MessageVariant, position int) error {
+	var mediaFileID *string
+	if variant.Media() != nil {
+		id, err := r.insertMediaFile(ctx, tx, variant.Media())
+		if err != nil {
+			return err
+		}
+		mediaFileID = id
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO campaign_message_variants (campaign_id, name, message, media_file_id, percent, position, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+	`, campaignID, variant.Name(), variant.Message(), mediaFileID, variant.Percent(), position)
+
+	return err
+}
+
+// getVariants загружает варианты сообщения кампании в порядке их создания
+func (r *PostgresCampaignRepository) getVariants(ctx context.Context, campaignID string) ([]*campaign.MessageVariant, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, campaign_id, name, message, media_file_id, percent, position, created_at
+		FROM campaign_message_variants WHERE campaign_id = $1
+		ORDER BY position
+	`, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variantModels []*models.CampaignVariantModel
+	for rows.Next() {
+		variantModel := &models.CampaignVariantModel{}
+		err = rows.Scan(
+			&variantModel.ID, &variantModel.CampaignID, &variantModel.Name, &variantModel.Message,
+			&variantModel.MediaFileID, &variantModel.Percent, &variantModel.Position, &variantModel.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		variantModels = append(variantModels, variantModel)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	variants := make([]*campaign.MessageVariant, 0, len(variantModels))
+	for _, variantModel := range variantModels {
+		var mediaModel *models.MediaFileModel
+		if variantModel.MediaFileID != nil {
+			mediaModel, err = r.getMediaFile(ctx, *variantModel.MediaFileID)
+			if err != nil {
+				r.logger.Warn("campaign repository: failed to load variant media file",
+					"campaign_id", campaignID, "variant", variantModel.Name, "error", err)
+				mediaModel = nil
+			}
+		}
+
+		if variant := converter.MapVariantModelToEntity(variantModel, mediaModel); variant != nil {
+			variants = append(variants, variant)
+		}
+	}
+
+	return variants, nil
+}
+
 // Update обновляет кампанию в базе данных
 func (r *PostgresCampaignRepository) Update(ctx context.Context, campaign *campaign.Campaign) error {
 	r.logger.Debug("campaign repository Update started",
@@ -208,12 +335,12 @@ func (r *PostgresCampaignRepository) Update(ctx context.Context, campaign *campa
 	_, err := r.pool.Exec(ctx, `
 		UPDATE campaigns SET
 			name = $2, message = $3, status = $4, total_count = $5, processed_count = $6,
-			error_count = $7, messages_per_hour = $8, initiator = $9, updated_at = NOW()
+			error_count = $7, messages_per_hour = $8, initiator = $9, scheduled_at = $10, updated_at = NOW()
 		WHERE id = $1
 	`,
 		campaignModel.ID, campaignModel.Name, campaignModel.Message, campaignModel.Status,
 		campaignModel.TotalCount, campaignModel.ProcessedCount, campaignModel.ErrorCount,
-		campaignModel.MessagesPerHour, campaignModel.Initiator,
+		campaignModel.MessagesPerHour, campaignModel.Initiator, campaignModel.ScheduledAt,
 	)
 
 	if err != nil {
@@ -251,6 +378,18 @@ func (r *PostgresCampaignRepository) Delete(ctx context.Context, id string) erro
 		return err
 	}
 
+	_, err = tx.Exec(ctx, `
+		DELETE FROM media_files WHERE id IN (
+			SELECT media_file_id FROM campaign_message_variants
+			WHERE campaign_id = $1 AND media_file_id IS NOT NULL
+		)
+	`, id)
+	if err != nil {
+		r.logger.Error("campaign repository Delete: failed to delete variant media files",
+			"campaign_id", id, "error", err)
+		return err
+	}
+
 	_, err = tx.Exec(ctx, "DELETE FROM campaigns WHERE id = $1", id)
 	if err != nil {
 		r.logger.Error("campaign repository Delete: failed to delete campaign",
@@ -280,8 +419,7 @@ func (r *PostgresCampaignRepository) List(ctx context.Context, limit, offset int
 	r.logger.Debug("campaign repository List started", "limit", limit, "offset", offset)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, name, message, status, total_count, processed_count, error_count,
-		       messages_per_hour, media_file_id, initiator, category_name, created_at, updated_at
+		SELECT `+campaignColumns+`
 		FROM campaigns 
 		ORDER BY created_at DESC
 		LIMIT $1 OFFSET $2
@@ -295,32 +433,13 @@ func (r *PostgresCampaignRepository) List(ctx context.Context, limit, offset int
 
 	var campaigns []*campaign.Campaign
 	for rows.Next() {
-		var campaignModel models.CampaignNewModel
-		var mediaFileID, initiator, categoryName sql.NullString
-
-		err = rows.Scan(
-			&campaignModel.ID, &campaignModel.Name, &campaignModel.Message, &campaignModel.Status,
-			&campaignModel.TotalCount, &campaignModel.ProcessedCount, &campaignModel.ErrorCount,
-			&campaignModel.MessagesPerHour, &mediaFileID, &initiator, &categoryName,
-			&campaignModel.CreatedAt, &campaignModel.UpdatedAt,
-		)
+		campaignModel, err := scanCampaign(rows)
 		if err != nil {
 			r.logger.Error("campaign repository List: failed to scan campaign", "error", err)
 			return nil, err
 		}
 
-		// Обработка NULL значений
-		if mediaFileID.Valid {
-			campaignModel.MediaFileID = &mediaFileID.String
-		}
-		if initiator.Valid {
-			campaignModel.Initiator = &initiator.String
-		}
-		if categoryName.Valid {
-			campaignModel.CategoryName = &categoryName.String
-		}
-
-		c := converter.MapCampaignNewModelToEntity(&campaignModel, nil, nil)
+		c := converter.MapCampaignNewModelToEntity(campaignModel, nil, nil)
 		campaigns = append(campaigns, c)
 	}
 
@@ -404,6 +523,42 @@ func (r *PostgresCampaignRepository) IncrementProcessedCount(ctx context.Context
 	return nil
 }
 
+// IncrementDeliveredCount увеличивает счетчик доставленных сообщений на 1
+func (r *PostgresCampaignRepository) IncrementDeliveredCount(ctx context.Context, id string) error {
+	r.logger.Debug("campaign repository IncrementDeliveredCount started", "campaign_id", id)
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE campaigns SET delivered_count = delivered_count + 1, updated_at = NOW() WHERE id = $1
+	`, id)
+
+	if err != nil {
+		r.logger.Error("campaign repository IncrementDeliveredCount failed",
+			"campaign_id", id, "error", err)
+		return err
+	}
+
+	r.logger.Debug("campaign repository IncrementDeliveredCount completed successfully", "campaign_id", id)
+	return nil
+}
+
+// IncrementReadCount увеличивает счетчик прочитанных сообщений на 1
+func (r *PostgresCampaignRepository) IncrementReadCount(ctx context.Context, id string) error {
+	r.logger.Debug("campaign repository IncrementReadCount started", "campaign_id", id)
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE campaigns SET read_count = read_count + 1, updated_at = NOW() WHERE id = $1
+	`, id)
+
+	if err != nil {
+		r.logger.Error("campaign repository IncrementReadCount failed",
+			"campaign_id", id, "error", err)
+		return err
+	}
+
+	r.logger.Debug("campaign repository IncrementReadCount completed successfully", "campaign_id", id)
+	return nil
+}
+
 // GetActiveCampaigns возвращает список активных кампаний
 func (r *PostgresCampaignRepository) GetActiveCampaigns(ctx context.Context) ([]*campaign.Campaign, error) {
 	r.logger.Debug("campaign repository GetActiveCampaigns started")
@@ -411,9 +566,45 @@ func (r *PostgresCampaignRepository) GetActiveCampaigns(ctx context.Context) ([]
 	return r.getCampaignsByStatus(ctx, []string{
 		string(campaign.CampaignStatusPending),
 		string(campaign.CampaignStatusStarted),
+		string(campaign.CampaignStatusInterrupted),
+		string(campaign.CampaignStatusPaused),
+		string(campaign.CampaignStatusScheduled),
 	})
 }
 
+// ListDueScheduled возвращает запланированные кампании, время запуска которых уже наступило
+func (r *PostgresCampaignRepository) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]*campaign.Campaign, error) {
+	r.logger.Debug("campaign repository ListDueScheduled started", "now", now, "limit", limit)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+campaignColumns+`
+		FROM campaigns 
+		WHERE status = $1 AND scheduled_at <= $2
+		ORDER BY scheduled_at ASC
+		LIMIT $3
+	`, string(campaign.CampaignStatusScheduled), now, limit)
+
+	if err != nil {
+		r.logger.Error("campaign repository ListDueScheduled failed", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var campaigns []*campaign.Campaign
+	for rows.Next() {
+		campaignModel, err := scanCampaign(rows)
+		if err != nil {
+			r.logger.Error("campaign repository ListDueScheduled: failed to scan campaign", "error", err)
+			return nil, err
+		}
+
+		campaigns = append(campaigns, converter.MapCampaignNewModelToEntity(campaignModel, nil, nil))
+	}
+
+	r.logger.Debug("campaign repository ListDueScheduled completed successfully", "count", len(campaigns))
+	return campaigns, nil
+}
+
 // getCampaignsByStatus получает кампании по статусам
 func (r *PostgresCampaignRepository) getCampaignsByStatus(ctx context.Context, statuses []string) ([]*campaign.Campaign, error) {
 	if len(statuses) == 0 {
@@ -427,13 +618,12 @@ func (r *PostgresCampaignRepository) getCampaignsByStatus(ctx context.Context, s
 		if i > 0 {
 			placeholders += ", "
 		}
-		placeholders += "$" + string(rune('1'+i))
+		placeholders += fmt.Sprintf("$%d", i+1)
 		args[i] = status
 	}
 
 	query := `
-		SELECT id, name, message, status, total_count, processed_count, error_count,
-		       messages_per_hour, media_file_id, initiator, category_name, created_at, updated_at
+		SELECT ` + campaignColumns + `
 		FROM campaigns 
 		WHERE status IN (` + placeholders + `)
 		ORDER BY created_at DESC
@@ -449,33 +639,14 @@ func (r *PostgresCampaignRepository) getCampaignsByStatus(ctx context.Context, s
 
 	var campaigns []*campaign.Campaign
 	for rows.Next() {
-		var campaignModel models.CampaignNewModel
-		var mediaFileID, initiator, categoryName sql.NullString
-
-		err = rows.Scan(
-			&campaignModel.ID, &campaignModel.Name, &campaignModel.Message, &campaignModel.Status,
-			&campaignModel.TotalCount, &campaignModel.ProcessedCount, &campaignModel.ErrorCount,
-			&campaignModel.MessagesPerHour, &mediaFileID, &initiator, &categoryName,
-			&campaignModel.CreatedAt, &campaignModel.UpdatedAt,
-		)
+		campaignModel, err := scanCampaign(rows)
 		if err != nil {
 			r.logger.Error("campaign repository getCampaignsByStatus: failed to scan campaign", "error", err)
 			return nil, err
 		}
 
-		// Обработка NULL значений
-		if mediaFileID.Valid {
-			campaignModel.MediaFileID = &mediaFileID.String
-		}
-		if initiator.Valid {
-			campaignModel.Initiator = &initiator.String
-		}
-		if categoryName.Valid {
-			campaignModel.CategoryName = &categoryName.String
-		}
-
 		// Для списка активных кампаний не загружаем детали
-		c := converter.MapCampaignNewModelToEntity(&campaignModel, nil, nil)
+		c := converter.MapCampaignNewModelToEntity(campaignModel, nil, nil)
 		campaigns = append(campaigns, c)
 	}
 
@@ -490,8 +661,7 @@ func (r *PostgresCampaignRepository) ListByStatus(ctx context.Context, status st
 		"status", status, "limit", limit, "offset", offset)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, name, message, status, total_count, processed_count, error_count,
-		       messages_per_hour, media_file_id, initiator, created_at, updated_at
+		SELECT `+campaignColumns+`
 		FROM campaigns 
 		WHERE status = $1
 		ORDER BY created_at DESC
@@ -507,30 +677,14 @@ func (r *PostgresCampaignRepository) ListByStatus(ctx context.Context, status st
 
 	var campaigns []*campaign.Campaign
 	for rows.Next() {
-		var campaignModel models.CampaignNewModel
-		var mediaFileID, initiator sql.NullString
-
-		err = rows.Scan(
-			&campaignModel.ID, &campaignModel.Name, &campaignModel.Message, &campaignModel.Status,
-			&campaignModel.TotalCount, &campaignModel.ProcessedCount, &campaignModel.ErrorCount,
-			&campaignModel.MessagesPerHour, &mediaFileID, &initiator,
-			&campaignModel.CreatedAt, &campaignModel.UpdatedAt,
-		)
+		campaignModel, err := scanCampaign(rows)
 		if err != nil {
 			r.logger.Error("campaign repository ListByStatus: failed to scan campaign", "error", err)
 			return nil, err
 		}
 
-		// Обработка NULL значений
-		if mediaFileID.Valid {
-			campaignModel.MediaFileID = &mediaFileID.String
-		}
-		if initiator.Valid {
-			campaignModel.Initiator = &initiator.String
-		}
-
 		// Для списка не загружаем медиафайлы и номера телефонов
-		c := converter.MapCampaignNewModelToEntity(&campaignModel, nil, nil)
+		c := converter.MapCampaignNewModelToEntity(campaignModel, nil, nil)
 		campaigns = append(campaigns, c)
 	}
 
@@ -574,6 +728,27 @@ func (r *PostgresCampaignRepository) CountByStatus(ctx context.Context, status s
 
 // ========== Методы для работы со статусами номеров телефонов ==========
 
+// phoneStatusColumns — список колонок статуса номера, читаемых scanPhoneStatus
+const phoneStatusColumns = `id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
+	sent_at, delivered_at, read_at, variables, variant, created_at, updated_at`
+
+// scanPhoneStatus читает строку статуса номера, выбранную по списку phoneStatusColumns
+func scanPhoneStatus(row pgx.Row) (*models.CampaignPhoneNumberModel, error) {
+	var phoneModel models.CampaignPhoneNumberModel
+
+	err := row.Scan(
+		&phoneModel.ID, &phoneModel.CampaignID, &phoneModel.PhoneNumber, &phoneModel.Status,
+		&phoneModel.ErrorMessage, &phoneModel.WhatsappMessageID, &phoneModel.SentAt,
+		&phoneModel.DeliveredAt, &phoneModel.ReadAt, &phoneModel.Variables, &phoneModel.Variant,
+		&phoneModel.CreatedAt, &phoneModel.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &phoneModel, nil
+}
+
 // SavePhoneStatus сохраняет статус номера телефона
 func (r *PostgresCampaignRepository) SavePhoneStatus(ctx context.Context, status *campaign.CampaignPhoneStatus) error {
 	r.logger.Debug("campaign repository SavePhoneStatus started", "status_id", status.ID())
@@ -581,10 +756,11 @@ func (r *PostgresCampaignRepository) SavePhoneStatus(ctx context.Context, status
 	_, err := r.pool.Exec(ctx, `
 		INSERT INTO campaign_phone_numbers (
 			id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
-			sent_at, delivered_at, read_at, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+			sent_at, delivered_at, read_at, variables, variant, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
 	`, status.ID(), status.CampaignID(), status.PhoneNumber(), status.Status(), status.ErrorMessage(),
-		status.WhatsappMessageID(), status.SentAt(), status.DeliveredAt(), status.ReadAt(), status.CreatedAt())
+		status.WhatsappMessageID(), status.SentAt(), status.DeliveredAt(), status.ReadAt(),
+		status.Variables(), status.Variant(), status.CreatedAt())
 
 	if err != nil {
 		r.logger.Error("campaign repository SavePhoneStatus failed", "status_id", status.ID(), "error", err)
@@ -595,20 +771,87 @@ func (r *PostgresCampaignRepository) SavePhoneStatus(ctx context.Context, status
 	return nil
 }
 
+// phoneStatusInsertColumns количество параметров одной строки в insertPhoneStatuses
+const phoneStatusInsertColumns = 12
+
+// SavePhoneStatusBatches сохраняет пакеты статусов номеров в одной транзакции
+func (r *PostgresCampaignRepository) SavePhoneStatusBatches(ctx context.Context, batches iter.Seq[[]*campaign.CampaignPhoneStatus]) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.logger.Error("campaign repository SavePhoneStatusBatches: failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := r.insertPhoneStatusBatches(ctx, tx, batches); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("campaign repository SavePhoneStatusBatches: failed to commit transaction", "error", err)
+		return err
+	}
+	return nil
+}
+
+// insertPhoneStatusBatches сохраняет пакеты статусов в рамках транзакции, останавливаясь на первой ошибке
+func (r *PostgresCampaignRepository) insertPhoneStatusBatches(ctx context.Context, tx pgx.Tx, batches iter.Seq[[]*campaign.CampaignPhoneStatus]) error {
+	saved := 0
+	for batch := range batches {
+		if err := r.insertPhoneStatuses(ctx, tx, batch); err != nil {
+			r.logger.Error("campaign repository: failed to save phone statuses batch",
+				"saved", saved, "batch_size", len(batch), "error", err)
+			return err
+		}
+		saved += len(batch)
+	}
+
+	r.logger.Debug("campaign repository: phone statuses saved", "count", saved)
+	return nil
+}
+
+// insertPhoneStatuses сохраняет статусы номеров одним многострочным INSERT.
+// Количество статусов ограничено лимитом параметров запроса PostgreSQL (65535 / 12)
+func (r *PostgresCampaignRepository) insertPhoneStatuses(ctx context.Context, tx pgx.Tx, statuses []*campaign.CampaignPhoneStatus) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	var query strings.Builder
+	query.WriteString(`
+		INSERT INTO campaign_phone_numbers (
+			id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
+			sent_at, delivered_at, read_at, variables, variant, created_at, updated_at
+		) VALUES `)
+
+	args := make([]interface{}, 0, len(statuses)*phoneStatusInsertColumns)
+	for i, status := range statuses {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for column := 1; column <= phoneStatusInsertColumns; column++ {
+			fmt.Fprintf(&query, "$%d, ", len(args)+column)
+		}
+		query.WriteString("NOW())")
+
+		args = append(args, status.ID(), status.CampaignID(), status.PhoneNumber(), status.Status(), status.ErrorMessage(),
+			status.WhatsappMessageID(), status.SentAt(), status.DeliveredAt(), status.ReadAt(),
+			status.Variables(), status.Variant(), status.CreatedAt())
+	}
+
+	_, err := tx.Exec(ctx, query.String(), args...)
+	return err
+}
+
 // GetPhoneStatusByID получает статус номера телефона по ID
 func (r *PostgresCampaignRepository) GetPhoneStatusByID(ctx context.Context, id string) (*campaign.CampaignPhoneStatus, error) {
 	r.logger.Debug("campaign repository GetPhoneStatusByID started", "status_id", id)
 
-	var phoneModel models.CampaignPhoneNumberModel
-	err := r.pool.QueryRow(ctx, `
-		SELECT id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
-		       sent_at, delivered_at, read_at, created_at, updated_at
+	phoneModel, err := scanPhoneStatus(r.pool.QueryRow(ctx, `
+		SELECT `+phoneStatusColumns+`
 		FROM campaign_phone_numbers WHERE id = $1
-	`, id).Scan(
-		&phoneModel.ID, &phoneModel.CampaignID, &phoneModel.PhoneNumber, &phoneModel.Status,
-		&phoneModel.ErrorMessage, &phoneModel.WhatsappMessageID, &phoneModel.SentAt,
-		&phoneModel.DeliveredAt, &phoneModel.ReadAt, &phoneModel.CreatedAt, &phoneModel.UpdatedAt,
-	)
+	`, id))
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -619,11 +862,39 @@ func (r *PostgresCampaignRepository) GetPhoneStatusByID(ctx context.Context, id
 		return nil, err
 	}
 
-	phoneStatus := converter.MapPhoneNumberModelToEntity(&phoneModel)
+	phoneStatus := converter.MapPhoneNumberModelToEntity(phoneModel)
 	r.logger.Debug("campaign repository GetPhoneStatusByID completed successfully", "status_id", id)
 	return phoneStatus, nil
 }
 
+// GetPhoneStatusByMessageID получает статус номера телефона по ID сообщения в WhatsApp
+func (r *PostgresCampaignRepository) GetPhoneStatusByMessageID(ctx context.Context, whatsappMessageID string) (*campaign.CampaignPhoneStatus, error) {
+	r.logger.Debug("campaign repository GetPhoneStatusByMessageID started", "whatsapp_message_id", whatsappMessageID)
+
+	phoneModel, err := scanPhoneStatus(r.pool.QueryRow(ctx, `
+		SELECT `+phoneStatusColumns+`
+		FROM campaign_phone_numbers WHERE whatsapp_message_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, whatsappMessageID))
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			r.logger.Debug("campaign repository GetPhoneStatusByMessageID: status not found",
+				"whatsapp_message_id", whatsappMessageID)
+			return nil, campaign.ErrPhoneNumberNotFound
+		}
+		r.logger.Error("campaign repository GetPhoneStatusByMessageID failed",
+			"whatsapp_message_id", whatsappMessageID, "error", err)
+		return nil, err
+	}
+
+	phoneStatus := converter.MapPhoneNumberModelToEntity(phoneModel)
+	r.logger.Debug("campaign repository GetPhoneStatusByMessageID completed successfully",
+		"whatsapp_message_id", whatsappMessageID, "status_id", phoneStatus.ID())
+	return phoneStatus, nil
+}
+
 // UpdatePhoneStatus обновляет статус номера телефона
 func (r *PostgresCampaignRepository) UpdatePhoneStatus(ctx context.Context, status *campaign.CampaignPhoneStatus) error {
 	r.logger.Debug("campaign repository UpdatePhoneStatus started", "status_id", status.ID())
@@ -645,6 +916,83 @@ func (r *PostgresCampaignRepository) UpdatePhoneStatus(ctx context.Context, stat
 	return nil
 }
 
+// phoneStatusUpdateBatchSize количество номеров в параметре одного UPDATE
+const phoneStatusUpdateBatchSize = 1000
+
+// RetryFailedPhoneStatuses в одной транзакции возвращает в pending неудачные номера и сохраняет статус и счетчики кампании
+func (r *PostgresCampaignRepository) RetryFailedPhoneStatuses(ctx context.Context, c *campaign.Campaign, phoneNumbers []string) error {
+	r.logger.Debug("campaign repository RetryFailedPhoneStatuses started",
+		"campaign_id", c.ID(), "phones", len(phoneNumbers))
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.logger.Error("campaign repository RetryFailedPhoneStatuses: failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var reset int64
+	for start := 0; start < len(phoneNumbers); start += phoneStatusUpdateBatchSize {
+		batch := phoneNumbers[start:min(start+phoneStatusUpdateBatchSize, len(phoneNumbers))]
+		tag, err := tx.Exec(ctx, `
+			UPDATE campaign_phone_numbers SET
+				status = $1, error_message = '', sent_at = NULL, updated_at = NOW()
+			WHERE campaign_id = $2 AND status = $3 AND phone_number = ANY($4::text[])
+		`, campaign.CampaignStatusTypePending, c.ID(), campaign.CampaignStatusTypeFailed, batch)
+		if err != nil {
+			r.logger.Error("campaign repository RetryFailedPhoneStatuses failed", "campaign_id", c.ID(), "error", err)
+			return err
+		}
+		reset += tag.RowsAffected()
+	}
+
+	if reset != int64(len(phoneNumbers)) {
+		r.logger.Warn("campaign repository RetryFailedPhoneStatuses: failed numbers changed concurrently",
+			"campaign_id", c.ID(), "expected", len(phoneNumbers), "reset", reset)
+		return fmt.Errorf("failed numbers changed concurrently: expected %d, reset %d", len(phoneNumbers), reset)
+	}
+
+	metrics := c.Metrics()
+	_, err = tx.Exec(ctx, `
+		UPDATE campaigns SET status = $2, processed_count = $3, error_count = $4, updated_at = NOW() WHERE id = $1
+	`, c.ID(), string(c.Status()), metrics.Processed, metrics.Errors)
+	if err != nil {
+		r.logger.Error("campaign repository RetryFailedPhoneStatuses: failed to update campaign", "campaign_id", c.ID(), "error", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("campaign repository RetryFailedPhoneStatuses: failed to commit transaction", "error", err)
+		return err
+	}
+
+	r.logger.Debug("campaign repository RetryFailedPhoneStatuses completed successfully",
+		"campaign_id", c.ID(), "reset", reset)
+	return nil
+}
+
+// UpdatePhoneDeliveryStatus сохраняет статус доставки номера, если в БД он все еще равен previousStatus
+func (r *PostgresCampaignRepository) UpdatePhoneDeliveryStatus(ctx context.Context, status *campaign.CampaignPhoneStatus, previousStatus campaign.CampaignStatusType) (bool, error) {
+	r.logger.Debug("campaign repository UpdatePhoneDeliveryStatus started",
+		"status_id", status.ID(), "previous_status", previousStatus, "status", status.Status())
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE campaign_phone_numbers SET 
+			status = $1, delivered_at = $2, read_at = $3, updated_at = NOW()
+		WHERE id = $4 AND status = $5
+	`, status.Status(), status.DeliveredAt(), status.ReadAt(), status.ID(), previousStatus)
+
+	if err != nil {
+		r.logger.Error("campaign repository UpdatePhoneDeliveryStatus failed", "status_id", status.ID(), "error", err)
+		return false, err
+	}
+
+	updated := tag.RowsAffected() > 0
+	r.logger.Debug("campaign repository UpdatePhoneDeliveryStatus completed successfully",
+		"status_id", status.ID(), "updated", updated)
+	return updated, nil
+}
+
 // UpdatePhoneStatusByNumber обновляет статус номера телефона по номеру
 func (r *PostgresCampaignRepository) UpdatePhoneStatusByNumber(ctx context.Context, campaignID, phoneNumber string, newStatus campaign.CampaignStatusType, errorMessage string) error {
 	r.logger.Debug("campaign repository UpdatePhoneStatusByNumber started",
@@ -667,13 +1015,110 @@ func (r *PostgresCampaignRepository) UpdatePhoneStatusByNumber(ctx context.Conte
 	return nil
 }
 
+// CancelPendingPhoneStatusesByNumber отменяет ожидающие отправки записи номера во всех кампаниях
+func (r *PostgresCampaignRepository) CancelPendingPhoneStatusesByNumber(ctx context.Context, phoneNumber, errorMessage string) (int, error) {
+	r.logger.Debug("campaign repository CancelPendingPhoneStatusesByNumber started", "phone_number", phoneNumber)
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE campaign_phone_numbers SET
+			status = $1, error_message = $2, updated_at = NOW()
+		WHERE phone_number = $3 AND status = $4
+	`, campaign.CampaignStatusTypeCancelled, errorMessage, phoneNumber, campaign.CampaignStatusTypePending)
+	if err != nil {
+		r.logger.Error("campaign repository CancelPendingPhoneStatusesByNumber failed", "phone_number", phoneNumber, "error", err)
+		return 0, err
+	}
+
+	r.logger.Debug("campaign repository CancelPendingPhoneStatusesByNumber completed successfully",
+		"phone_number", phoneNumber, "cancelled", tag.RowsAffected())
+	return int(tag.RowsAffected()), nil
+}
+
+// FilterFrequencyCapped возвращает номера, достигшие лимита отправленных сообщений с момента since
+func (r *PostgresCampaignRepository) FilterFrequencyCapped(ctx context.Context, phoneNumbers []string, since time.Time, maxMessages int) ([]string, error) {
+	if len(phoneNumbers) == 0 {
+		return nil, nil
+	}
+
+	r.logger.Debug("campaign repository FilterFrequencyCapped started",
+		"phones", len(phoneNumbers), "since", since, "max_messages", maxMessages)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT phone_number
+		FROM campaign_phone_numbers
+		WHERE phone_number = ANY($1::text[]) AND sent_at >= $2
+		GROUP BY phone_number
+		HAVING COUNT(*) >= $3
+	`, phoneNumbers, since, maxMessages)
+	if err != nil {
+		r.logger.Error("campaign repository FilterFrequencyCapped failed", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var capped []string
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			r.logger.Error("campaign repository FilterFrequencyCapped: failed to scan row", "error", err)
+			return nil, err
+		}
+		capped = append(capped, phone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("campaign repository FilterFrequencyCapped completed successfully",
+		"phones", len(phoneNumbers), "capped", len(capped))
+	return capped, nil
+}
+
+// UpdatePhoneSendResultByNumber сохраняет результат отправки сообщения на номер кампании.
+// Обновляется только номер в статусе "pending": номер, отмененный во время отправки
+// (например, после отписки получателя), сохраняет статус и причину отмены. Если сообщение на такой номер
+// все же было отправлено, у него сохраняются время отправки и ID сообщения, чтобы отправку учитывал
+// лимит частоты, а подтверждения доставки находили номер. Возвращает количество номеров, перешедших из "pending"
+func (r *PostgresCampaignRepository) UpdatePhoneSendResultByNumber(ctx context.Context, campaignID, phoneNumber string, newStatus campaign.CampaignStatusType, errorMessage, whatsappMessageID string, sentAt *time.Time) (int64, error) {
+	r.logger.Debug("campaign repository UpdatePhoneSendResultByNumber started",
+		"campaign_id", campaignID, "phone_number", phoneNumber, "status", newStatus, "whatsapp_message_id", whatsappMessageID)
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE campaign_phone_numbers SET 
+			status = $1, error_message = $2, whatsapp_message_id = NULLIF($3, ''), sent_at = $4, updated_at = NOW()
+		WHERE campaign_id = $5 AND phone_number = $6 AND status = $7
+	`, newStatus, errorMessage, whatsappMessageID, sentAt, campaignID, phoneNumber, campaign.CampaignStatusTypePending)
+
+	if err != nil {
+		r.logger.Error("campaign repository UpdatePhoneSendResultByNumber failed",
+			"campaign_id", campaignID, "phone_number", phoneNumber, "error", err)
+		return 0, err
+	}
+
+	if tag.RowsAffected() == 0 && sentAt != nil {
+		_, err = r.pool.Exec(ctx, `
+			UPDATE campaign_phone_numbers SET 
+				whatsapp_message_id = COALESCE(NULLIF($1, ''), whatsapp_message_id), sent_at = $2, updated_at = NOW()
+			WHERE campaign_id = $3 AND phone_number = $4 AND status = $5
+		`, whatsappMessageID, sentAt, campaignID, phoneNumber, campaign.CampaignStatusTypeCancelled)
+		if err != nil {
+			r.logger.Error("campaign repository UpdatePhoneSendResultByNumber: failed to store send of cancelled number",
+				"campaign_id", campaignID, "phone_number", phoneNumber, "error", err)
+			return 0, err
+		}
+	}
+
+	r.logger.Debug("campaign repository UpdatePhoneSendResultByNumber completed successfully",
+		"campaign_id", campaignID, "phone_number", phoneNumber, "updated", tag.RowsAffected())
+	return tag.RowsAffected(), nil
+}
+
 // ListPhoneStatusesByCampaignID возвращает список статусов номеров для кампании
 func (r *PostgresCampaignRepository) ListPhoneStatusesByCampaignID(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error) {
 	r.logger.Debug("campaign repository ListPhoneStatusesByCampaignID started", "campaign_id", campaignID)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
-		       sent_at, delivered_at, read_at, created_at, updated_at
+		SELECT `+phoneStatusColumns+`
 		FROM campaign_phone_numbers WHERE campaign_id = $1
 		ORDER BY created_at
 	`, campaignID)
@@ -686,18 +1131,13 @@ func (r *PostgresCampaignRepository) ListPhoneStatusesByCampaignID(ctx context.C
 
 	var phoneStatuses []*campaign.CampaignPhoneStatus
 	for rows.Next() {
-		var phoneModel models.CampaignPhoneNumberModel
-		err = rows.Scan(
-			&phoneModel.ID, &phoneModel.CampaignID, &phoneModel.PhoneNumber, &phoneModel.Status,
-			&phoneModel.ErrorMessage, &phoneModel.WhatsappMessageID, &phoneModel.SentAt,
-			&phoneModel.DeliveredAt, &phoneModel.ReadAt, &phoneModel.CreatedAt, &phoneModel.UpdatedAt,
-		)
+		phoneModel, err := scanPhoneStatus(rows)
 		if err != nil {
 			r.logger.Error("campaign repository ListPhoneStatusesByCampaignID: failed to scan phone status", "error", err)
 			return nil, err
 		}
 
-		phoneStatus := converter.MapPhoneNumberModelToEntity(&phoneModel)
+		phoneStatus := converter.MapPhoneNumberModelToEntity(phoneModel)
 		phoneStatuses = append(phoneStatuses, phoneStatus)
 	}
 
@@ -706,6 +1146,125 @@ func (r *PostgresCampaignRepository) ListPhoneStatusesByCampaignID(ctx context.C
 	return phoneStatuses, nil
 }
 
+// phoneStatusFilterCondition условие фильтра статусов номеров; параметры $2 (статусы) и $3 (поиск)
+const phoneStatusFilterCondition = `campaign_id = $1
+			AND (cardinality($2::text[]) = 0 OR status = ANY($2))
+			AND ($3::text = '' OR phone_number LIKE '%' || $3::text || '%')`
+
+// phoneStatusFilterArgs возвращает параметры $2 и $3 для phoneStatusFilterCondition
+func phoneStatusFilterArgs(filter repository.PhoneStatusFilter) (statuses []string, search string) {
+	statuses = make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+	return statuses, filter.Search
+}
+
+// ListPhoneStatusesPage возвращает страницу статусов номеров кампании в порядке добавления
+func (r *PostgresCampaignRepository) ListPhoneStatusesPage(ctx context.Context, campaignID string, page repository.PhoneStatusPage) ([]*campaign.CampaignPhoneStatus, error) {
+	r.logger.Debug("campaign repository ListPhoneStatusesPage started",
+		"campaign_id", campaignID, "after_id", page.AfterID, "offset", page.Offset, "limit", page.Limit)
+
+	statuses, search := phoneStatusFilterArgs(page.PhoneStatusFilter)
+
+	// Ключ страницы — (created_at, id): номера одной вставки имеют одинаковое время создания
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+phoneStatusColumns+`
+		FROM campaign_phone_numbers
+		WHERE `+phoneStatusFilterCondition+`
+			AND ($4::text = '' OR (created_at, id) > (
+				SELECT created_at, id FROM campaign_phone_numbers WHERE id = $4::text::uuid
+			))
+		ORDER BY created_at, id
+		LIMIT $5 OFFSET $6
+	`, campaignID, statuses, search, page.AfterID, page.Limit, page.Offset)
+
+	if err != nil {
+		r.logger.Error("campaign repository ListPhoneStatusesPage failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	phoneStatuses := make([]*campaign.CampaignPhoneStatus, 0, page.Limit)
+	for rows.Next() {
+		phoneModel, err := scanPhoneStatus(rows)
+		if err != nil {
+			r.logger.Error("campaign repository ListPhoneStatusesPage: failed to scan phone status", "error", err)
+			return nil, err
+		}
+
+		phoneStatuses = append(phoneStatuses, converter.MapPhoneNumberModelToEntity(phoneModel))
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("campaign repository ListPhoneStatusesPage: rows iteration failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+
+	r.logger.Debug("campaign repository ListPhoneStatusesPage completed successfully",
+		"campaign_id", campaignID, "count", len(phoneStatuses))
+	return phoneStatuses, nil
+}
+
+// CountPhoneStatusesByFilter возвращает количество номеров кампании, подходящих под фильтр
+func (r *PostgresCampaignRepository) CountPhoneStatusesByFilter(ctx context.Context, campaignID string, filter repository.PhoneStatusFilter) (int, error) {
+	r.logger.Debug("campaign repository CountPhoneStatusesByFilter started", "campaign_id", campaignID)
+
+	statuses, search := phoneStatusFilterArgs(filter)
+
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM campaign_phone_numbers
+		WHERE `+phoneStatusFilterCondition+`
+	`, campaignID, statuses, search).Scan(&count)
+
+	if err != nil {
+		r.logger.Error("campaign repository CountPhoneStatusesByFilter failed", "campaign_id", campaignID, "error", err)
+		return 0, err
+	}
+
+	r.logger.Debug("campaign repository CountPhoneStatusesByFilter completed successfully",
+		"campaign_id", campaignID, "count", count)
+	return count, nil
+}
+
+// CountPhoneStatusesGrouped возвращает количество номеров кампании по вариантам сообщения и статусам
+func (r *PostgresCampaignRepository) CountPhoneStatusesGrouped(ctx context.Context, campaignID string) ([]repository.PhoneStatusCount, error) {
+	r.logger.Debug("campaign repository CountPhoneStatusesGrouped started", "campaign_id", campaignID)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT COALESCE(variant, ''), status, COUNT(*)
+		FROM campaign_phone_numbers
+		WHERE campaign_id = $1
+		GROUP BY variant, status
+	`, campaignID)
+
+	if err != nil {
+		r.logger.Error("campaign repository CountPhoneStatusesGrouped failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []repository.PhoneStatusCount
+	for rows.Next() {
+		var count repository.PhoneStatusCount
+		var status string
+		if err := rows.Scan(&count.Variant, &status, &count.Count); err != nil {
+			r.logger.Error("campaign repository CountPhoneStatusesGrouped: failed to scan row", "error", err)
+			return nil, err
+		}
+		count.Status = campaign.CampaignStatusType(status)
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("campaign repository CountPhoneStatusesGrouped: rows iteration failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+
+	r.logger.Debug("campaign repository CountPhoneStatusesGrouped completed successfully",
+		"campaign_id", campaignID, "groups", len(counts))
+	return counts, nil
+}
+
 // UpdatePhoneStatusesByCampaignID обновляет статусы всех номеров кампании
 func (r *PostgresCampaignRepository) UpdatePhoneStatusesByCampaignID(ctx context.Context, campaignID string, oldStatus, newStatus campaign.CampaignStatusType) error {
 	r.logger.Debug("campaign repository UpdatePhoneStatusesByCampaignID started",
@@ -791,9 +1350,9 @@ func (r *PostgresCampaignRepository) GetSentPhoneNumbers(ctx context.Context, ca
 
 	rows, err := r.pool.Query(ctx, `
 		SELECT phone_number FROM campaign_phone_numbers 
-		WHERE campaign_id = $1 AND status = $2
+		WHERE campaign_id = $1 AND status IN ($2, $3, $4)
 		ORDER BY sent_at
-	`, campaignID, campaign.CampaignStatusTypeSent)
+	`, campaignID, campaign.CampaignStatusTypeSent, campaign.CampaignStatusTypeDelivered, campaign.CampaignStatusTypeRead)
 
 	if err != nil {
 		r.logger.Error("campaign repository GetSentPhoneNumbers failed", "campaign_id", campaignID, "error", err)
@@ -821,8 +1380,7 @@ func (r *PostgresCampaignRepository) GetFailedPhoneStatuses(ctx context.Context,
 	r.logger.Debug("campaign repository GetFailedPhoneStatuses started", "campaign_id", campaignID)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, campaign_id, phone_number, status, error_message, whatsapp_message_id,
-		       sent_at, delivered_at, read_at, created_at, updated_at
+		SELECT `+phoneStatusColumns+`
 		FROM campaign_phone_numbers 
 		WHERE campaign_id = $1 AND status = $2
 		ORDER BY updated_at DESC
@@ -836,18 +1394,13 @@ func (r *PostgresCampaignRepository) GetFailedPhoneStatuses(ctx context.Context,
 
 	var failedStatuses []*campaign.CampaignPhoneStatus
 	for rows.Next() {
-		var phoneModel models.CampaignPhoneNumberModel
-		err = rows.Scan(
-			&phoneModel.ID, &phoneModel.CampaignID, &phoneModel.PhoneNumber, &phoneModel.Status,
-			&phoneModel.ErrorMessage, &phoneModel.WhatsappMessageID, &phoneModel.SentAt,
-			&phoneModel.DeliveredAt, &phoneModel.ReadAt, &phoneModel.CreatedAt, &phoneModel.UpdatedAt,
-		)
+		phoneModel, err := scanPhoneStatus(rows)
 		if err != nil {
 			r.logger.Error("campaign repository GetFailedPhoneStatuses: failed to scan phone status", "error", err)
 			return nil, err
 		}
 
-		phoneStatus := converter.MapPhoneNumberModelToEntity(&phoneModel)
+		phoneStatus := converter.MapPhoneNumberModelToEntity(phoneModel)
 		failedStatuses = append(failedStatuses, phoneStatus)
 	}
 
@@ -856,6 +1409,39 @@ func (r *PostgresCampaignRepository) GetFailedPhoneStatuses(ctx context.Context,
 	return failedStatuses, nil
 }
 
+// GetPendingPhoneStatuses возвращает список статусов, ожидающих отправки, в порядке добавления
+func (r *PostgresCampaignRepository) GetPendingPhoneStatuses(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error) {
+	r.logger.Debug("campaign repository GetPendingPhoneStatuses started", "campaign_id", campaignID)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+phoneStatusColumns+`
+		FROM campaign_phone_numbers 
+		WHERE campaign_id = $1 AND status = $2
+		ORDER BY created_at ASC
+	`, campaignID, campaign.CampaignStatusTypePending)
+
+	if err != nil {
+		r.logger.Error("campaign repository GetPendingPhoneStatuses failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pendingStatuses []*campaign.CampaignPhoneStatus
+	for rows.Next() {
+		phoneModel, err := scanPhoneStatus(rows)
+		if err != nil {
+			r.logger.Error("campaign repository GetPendingPhoneStatuses: failed to scan phone status", "error", err)
+			return nil, err
+		}
+
+		pendingStatuses = append(pendingStatuses, converter.MapPhoneNumberModelToEntity(phoneModel))
+	}
+
+	r.logger.Debug("campaign repository GetPendingPhoneStatuses completed successfully",
+		"campaign_id", campaignID, "count", len(pendingStatuses))
+	return pendingStatuses, nil
+}
+
 // CountPhoneStatusesByCampaignID возвращает количество номеров с определенным статусом
 func (r *PostgresCampaignRepository) CountPhoneStatusesByCampaignID(ctx context.Context, campaignID string, status campaign.CampaignStatusType) (int, error) {
 	r.logger.Debug("campaign repository CountPhoneStatusesByCampaignID started",
@@ -0,0 +1,133 @@
+package campaignRepository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/infrastructure/repositories/campaign/converter"
+	"whatsapp-service/internal/infrastructure/repositories/campaign/models"
+	"whatsapp-service/internal/interfaces"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Ensure implementation
+var _ repository.CampaignStatsRepository = (*PostgresCampaignStatsRepository)(nil)
+
+// statsCounterColumns сопоставляет счетчики статистики с колонками таблицы campaign_stats
+var statsCounterColumns = map[campaign.StatsCounter]string{
+	campaign.StatsCounterSent:      "messages_sent",
+	campaign.StatsCounterDelivered: "messages_delivered",
+	campaign.StatsCounterRead:      "messages_read",
+	campaign.StatsCounterFailed:    "messages_failed",
+}
+
+// PostgresCampaignStatsRepository реализует CampaignStatsRepository для PostgreSQL
+type PostgresCampaignStatsRepository struct {
+	pool   *pgxpool.Pool
+	logger interfaces.Logger
+}
+
+// NewPostgresCampaignStatsRepository создает новый экземпляр репозитория статистики кампаний
+func NewPostgresCampaignStatsRepository(pool *pgxpool.Pool, logger interfaces.Logger) *PostgresCampaignStatsRepository {
+	return &PostgresCampaignStatsRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// Increment увеличивает счетчик дневной статистики и в той же транзакции пересчитывает процент доставки
+// и прочтения всех дней кампании. Проценты считаются от всех отправленных сообщений кампании: подтверждение
+// может прийти на следующий день после отправки, а сумма дневных процентов совпадает с итоговым
+func (r *PostgresCampaignStatsRepository) Increment(ctx context.Context, campaignID string, date time.Time, counter campaign.StatsCounter) error {
+	column, ok := statsCounterColumns[counter]
+	if !ok {
+		return fmt.Errorf("unknown stats counter: %s", counter)
+	}
+
+	date = date.UTC()
+	statDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		r.logger.Error("campaign stats repository Increment: failed to begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO campaign_stats (campaign_id, stat_date, %[1]s, created_at, updated_at)
+		VALUES ($1, $2, 1, NOW(), NOW())
+		ON CONFLICT (campaign_id, stat_date) DO UPDATE SET %[1]s = campaign_stats.%[1]s + 1, updated_at = NOW()
+	`, column), campaignID, statDate)
+	if err != nil {
+		r.logger.Error("campaign stats repository Increment failed",
+			"campaign_id", campaignID, "counter", counter, "error", err)
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE campaign_stats SET
+			delivery_rate = CASE WHEN total.sent > 0
+				THEN ROUND(campaign_stats.messages_delivered * 100.0 / total.sent, 2) ELSE 0 END,
+			read_rate = CASE WHEN total.sent > 0
+				THEN ROUND(campaign_stats.messages_read * 100.0 / total.sent, 2) ELSE 0 END
+		FROM (SELECT SUM(messages_sent) AS sent FROM campaign_stats WHERE campaign_id = $1) AS total
+		WHERE campaign_stats.campaign_id = $1
+	`, campaignID)
+	if err != nil {
+		r.logger.Error("campaign stats repository Increment: failed to update rates",
+			"campaign_id", campaignID, "error", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.logger.Error("campaign stats repository Increment: failed to commit transaction", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// ListByPeriod возвращает дневную статистику кампании за период [from, to]
+func (r *PostgresCampaignStatsRepository) ListByPeriod(ctx context.Context, campaignID string, from, to time.Time) ([]*campaign.DailyStats, error) {
+	r.logger.Debug("campaign stats repository ListByPeriod started",
+		"campaign_id", campaignID, "from", from, "to", to)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, campaign_id, stat_date, messages_sent, messages_delivered, messages_read, messages_failed,
+		       COALESCE(delivery_rate, 0)::float8, COALESCE(read_rate, 0)::float8, created_at, updated_at
+		FROM campaign_stats
+		WHERE campaign_id = $1 AND stat_date BETWEEN $2 AND $3
+		ORDER BY stat_date
+	`, campaignID, from, to)
+	if err != nil {
+		r.logger.Error("campaign stats repository ListByPeriod failed", "campaign_id", campaignID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*campaign.DailyStats
+	for rows.Next() {
+		var statsModel models.CampaignStatsModel
+		err = rows.Scan(
+			&statsModel.ID, &statsModel.CampaignID, &statsModel.StatDate,
+			&statsModel.MessagesSent, &statsModel.MessagesDelivered, &statsModel.MessagesRead, &statsModel.MessagesFailed,
+			&statsModel.DeliveryRate, &statsModel.ReadRate, &statsModel.CreatedAt, &statsModel.UpdatedAt,
+		)
+		if err != nil {
+			r.logger.Error("campaign stats repository ListByPeriod: failed to scan row", "error", err)
+			return nil, err
+		}
+		result = append(result, converter.MapStatsModelToEntity(&statsModel))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("campaign stats repository ListByPeriod completed successfully",
+		"campaign_id", campaignID, "days", len(result))
+	return result, nil
+}
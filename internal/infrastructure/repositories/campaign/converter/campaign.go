@@ -27,7 +27,7 @@ func MapCampaignEntityToNewModel(c *campaign.Campaign) *models.CampaignNewModel
 		categoryName = &categoryNameValue
 	}
 
-	return &models.CampaignNewModel{
+	model := &models.CampaignNewModel{
 		ID:              c.ID(),
 		Name:            c.Name(),
 		Message:         c.Message(),
@@ -35,30 +35,90 @@ func MapCampaignEntityToNewModel(c *campaign.Campaign) *models.CampaignNewModel
 		TotalCount:      c.Metrics().Total,
 		ProcessedCount:  c.Metrics().Processed,
 		ErrorCount:      c.Metrics().Errors,
+		DeliveredCount:  c.Metrics().Delivered,
+		ReadCount:       c.Metrics().Read,
 		MessagesPerHour: c.MessagesPerHour(),
 		MediaFileID:     mediaFileID,
 		Initiator:       initiator,
 		CategoryName:    categoryName,
+		ScheduledAt:     c.ScheduledAt(),
 		CreatedAt:       c.CreatedAt(),
 	}
+
+	if window := c.SendingWindow(); window != nil {
+		start, end, timezone := window.Start(), window.End(), window.Timezone()
+		model.WindowStart = &start
+		model.WindowEnd = &end
+		model.WindowTimezone = &timezone
+		model.WindowWeekdays = make([]int16, len(window.Weekdays()))
+		for i, day := range window.Weekdays() {
+			model.WindowWeekdays[i] = int16(day)
+		}
+	}
+
+	return model
 }
 
-// MapMediaToModel преобразует медиа в модель для БД
+// MapMediaToModel преобразует медиа в модель для БД. Содержимое файла, сохраненного
+// во внешнем хранилище, в БД не записывается
 func MapMediaToModel(media *campaign.Media) *models.MediaFileModel {
 	if media == nil {
 		return nil
 	}
 
-	// Кодируем данные в Base64 для хранения в БД
-	fileData := base64.StdEncoding.EncodeToString(media.Data())
-
-	return &models.MediaFileModel{
+	model := &models.MediaFileModel{
 		Filename:    media.Filename(),
 		MimeType:    media.MimeType(),
 		MessageType: string(media.MessageType()),
-		FileData:    &fileData,
-		FileSize:    int64(len(media.Data())),
+		FileSize:    media.Size(),
+		ChecksumMD5: media.ChecksumMD5(),
+	}
+
+	if storagePath := media.StoragePath(); storagePath != "" {
+		model.StoragePath = &storagePath
+		return model
+	}
+
+	// Кодируем данные в Base64 для хранения в БД
+	fileData := base64.StdEncoding.EncodeToString(media.Data())
+	model.FileData = &fileData
+	return model
+}
+
+// MapMediaModelToEntity преобразует модель медиафайла в сущность (nil, если данных нет или они повреждены).
+// Для файла во внешнем хранилище содержимое не загружается
+func MapMediaModelToEntity(mediaFile *models.MediaFileModel) *campaign.Media {
+	if mediaFile == nil {
+		return nil
+	}
+
+	if mediaFile.StoragePath != nil && *mediaFile.StoragePath != "" {
+		return campaign.RestoreMedia(mediaFile.Filename, mediaFile.MimeType, campaign.MessageType(mediaFile.MessageType),
+			mediaFile.FileSize, mediaFile.ChecksumMD5, *mediaFile.StoragePath)
+	}
+
+	if mediaFile.FileData == nil {
+		return nil
+	}
+
+	// Декодируем Base64 данные из БД
+	data, err := base64.StdEncoding.DecodeString(*mediaFile.FileData)
+	if err != nil {
+		return nil
+	}
+
+	media := campaign.NewMedia(mediaFile.Filename, mediaFile.MimeType, data)
+	media.SetMessageType(campaign.MessageType(mediaFile.MessageType))
+	return media
+}
+
+// MapVariantModelToEntity преобразует модель варианта сообщения в сущность
+func MapVariantModelToEntity(model *models.CampaignVariantModel, mediaFile *models.MediaFileModel) *campaign.MessageVariant {
+	variant, err := campaign.NewMessageVariant(model.Name, model.Message, MapMediaModelToEntity(mediaFile), model.Percent)
+	if err != nil {
+		return nil
 	}
+	return variant
 }
 
 // MapPhoneNumbersToModel преобразует номера телефонов в модели для БД
@@ -82,14 +142,7 @@ func MapCampaignNewModelToEntity(
 	mediaFile *models.MediaFileModel,
 	phoneNumbers []*models.CampaignPhoneNumberModel,
 ) *campaign.Campaign {
-	var media *campaign.Media
-	if mediaFile != nil && mediaFile.FileData != nil {
-		// Декодируем Base64 данные из БД
-		if data, err := base64.StdEncoding.DecodeString(*mediaFile.FileData); err == nil {
-			media = campaign.NewMedia(mediaFile.Filename, mediaFile.MimeType, data)
-			media.SetMessageType(campaign.MessageType(mediaFile.MessageType))
-		}
-	}
+	media := MapMediaModelToEntity(mediaFile)
 
 	initiator := ""
 	if dbCampaign.Initiator != nil {
@@ -99,7 +152,7 @@ func MapCampaignNewModelToEntity(
 	// Создаем аудиторию из номеров телефонов
 	audience := &campaign.TargetAudience{}
 	for _, phoneModel := range phoneNumbers {
-		phone, err := campaign.NewPhoneNumber(phoneModel.PhoneNumber)
+		phone, err := campaign.RestorePhoneNumber(phoneModel.PhoneNumber)
 		if err == nil {
 			audience.Primary = append(audience.Primary, phone)
 		}
@@ -122,6 +175,10 @@ func MapCampaignNewModelToEntity(
 			phoneModel.SentAt,
 			phoneModel.CreatedAt,
 		)
+		status.SetVariables(phoneModel.Variables)
+		if phoneModel.Variant != nil {
+			status.SetVariant(*phoneModel.Variant)
+		}
 		delivery.Add(status)
 	}
 
@@ -131,7 +188,7 @@ func MapCampaignNewModelToEntity(
 		categoryName = *dbCampaign.CategoryName
 	}
 
-	return campaign.RestoreCampaign(
+	result := campaign.RestoreCampaign(
 		dbCampaign.ID,
 		dbCampaign.Name,
 		dbCampaign.Message,
@@ -146,9 +203,38 @@ func MapCampaignNewModelToEntity(
 			Total:     dbCampaign.TotalCount,
 			Processed: dbCampaign.ProcessedCount,
 			Errors:    dbCampaign.ErrorCount,
+			Delivered: dbCampaign.DeliveredCount,
+			Read:      dbCampaign.ReadCount,
 		},
 		delivery,
 	)
+	result.SetScheduledAt(dbCampaign.ScheduledAt)
+	result.SetSendingWindow(mapSendingWindow(dbCampaign))
+
+	return result
+}
+
+// mapSendingWindow восстанавливает окно отправки из модели БД (nil, если окно не задано или некорректно)
+func mapSendingWindow(dbCampaign *models.CampaignNewModel) *campaign.SendingWindow {
+	if dbCampaign.WindowStart == nil || dbCampaign.WindowEnd == nil {
+		return nil
+	}
+
+	timezone := ""
+	if dbCampaign.WindowTimezone != nil {
+		timezone = *dbCampaign.WindowTimezone
+	}
+
+	weekdays := make([]time.Weekday, len(dbCampaign.WindowWeekdays))
+	for i, day := range dbCampaign.WindowWeekdays {
+		weekdays[i] = time.Weekday(day)
+	}
+
+	window, err := campaign.NewSendingWindow(*dbCampaign.WindowStart, *dbCampaign.WindowEnd, timezone, weekdays)
+	if err != nil {
+		return nil
+	}
+	return window
 }
 
 // MapPhoneStatusesToModel преобразует статусы телефонов в модели для БД
@@ -179,6 +265,12 @@ func MapPhoneStatusesToModel(statuses []*campaign.CampaignPhoneStatus) []*models
 			whatsappMessageID = &msgID
 		}
 
+		var variant *string
+		if status.Variant() != "" {
+			variantName := status.Variant()
+			variant = &variantName
+		}
+
 		phoneModels = append(phoneModels, &models.CampaignPhoneNumberModel{
 			ID:                status.ID(),
 			CampaignID:        status.CampaignID(),
@@ -189,6 +281,8 @@ func MapPhoneStatusesToModel(statuses []*campaign.CampaignPhoneStatus) []*models
 			SentAt:            sentAt,
 			DeliveredAt:       deliveredAt,
 			ReadAt:            readAt,
+			Variables:         status.Variables(),
+			Variant:           variant,
 			CreatedAt:         status.CreatedAt(),
 		})
 	}
@@ -208,7 +302,7 @@ func MapPhoneNumberModelToEntity(model *models.CampaignPhoneNumberModel) *campai
 		errorMessage = *model.ErrorMessage
 	}
 
-	return campaign.RestoreCampaignStatusExtended(
+	status := campaign.RestoreCampaignStatusExtended(
 		model.ID,
 		model.CampaignID,
 		model.PhoneNumber,
@@ -220,4 +314,34 @@ func MapPhoneNumberModelToEntity(model *models.CampaignPhoneNumberModel) *campai
 		model.ReadAt,
 		model.CreatedAt,
 	)
+	status.SetVariables(model.Variables)
+	if model.Variant != nil {
+		status.SetVariant(*model.Variant)
+	}
+
+	return status
+}
+
+// MapStatsModelToEntity преобразует модель дневной статистики в сущность
+func MapStatsModelToEntity(model *models.CampaignStatsModel) *campaign.DailyStats {
+	return &campaign.DailyStats{
+		CampaignID:   model.CampaignID,
+		Date:         model.StatDate,
+		Sent:         model.MessagesSent,
+		Delivered:    model.MessagesDelivered,
+		Read:         model.MessagesRead,
+		Failed:       model.MessagesFailed,
+		DeliveryRate: model.DeliveryRate,
+		ReadRate:     model.ReadRate,
+	}
+}
+
+// MapOptOutModelToEntity преобразует модель записи стоп-листа в сущность
+func MapOptOutModelToEntity(model *models.OptOutModel) *campaign.OptOut {
+	return &campaign.OptOut{
+		PhoneNumber: model.PhoneNumber,
+		Reason:      model.Reason,
+		Source:      campaign.OptOutSource(model.Source),
+		CreatedAt:   model.CreatedAt,
+	}
 }
@@ -13,10 +13,17 @@ type CampaignNewModel struct {
 	ProcessedCount  int        `db:"processed_count"`
 	ErrorCount      int        `db:"error_count"`
 	SuccessCount    int        `db:"success_count"`
+	DeliveredCount  int        `db:"delivered_count"`
+	ReadCount       int        `db:"read_count"`
 	Initiator       *string    `db:"initiator"`
 	CategoryName    *string    `db:"category_name"`
 	StartedAt       *time.Time `db:"started_at"`
 	CompletedAt     *time.Time `db:"completed_at"`
+	ScheduledAt     *time.Time `db:"scheduled_at"`
+	WindowStart     *string    `db:"send_window_start"`
+	WindowEnd       *string    `db:"send_window_end"`
+	WindowTimezone  *string    `db:"send_window_timezone"`
+	WindowWeekdays  []int16    `db:"send_window_weekdays"`
 	CreatedAt       time.Time  `db:"created_at"`
 	UpdatedAt       time.Time  `db:"updated_at"`
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type CampaignVariantModel struct {
+	ID          string    `db:"id"`
+	CampaignID  string    `db:"campaign_id"`
+	Name        string    `db:"name"`
+	Message     string    `db:"message"`
+	MediaFileID *string   `db:"media_file_id"`
+	Percent     int       `db:"percent"`
+	Position    int       `db:"position"`
+	CreatedAt   time.Time `db:"created_at"`
+}
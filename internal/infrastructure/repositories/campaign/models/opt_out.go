@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type OptOutModel struct {
+	PhoneNumber string    `db:"phone_number"`
+	Reason      string    `db:"reason"`
+	Source      string    `db:"source"`
+	CreatedAt   time.Time `db:"created_at"`
+}
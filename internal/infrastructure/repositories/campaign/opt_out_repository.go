@@ -0,0 +1,166 @@
+package campaignRepository
+
+import (
+	"context"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/infrastructure/repositories/campaign/converter"
+	"whatsapp-service/internal/infrastructure/repositories/campaign/models"
+	"whatsapp-service/internal/interfaces"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Ensure implementation
+var _ repository.OptOutRepository = (*PostgresOptOutRepository)(nil)
+
+// PostgresOptOutRepository реализует OptOutRepository для PostgreSQL
+type PostgresOptOutRepository struct {
+	pool   *pgxpool.Pool
+	logger interfaces.Logger
+}
+
+// NewPostgresOptOutRepository создает новый экземпляр репозитория стоп-листа
+func NewPostgresOptOutRepository(pool *pgxpool.Pool, logger interfaces.Logger) *PostgresOptOutRepository {
+	return &PostgresOptOutRepository{
+		pool:   pool,
+		logger: logger,
+	}
+}
+
+// Add добавляет номер в стоп-лист, не изменяя уже существующую запись
+func (r *PostgresOptOutRepository) Add(ctx context.Context, optOut *campaign.OptOut) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO opt_outs (phone_number, reason, source, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (phone_number) DO NOTHING
+	`, optOut.PhoneNumber, optOut.Reason, string(optOut.Source), optOut.CreatedAt)
+	if err != nil {
+		r.logger.Error("opt-out repository Add failed", "phone_number", optOut.PhoneNumber, "error", err)
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// AddBatch добавляет номера в стоп-лист одним запросом
+func (r *PostgresOptOutRepository) AddBatch(ctx context.Context, optOuts []*campaign.OptOut) (int, error) {
+	if len(optOuts) == 0 {
+		return 0, nil
+	}
+
+	phones := make([]string, len(optOuts))
+	reasons := make([]string, len(optOuts))
+	sources := make([]string, len(optOuts))
+	for i, optOut := range optOuts {
+		phones[i] = optOut.PhoneNumber
+		reasons[i] = optOut.Reason
+		sources[i] = string(optOut.Source)
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		INSERT INTO opt_outs (phone_number, reason, source, created_at)
+		SELECT phone, reason, source, NOW()
+		FROM unnest($1::text[], $2::text[], $3::text[]) AS t(phone, reason, source)
+		ON CONFLICT (phone_number) DO NOTHING
+	`, phones, reasons, sources)
+	if err != nil {
+		r.logger.Error("opt-out repository AddBatch failed", "count", len(optOuts), "error", err)
+		return 0, err
+	}
+
+	r.logger.Debug("opt-out repository AddBatch completed successfully",
+		"count", len(optOuts), "inserted", tag.RowsAffected())
+	return int(tag.RowsAffected()), nil
+}
+
+// Delete удаляет номер из стоп-листа
+func (r *PostgresOptOutRepository) Delete(ctx context.Context, phoneNumber string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM opt_outs WHERE phone_number = $1`, phoneNumber)
+	if err != nil {
+		r.logger.Error("opt-out repository Delete failed", "phone_number", phoneNumber, "error", err)
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return campaign.ErrOptOutNotFound
+	}
+	return nil
+}
+
+// List возвращает страницу стоп-листа
+func (r *PostgresOptOutRepository) List(ctx context.Context, search string, limit, offset int) ([]*campaign.OptOut, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT phone_number, reason, source, created_at
+		FROM opt_outs
+		WHERE $1 = '' OR phone_number LIKE '%' || $1 || '%'
+		ORDER BY created_at DESC, phone_number
+		LIMIT $2 OFFSET $3
+	`, search, limit, offset)
+	if err != nil {
+		r.logger.Error("opt-out repository List failed", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*campaign.OptOut
+	for rows.Next() {
+		var model models.OptOutModel
+		if err := rows.Scan(&model.PhoneNumber, &model.Reason, &model.Source, &model.CreatedAt); err != nil {
+			r.logger.Error("opt-out repository List: failed to scan row", "error", err)
+			return nil, err
+		}
+		result = append(result, converter.MapOptOutModelToEntity(&model))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Count возвращает количество номеров стоп-листа
+func (r *PostgresOptOutRepository) Count(ctx context.Context, search string) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM opt_outs
+		WHERE $1 = '' OR phone_number LIKE '%' || $1 || '%'
+	`, search).Scan(&count)
+	if err != nil {
+		r.logger.Error("opt-out repository Count failed", "error", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// FilterOptedOut возвращает номера, находящиеся в стоп-листе
+func (r *PostgresOptOutRepository) FilterOptedOut(ctx context.Context, phoneNumbers []string) ([]string, error) {
+	if len(phoneNumbers) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT phone_number FROM opt_outs WHERE phone_number = ANY($1::text[])
+	`, phoneNumbers)
+	if err != nil {
+		r.logger.Error("opt-out repository FilterOptedOut failed", "count", len(phoneNumbers), "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var optedOut []string
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			r.logger.Error("opt-out repository FilterOptedOut: failed to scan row", "error", err)
+			return nil, err
+		}
+		optedOut = append(optedOut, phone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	r.logger.Debug("opt-out repository FilterOptedOut completed successfully",
+		"checked", len(phoneNumbers), "opted_out", len(optedOut))
+	return optedOut, nil
+}
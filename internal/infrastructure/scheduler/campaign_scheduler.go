@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+	"whatsapp-service/internal/interfaces"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// DueCampaignsStarter возобновляет прерванные кампании и запускает запланированные, время запуска которых наступило
+type DueCampaignsStarter interface {
+	StartDueCampaigns(ctx context.Context, req dto.StartDueCampaignsRequest) (*dto.StartDueCampaignsResponse, error)
+}
+
+// CampaignScheduler периодически возобновляет прерванные кампании и запускает запланированные.
+// Расписание хранится в БД, поэтому кампании, время которых наступило во время простоя сервиса,
+// запускаются при первой проверке после старта
+type CampaignScheduler struct {
+	starter  DueCampaignsStarter
+	interval time.Duration
+	logger   interfaces.Logger
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewCampaignScheduler создает новый планировщик кампаний
+func NewCampaignScheduler(starter DueCampaignsStarter, interval time.Duration, logger interfaces.Logger) *CampaignScheduler {
+	return &CampaignScheduler{
+		starter:  starter,
+		interval: interval,
+		logger:   logger,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start запускает фоновую проверку запланированных кампаний
+func (s *CampaignScheduler) Start(ctx context.Context) {
+	s.logger.Info("Campaign scheduler starting", "interval", s.interval.String())
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop останавливает планировщик и дожидается завершения текущей проверки
+func (s *CampaignScheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *CampaignScheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Campaign scheduler stopped by context")
+			return
+		case <-s.stopChan:
+			s.logger.Info("Campaign scheduler stopped")
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *CampaignScheduler) tick(ctx context.Context) {
+	resp, err := s.starter.StartDueCampaigns(ctx, dto.StartDueCampaignsRequest{Now: time.Now()})
+	if err != nil {
+		s.logger.Error("Campaign scheduler: failed to start due campaigns", "error", err)
+		return
+	}
+
+	if len(resp.ResumedCampaignIDs) > 0 || len(resp.StartedCampaignIDs) > 0 || resp.DeferredCount > 0 || resp.FailedCount > 0 {
+		s.logger.Info("Campaign scheduler: due campaigns processed",
+			"resumed", len(resp.ResumedCampaignIDs),
+			"started", len(resp.StartedCampaignIDs),
+			"deferred", resp.DeferredCount,
+			"failed", resp.FailedCount,
+		)
+	}
+}
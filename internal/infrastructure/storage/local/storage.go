@@ -0,0 +1,94 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+)
+
+// Ensure implementation
+var _ ports.MediaStorage = (*Storage)(nil)
+
+// Storage хранит медиафайлы в локальной директории. Ключ файла — относительный путь внутри директории
+type Storage struct {
+	root string
+}
+
+// NewStorage создает хранилище в директории root, создавая ее при необходимости
+func NewStorage(root string) (*Storage, error) {
+	if root == "" {
+		return nil, errors.New("media storage path is empty")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media storage directory: %w", err)
+	}
+	return &Storage{root: root}, nil
+}
+
+// Put атомарно записывает файл: данные пишутся во временный файл, который затем переименовывается
+func (s *Storage) Put(_ context.Context, key string, data []byte, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save media file: %w", err)
+	}
+	return nil
+}
+
+// Get читает файл целиком
+func (s *Storage) Get(_ context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media file: %w", err)
+	}
+	return data, nil
+}
+
+// Delete удаляет файл. Отсутствие файла ошибкой не считается
+func (s *Storage) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	return nil
+}
+
+// path возвращает путь файла в директории хранилища. Ключи, выходящие за пределы директории, отклоняются
+func (s *Storage) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == "." ||
+		cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid media key: %q", key)
+	}
+	return filepath.Join(s.root, cleaned), nil
+}
@@ -0,0 +1,63 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	storage, err := NewStorage(root)
+	require.NoError(t, err)
+
+	key := "campaigns/42/image.png"
+	require.NoError(t, storage.Put(ctx, key, []byte("image data"), "image/png"))
+
+	data, err := os.ReadFile(filepath.Join(root, "campaigns", "42", "image.png"))
+	require.NoError(t, err)
+	require.Equal(t, "image data", string(data))
+
+	data, err = storage.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "image data", string(data))
+
+	// Повторная запись перезаписывает файл
+	require.NoError(t, storage.Put(ctx, key, []byte("new data"), "image/png"))
+	data, err = storage.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "new data", string(data))
+
+	require.NoError(t, storage.Delete(ctx, key))
+	_, err = storage.Get(ctx, key)
+	require.Error(t, err)
+
+	// Удаление отсутствующего файла не ошибка
+	require.NoError(t, storage.Delete(ctx, key))
+
+	// Временные файлы не остаются в директории
+	entries, err := os.ReadDir(filepath.Join(root, "campaigns", "42"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestStorage_InvalidKeys(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewStorage(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", ".", "..", "../outside.png", "campaigns/../../outside.png", "/etc/passwd"} {
+		t.Run(key, func(t *testing.T) {
+			require.Error(t, storage.Put(ctx, key, []byte("data"), "image/png"))
+			_, err := storage.Get(ctx, key)
+			require.Error(t, err)
+			require.Error(t, storage.Delete(ctx, key))
+		})
+	}
+}
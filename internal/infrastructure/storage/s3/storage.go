@@ -0,0 +1,217 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	signedHeaders    = "host;x-amz-content-sha256;x-amz-date"
+	maxErrorBody     = 1024 // Сколько байт тела ответа с ошибкой попадает в текст ошибки
+)
+
+// Ensure implementation
+var _ ports.MediaStorage = (*Storage)(nil)
+
+// Config настройки подключения к S3-совместимому хранилищу
+type Config struct {
+	Endpoint  string // Адрес хранилища, например https://s3.amazonaws.com или http://localhost:9000
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	Timeout   time.Duration
+}
+
+// Storage хранит медиафайлы в S3-совместимом хранилище (AWS S3, MinIO и т.д.).
+// Используются path-style адреса (endpoint/bucket/key) и подпись запросов AWS Signature V4
+type Storage struct {
+	cfg      Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewStorage создает хранилище по настройкам подключения
+func NewStorage(cfg Config) (*Storage, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("s3 endpoint and bucket are required")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("s3 access key and secret key are required")
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", cfg.Endpoint)
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &Storage{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		now:      time.Now,
+	}, nil
+}
+
+// Put загружает файл в хранилище
+func (s *Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data, contentType)
+	if err != nil {
+		return fmt.Errorf("failed to upload media file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload media file: %w", responseError(resp))
+	}
+	return nil
+}
+
+// Get скачивает файл из хранилища
+func (s *Storage) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download media file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download media file: %w", responseError(resp))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media file: %w", err)
+	}
+	return data, nil
+}
+
+// Delete удаляет файл из хранилища. Отсутствие файла ошибкой не считается
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete media file: %w", responseError(resp))
+	}
+}
+
+// do выполняет подписанный запрос к объекту key
+func (s *Storage) do(ctx context.Context, method, key string, body []byte, contentType string) (*http.Response, error) {
+	if key == "" {
+		return nil, errors.New("media key is empty")
+	}
+
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(s.endpoint.Path, "/") + "/" + s.cfg.Bucket + "/" + strings.TrimPrefix(key, "/")
+	objectURL.RawPath = escapePath(objectURL.Path)
+	objectURL.RawQuery = ""
+
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	payloadHash := hashHex(body)
+	amzDate := s.now().UTC().Format("20060102T150405Z")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("Authorization", s.authorization(method, req.URL.Host, req.URL.EscapedPath(), payloadHash, amzDate))
+
+	return s.client.Do(req)
+}
+
+// authorization формирует заголовок Authorization по AWS Signature V4
+func (s *Storage) authorization(method, host, escapedPath, payloadHash, amzDate string) string {
+	date := amzDate[:8]
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+
+	canonicalHeaders := "host:" + host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		method,
+		escapedPath,
+		"", // Строка запроса не используется
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.cfg.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, s.cfg.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.cfg.AccessKey, scope, signedHeaders, signature)
+}
+
+// escapePath кодирует путь объекта по правилам S3: экранируется все, кроме A-Z, a-z, 0-9, '-', '.', '_', '~' и '/'
+func escapePath(path string) string {
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '.' || c == '_' || c == '~' || c == '/' {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
+
+// responseError формирует ошибку по ответу хранилища с кодом и началом тела ответа
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
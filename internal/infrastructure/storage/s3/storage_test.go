@@ -0,0 +1,120 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 хранит объекты в памяти и проверяет подпись запросов, как это делает S3
+type fakeS3 struct {
+	t       *testing.T
+	storage *Storage // Используется для расчета ожидаемой подписи
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	require.NoError(f.t, err)
+
+	require.Equal(f.t, hashHex(body), r.Header.Get("X-Amz-Content-Sha256"))
+	expected := f.storage.authorization(r.Method, r.Host, r.URL.EscapedPath(),
+		r.Header.Get("X-Amz-Content-Sha256"), r.Header.Get("X-Amz-Date"))
+	if r.Header.Get("Authorization") != expected {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code></Error>"))
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = fakeObject{data: body, contentType: r.Header.Get("Content-Type")}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, exists := f.objects[r.URL.Path]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code></Error>"))
+			return
+		}
+		_, _ = w.Write(object.data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func newTestStorage(t *testing.T, secretKey string) (*Storage, *fakeS3) {
+	fake := &fakeS3{t: t, objects: make(map[string]fakeObject)}
+	ts := httptest.NewServer(fake)
+	t.Cleanup(ts.Close)
+
+	// Сервер проверяет подпись с эталонным секретом
+	reference, err := NewStorage(Config{Endpoint: ts.URL, Bucket: "media", AccessKey: "access", SecretKey: "secret"})
+	require.NoError(t, err)
+	fake.storage = reference
+
+	storage, err := NewStorage(Config{Endpoint: ts.URL, Bucket: "media", AccessKey: "access", SecretKey: secretKey})
+	require.NoError(t, err)
+	return storage, fake
+}
+
+func TestStorage_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	storage, fake := newTestStorage(t, "secret")
+
+	key := "campaigns/42/файл с пробелом.png"
+	require.NoError(t, storage.Put(ctx, key, []byte("image data"), "image/png"))
+
+	object, exists := fake.objects["/media/"+key]
+	require.True(t, exists)
+	require.Equal(t, "image/png", object.contentType)
+
+	data, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "image data", string(data))
+
+	require.NoError(t, storage.Delete(ctx, key))
+	_, err = storage.Get(ctx, key)
+	require.ErrorContains(t, err, "404")
+
+	// Удаление отсутствующего объекта не ошибка
+	require.NoError(t, storage.Delete(ctx, key))
+}
+
+func TestStorage_InvalidSignature(t *testing.T) {
+	storage, _ := newTestStorage(t, "wrong-secret")
+
+	err := storage.Put(context.Background(), "campaigns/42/image.png", []byte("image data"), "image/png")
+	require.ErrorContains(t, err, "SignatureDoesNotMatch")
+}
+
+func TestNewStorage_Validation(t *testing.T) {
+	_, err := NewStorage(Config{Bucket: "media", AccessKey: "a", SecretKey: "s"})
+	require.Error(t, err)
+
+	_, err = NewStorage(Config{Endpoint: "http://localhost:9000", AccessKey: "a", SecretKey: "s"})
+	require.Error(t, err)
+
+	_, err = NewStorage(Config{Endpoint: "http://localhost:9000", Bucket: "media"})
+	require.Error(t, err)
+
+	_, err = NewStorage(Config{Endpoint: "localhost", Bucket: "media", AccessKey: "a", SecretKey: "s"})
+	require.Error(t, err)
+}
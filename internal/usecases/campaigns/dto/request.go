@@ -1,12 +1,20 @@
 package dto
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+)
 
 // CreateCampaignRequest представляет запрос на создание кампании
 type CreateCampaignRequest struct {
 	Name                 string                // Название кампании
 	Message              string                // Текст сообщения
-	PhoneFile            *multipart.FileHeader // Excel файл с номерами
+	PhoneFile            *multipart.FileHeader // Excel или CSV файл с номерами
+	PhoneSheet           string                // Лист Excel с номерами (пусто = первый лист)
+	PhoneAllSheets       bool                  // Читать номера со всех листов Excel
+	PhoneColumn          string                // Колонка с номерами (пусто = определить по заголовку)
 	MediaFile            *multipart.FileHeader // Медиа-файл (опционально)
 	AdditionalNumbers    []string              // Дополнительные номера
 	ExcludeNumbers       []string              // Номера для исключения
@@ -15,6 +23,57 @@ type CreateCampaignRequest struct {
 	Async                bool                  // Асинхронное выполнение
 	SelectedCategoryName string                // Название выбранной категории для фильтрации (пустая строка = без фильтрации)
 	AutoStartAfterFilter bool                  // Автоматически запустить после фильтрации
+	ScheduledAt          *time.Time            // Время запланированного запуска (nil = без планирования)
+	SendingWindow        *SendingWindow        // Разрешенное окно отправки (nil = без ограничений)
+	Variants             []MessageVariant      // Варианты сообщения для A/B тестирования (пусто = один вариант)
+}
+
+// PreviewPhoneFileRequest представляет запрос анализа файла с номерами перед созданием кампании
+type PreviewPhoneFileRequest struct {
+	File  *multipart.FileHeader // Excel или CSV файл с номерами
+	Sheet string                // Анализируемый лист Excel (пусто = первый лист)
+}
+
+// PreviewAudienceRequest представляет запрос предварительного расчета аудитории кампании.
+// Поля совпадают с источниками номеров CreateCampaignRequest, ничего не сохраняется
+type PreviewAudienceRequest struct {
+	PhoneFile            *multipart.FileHeader // Excel или CSV файл с номерами (опционально)
+	PhoneSheet           string                // Лист Excel с номерами (пусто = первый лист)
+	PhoneAllSheets       bool                  // Читать номера со всех листов Excel
+	PhoneColumn          string                // Колонка с номерами (пусто = определить по заголовку)
+	AdditionalNumbers    []string              // Дополнительные номера
+	ExcludeNumbers       []string              // Номера для исключения
+	SelectedCategoryName string                // Название категории для фильтрации (пустая строка = без фильтрации)
+}
+
+// MessageVariant описывает вариант сообщения для A/B тестирования
+type MessageVariant struct {
+	Name      string                // Имя варианта (например, "A")
+	Message   string                // Текст варианта (пустая строка = текст кампании)
+	MediaFile *multipart.FileHeader // Медиа-файл варианта (nil = медиа кампании)
+	Percent   int                   // Доля получателей в процентах
+}
+
+// SendingWindow описывает разрешенное окно отправки сообщений кампании
+type SendingWindow struct {
+	Start    string         // Время открытия окна в формате "15:04"
+	End      string         // Время закрытия окна в формате "15:04"
+	Timezone string         // Часовой пояс IANA (например, "Europe/Moscow")
+	Weekdays []time.Weekday // Разрешенные дни недели (пустой список = все дни)
+}
+
+// RecordStatsRequest представляет запрос на учет результата отправки в дневной статистике
+type RecordStatsRequest struct {
+	CampaignID string                // ID кампании
+	Counter    campaign.StatsCounter // Увеличиваемый счетчик
+	At         time.Time             // Время события (нулевое значение = time.Now())
+}
+
+// GetCampaignStatsRequest представляет запрос дневной статистики кампании
+type GetCampaignStatsRequest struct {
+	CampaignID string    // ID кампании
+	From       time.Time // Начало периода (нулевое значение = To - DefaultStatsPeriodDays)
+	To         time.Time // Конец периода включительно (нулевое значение = сегодня)
 }
 
 // StartCampaignRequest представляет запрос на запуск кампании
@@ -22,6 +81,28 @@ type StartCampaignRequest struct {
 	CampaignID string // ID кампании для запуска
 }
 
+// StartDueCampaignsRequest представляет запрос на запуск запланированных кампаний
+type StartDueCampaignsRequest struct {
+	Now   time.Time // Текущее время (нулевое значение = time.Now())
+	Limit int       // Максимум кампаний за один вызов
+}
+
+// PauseCampaignRequest представляет запрос на приостановку кампании
+type PauseCampaignRequest struct {
+	CampaignID string // ID кампании для приостановки
+}
+
+// ResumeCampaignRequest представляет запрос на возобновление приостановленной или прерванной кампании
+type ResumeCampaignRequest struct {
+	CampaignID string // ID кампании для возобновления
+}
+
+// RetryFailedRequest представляет запрос на повторную отправку неудачных номеров
+type RetryFailedRequest struct {
+	CampaignID    string // ID кампании
+	ErrorContains string // Повторять только номера, текст ошибки которых содержит подстроку (опционально)
+}
+
 // CancelCampaignRequest представляет запрос на отмену кампании
 type CancelCampaignRequest struct {
 	CampaignID string // ID кампании для отмены
@@ -39,3 +120,77 @@ type ListCampaignsRequest struct {
 	Offset int    // Смещение для пагинации (опционально)
 	Status string // Фильтр по статусу (опционально)
 }
+
+// ListRecipientsRequest представляет запрос страницы номеров кампании
+type ListRecipientsRequest struct {
+	CampaignID string // ID кампании
+	Status     string // Фильтр по статусу номера (опционально, "sent" включает delivered и read)
+	Search     string // Подстрока номера телефона (опционально)
+	Limit      int    // Размер страницы (0 = значение по умолчанию)
+	Offset     int    // Смещение, если не задан After
+	After      string // Курсор: ID последнего номера предыдущей страницы (опционально)
+}
+
+// GetCampaignErrorsRequest представляет запрос разбора ошибок отправки кампании
+type GetCampaignErrorsRequest struct {
+	CampaignID string // ID кампании
+	Reason     string // Вернуть только номера с этой нормализованной причиной ошибки (опционально)
+	Limit      int    // Лимит количества номеров (0 = значение по умолчанию)
+	Offset     int    // Смещение для пагинации номеров
+}
+
+// ExportCampaignRequest представляет запрос выгрузки результатов кампании
+type ExportCampaignRequest struct {
+	CampaignID string             // ID кампании
+	Format     ports.ExportFormat // Формат файла (xlsx или csv)
+	Status     string             // Выгрузить только номера с этим статусом (опционально, "sent" включает delivered и read)
+}
+
+// DeliveryReceipt представляет подтверждение доставки или прочтения сообщения от провайдера
+type DeliveryReceipt struct {
+	MessageID string                      // ID сообщения у провайдера (whatsapp_message_id)
+	Status    campaign.CampaignStatusType // Новый статус: delivered или read
+	At        time.Time                   // Время события (нулевое значение = time.Now())
+}
+
+// ProcessDeliveryReceiptsRequest представляет пакет подтверждений доставки из webhook провайдера
+type ProcessDeliveryReceiptsRequest struct {
+	Receipts []DeliveryReceipt
+}
+
+// AddOptOutRequest представляет запрос добавления номера в стоп-лист
+type AddOptOutRequest struct {
+	PhoneNumber string // Номер телефона
+	Reason      string // Причина (опционально)
+}
+
+// RemoveOptOutRequest представляет запрос удаления номера из стоп-листа
+type RemoveOptOutRequest struct {
+	PhoneNumber string // Номер телефона
+}
+
+// ListOptOutsRequest представляет запрос страницы стоп-листа
+type ListOptOutsRequest struct {
+	Search string // Подстрока номера телефона (опционально)
+	Limit  int    // Размер страницы (0 = значение по умолчанию)
+	Offset int    // Смещение для пагинации
+}
+
+// ImportOptOutsRequest представляет запрос загрузки номеров в стоп-лист из файла и/или списка
+type ImportOptOutsRequest struct {
+	File         *multipart.FileHeader // Excel файл с номерами (опционально)
+	PhoneNumbers []string              // Номера телефонов (опционально)
+	Reason       string                // Причина для всех загружаемых номеров (опционально)
+}
+
+// InboundMessage представляет входящее сообщение получателя из webhook провайдера
+type InboundMessage struct {
+	From string    // Номер отправителя
+	Text string    // Текст сообщения
+	At   time.Time // Время сообщения (нулевое значение = time.Now())
+}
+
+// ProcessInboundMessagesRequest представляет пакет входящих сообщений из webhook провайдера
+type ProcessInboundMessagesRequest struct {
+	Messages []InboundMessage
+}
@@ -1,17 +1,44 @@
 package dto
 
-import "whatsapp-service/internal/entities/campaign"
+import (
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+	infraDTO "whatsapp-service/internal/usecases/dto"
+)
 
 // CreateCampaignResponse представляет ответ на создание кампании
 type CreateCampaignResponse struct {
 	Campaign       *campaign.Campaign // Созданная кампания
 	ValidPhones    int                // Количество валидных номеров
 	InvalidPhones  int                // Количество невалидных номеров
+	BlockedPhones  int                // Количество номеров, исключенных по стоп-листу
+	CappedPhones   int                // Количество номеров, исключенных по лимиту частоты отправки
 	DuplicateCount int                // Количество дубликатов
 	TotalNumbers   int                // Общее количество номеров после обработки
 	Warnings       []string           // Предупреждения
 }
 
+// PreviewAudienceResponse представляет итоговую аудиторию кампании без ее создания.
+// Списки невалидных номеров и дубликатов файла ограничены, счетчики учитывают все строки
+type PreviewAudienceResponse struct {
+	FileRows              int                       // Строки с данными в файле
+	FileValid             int                       // Уникальные валидные номера файла
+	FileInvalid           int                       // Невалидные номера файла
+	FileDuplicates        int                       // Повторы номеров внутри файла
+	FileCorrected         int                       // Номера файла, приведенные к международному формату
+	InvalidPhones         []infraDTO.InvalidPhone   // Невалидные номера файла с номерами строк
+	DuplicatePhones       []infraDTO.DuplicatePhone // Повторы номеров файла с номерами строк
+	AdditionalValid       int                       // Валидные дополнительные номера
+	AdditionalInvalid     []string                  // Невалидные дополнительные номера
+	AdditionalDuplicates  int                       // Дополнительные номера, уже присутствующие в аудитории
+	ExcludedCount         int                       // Номера, убранные списком исключений
+	CategoryFilteredCount int                       // Номера, не прошедшие фильтр по категории
+	BlockedCount          int                       // Номера, исключенные по глобальному стоп-листу
+	CappedCount           int                       // Номера, исключенные по лимиту частоты отправки
+	TotalNumbers          int                       // Итоговое количество получателей
+	Warnings              []string                  // Предупреждения парсера и расчета
+}
+
 // CancelCampaignResponse представляет ответ на отмену кампании
 type CancelCampaignResponse struct {
 	CampaignID         string                  // ID кампании
@@ -28,6 +55,47 @@ type StartCampaignResponse struct {
 	CampaignID          string                  // ID кампании
 	Status              campaign.CampaignStatus // Новый статус кампании
 	TotalNumbers        int                     // Общее количество номеров для отправки
+	SkippedNumbers      int                     // Количество уже обработанных номеров, пропущенных при запуске
+	BlockedNumbers      int                     // Количество номеров, отмененных при запуске по стоп-листу
+	CappedNumbers       int                     // Количество номеров, отмененных при запуске по лимиту частоты отправки
+	EstimatedCompletion string                  // Ориентировочное время завершения
+	WorkerStarted       bool                    // Запущен ли background worker
+}
+
+// StartDueCampaignsResponse представляет результат запуска запланированных и прерванных кампаний
+type StartDueCampaignsResponse struct {
+	ResumedCampaignIDs []string // ID возобновленных прерванных кампаний
+	StartedCampaignIDs []string // ID запущенных кампаний
+	DeferredCount      int      // Количество кампаний, отложенных из-за лимита одновременных рассылок
+	FailedCount        int      // Количество кампаний, которые невозможно запустить или возобновить
+}
+
+// PauseCampaignResponse представляет ответ на приостановку кампании
+type PauseCampaignResponse struct {
+	CampaignID     string                  // ID кампании
+	Status         campaign.CampaignStatus // Новый статус кампании
+	PendingNumbers int                     // Количество номеров, ожидающих отправки
+}
+
+// ResumeCampaignResponse представляет ответ на возобновление кампании
+type ResumeCampaignResponse struct {
+	CampaignID          string                  // ID кампании
+	Status              campaign.CampaignStatus // Новый статус кампании
+	PendingNumbers      int                     // Количество номеров, повторно отправленных в диспетчер
+	AlreadyProcessed    int                     // Количество номеров, обработанных до прерывания
+	BlockedNumbers      int                     // Количество номеров, отмененных по стоп-листу
+	CappedNumbers       int                     // Количество номеров, отмененных по лимиту частоты отправки
+	EstimatedCompletion string                  // Ориентировочное время завершения
+	WorkerStarted       bool                    // Запущен ли background worker
+}
+
+// RetryFailedResponse представляет ответ на повторную отправку неудачных номеров
+type RetryFailedResponse struct {
+	CampaignID          string                  // ID кампании
+	Status              campaign.CampaignStatus // Новый статус кампании
+	RetriedNumbers      int                     // Количество номеров, возвращенных в очередь
+	BlockedNumbers      int                     // Количество номеров, отмененных по стоп-листу
+	CappedNumbers       int                     // Количество номеров, отмененных по лимиту частоты отправки
 	EstimatedCompletion string                  // Ориентировочное время завершения
 	WorkerStarted       bool                    // Запущен ли background worker
 }
@@ -42,6 +110,7 @@ type PhoneNumberStatus struct {
 	SentAt            string
 	DeliveredAt       string
 	ReadAt            string
+	Variant           string
 	CreatedAt         string
 }
 
@@ -65,12 +134,84 @@ type GetCampaignByIDResponse struct {
 	TotalCount      int
 	ProcessedCount  int
 	ErrorCount      int
+	DeliveredCount  int
+	ReadCount       int
 	MessagesPerHour int
 	CategoryName    string
+	ScheduledAt     string
+	SendingWindow   *SendingWindow
 	CreatedAt       string
-	SentNumbers     []PhoneNumberStatus
-	FailedNumbers   []PhoneNumberStatus
+	RecipientCounts RecipientCounts
 	Media           *MediaInfo
+	Variants        []VariantStats
+}
+
+// RecipientCounts представляет количество номеров кампании по статусам
+type RecipientCounts struct {
+	Pending   int
+	Sent      int // Все успешно отправленные, включая доставленные и прочитанные
+	Delivered int // Доставленные, включая прочитанные
+	Read      int
+	Failed    int
+	Cancelled int
+}
+
+// ListRecipientsResponse представляет страницу номеров кампании
+type ListRecipientsResponse struct {
+	CampaignID string
+	Recipients []PhoneNumberStatus
+	Total      int // Количество номеров, подходящих под фильтр
+	Limit      int
+	Offset     int
+	NextCursor string // ID последнего номера страницы для запроса следующей (пусто, если страниц больше нет)
+}
+
+// VariantStats представляет вариант сообщения и результаты его отправки
+type VariantStats struct {
+	Name     string
+	Message  string
+	Percent  int
+	HasMedia bool
+	Total    int
+	Sent     int
+	Failed   int
+}
+
+// GetCampaignErrorsResponse представляет ошибки отправки кампании, сгруппированные по причине
+type GetCampaignErrorsResponse struct {
+	CampaignID     string
+	TotalFailed    int                  // Общее количество неудачных номеров
+	RetryableCount int                  // Количество номеров с ошибками, которые имеет смысл повторить
+	Groups         []CampaignErrorGroup // Группы по причине, по убыванию количества
+	Errors         []FailedNumber       // Страница неудачных номеров (с учетом фильтра по причине)
+	Total          int                  // Количество номеров, подходящих под фильтр
+	Limit          int
+	Offset         int
+}
+
+// CampaignErrorGroup представляет группу неудачных номеров с одной причиной ошибки
+type CampaignErrorGroup struct {
+	Reason         string // Нормализованная причина ошибки
+	Count          int    // Количество номеров
+	RetryableCount int    // Количество номеров группы, отправку которых имеет смысл повторить
+	Retryable      bool   // Имеет ли смысл повторная отправка хотя бы части номеров группы
+	Example        string // Пример исходного текста ошибки
+}
+
+// FailedNumber представляет неудачный номер с исходным текстом ошибки
+type FailedNumber struct {
+	PhoneNumber string
+	Error       string
+	Reason      string
+	Retryable   bool
+	Variant     string
+}
+
+// ExportCampaignResponse представляет итог выгрузки результатов кампании
+type ExportCampaignResponse struct {
+	CampaignID string
+	Format     ports.ExportFormat
+	Rows       int // Количество выгруженных номеров
 }
 
 // CampaignSummary представляет краткую информацию о кампании для списка
@@ -81,8 +222,11 @@ type CampaignSummary struct {
 	TotalCount      int
 	ProcessedCount  int
 	ErrorCount      int
+	DeliveredCount  int
+	ReadCount       int
 	MessagesPerHour int
 	CategoryName    string
+	ScheduledAt     string
 	CreatedAt       string
 }
 
@@ -93,3 +237,67 @@ type ListCampaignsResponse struct {
 	Limit     int
 	Offset    int
 }
+
+// GetCampaignStatsResponse представляет дневную статистику кампании за период
+type GetCampaignStatsResponse struct {
+	CampaignID string
+	From       string
+	To         string
+	Days       []DailyStats // По одному элементу на каждый день периода, включая дни без отправок
+	Total      DailyStats   // Итог за период (Date пустой)
+}
+
+// DailyStats представляет статистику отправки кампании за один день
+type DailyStats struct {
+	Date         string
+	Sent         int
+	Delivered    int
+	Read         int
+	Failed       int
+	DeliveryRate float64
+	ReadRate     float64
+}
+
+// ProcessDeliveryReceiptsResponse представляет результат обработки подтверждений доставки
+type ProcessDeliveryReceiptsResponse struct {
+	Updated  int // Количество номеров, статус которых изменен
+	Ignored  int // Количество подтверждений, не изменивших статус (повторы, устаревшие или неизвестные статусы)
+	NotFound int // Количество подтверждений для неизвестных ID сообщений
+}
+
+// OptOut представляет номер стоп-листа
+type OptOut struct {
+	PhoneNumber string
+	Reason      string
+	Source      string
+	CreatedAt   string
+}
+
+// AddOptOutResponse представляет результат добавления номера в стоп-лист
+type AddOptOutResponse struct {
+	OptOut OptOut
+	Added  bool // false, если номер уже был в стоп-листе
+}
+
+// ListOptOutsResponse представляет страницу стоп-листа
+type ListOptOutsResponse struct {
+	OptOuts []OptOut
+	Total   int
+	Limit   int
+	Offset  int
+}
+
+// ImportOptOutsResponse представляет результат загрузки номеров в стоп-лист
+type ImportOptOutsResponse struct {
+	Total          int // Количество валидных уникальных номеров
+	Added          int // Количество добавленных номеров
+	AlreadyPresent int // Количество номеров, уже находившихся в стоп-листе
+	Invalid        int // Количество невалидных номеров
+}
+
+// ProcessInboundMessagesResponse представляет результат обработки входящих сообщений
+type ProcessInboundMessagesResponse struct {
+	OptedOut         int // Количество отписавшихся отправителей
+	CancelledNumbers int // Количество отмененных номеров в кампаниях
+	Ignored          int // Количество сообщений без стоп-слова или с невалидным номером
+}
@@ -0,0 +1,134 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для предварительного расчета аудитории
+const (
+	MaxPreviewPhoneDetails = 1000 // Максимум невалидных номеров и дубликатов файла в ответе
+)
+
+// Кастомные ошибки для предварительного расчета аудитории
+var (
+	ErrPreviewCategoryFilter = fmt.Errorf("failed to filter audience by category")
+)
+
+// PreviewAudience рассчитывает итоговую аудиторию кампании по тем же правилам, что и Create:
+// парсинг файла, дополнительные номера, исключения, фильтр по категории, стоп-лист и лимит частоты.
+// Кампания и статусы номеров не сохраняются
+func (ci *CampaignInteractor) PreviewAudience(ctx context.Context, req dto.PreviewAudienceRequest) (*dto.PreviewAudienceResponse, error) {
+	if err := ci.validatePreviewAudienceRequest(req); err != nil {
+		return nil, err
+	}
+
+	result, err := ci.processPhoneNumbers(dto.CreateCampaignRequest{
+		PhoneFile:         req.PhoneFile,
+		PhoneSheet:        req.PhoneSheet,
+		PhoneAllSheets:    req.PhoneAllSheets,
+		PhoneColumn:       req.PhoneColumn,
+		AdditionalNumbers: req.AdditionalNumbers,
+		ExcludeNumbers:    req.ExcludeNumbers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := ci.newPreviewAudienceResponse(result)
+
+	if req.SelectedCategoryName != "" {
+		before := len(result.FilePhones) + len(result.AdditionalPhones)
+		if err := ci.filterByCategory(ctx, result, req.SelectedCategoryName); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrPreviewCategoryFilter, err.Error())
+		}
+		response.CategoryFilteredCount = before - len(result.FilePhones) - len(result.AdditionalPhones)
+	}
+
+	candidates := (&campaign.TargetAudience{
+		Primary:    result.FilePhones,
+		Additional: result.AdditionalPhones,
+	}).AllTargets()
+	response.AdditionalDuplicates = len(result.FilePhones) + len(result.AdditionalPhones) - len(candidates)
+
+	afterExclusion := (&campaign.TargetAudience{
+		Primary:  candidates,
+		Excluded: result.ExcludePhones,
+	}).AllTargets()
+	response.ExcludedCount = len(candidates) - len(afterExclusion)
+
+	preview := campaign.NewCampaign("", "", 0, req.SelectedCategoryName)
+	if err := ci.addNumbersToCampaign(ctx, preview, result); err != nil && err != campaign.ErrNoPhoneNumbers {
+		return nil, err
+	}
+
+	response.BlockedCount = result.BlockedCount
+	response.CappedCount = len(result.CappedPhones)
+	response.TotalNumbers = result.TotalTargets
+	if response.TotalNumbers == 0 {
+		response.Warnings = append(response.Warnings, "После применения всех фильтров не осталось получателей")
+	}
+
+	ci.logger.Debug("campaign interactor PreviewAudience completed",
+		"file_valid", response.FileValid,
+		"file_invalid", response.FileInvalid,
+		"additional_valid", response.AdditionalValid,
+		"excluded", response.ExcludedCount,
+		"category_filtered", response.CategoryFilteredCount,
+		"blocked", response.BlockedCount,
+		"capped", response.CappedCount,
+		"total", response.TotalNumbers,
+	)
+
+	return response, nil
+}
+
+// newPreviewAudienceResponse заполняет статистику файла и дополнительных номеров
+func (ci *CampaignInteractor) newPreviewAudienceResponse(result *PhoneProcessingResult) *dto.PreviewAudienceResponse {
+	response := &dto.PreviewAudienceResponse{
+		FileRows:          result.FileStatistics.DataRows,
+		FileValid:         len(result.FilePhones),
+		FileInvalid:       result.FileStatistics.InvalidCount,
+		FileDuplicates:    result.FileStatistics.DuplicateCount,
+		FileCorrected:     result.CorrectedCount,
+		InvalidPhones:     result.FileInvalid,
+		DuplicatePhones:   result.FileDuplicates,
+		AdditionalValid:   len(result.AdditionalPhones),
+		AdditionalInvalid: result.InvalidNumbers,
+		Warnings:          append([]string{}, result.FileWarnings...),
+	}
+
+	// Парсер хранит подробности только для первых номеров, полное количество берется из статистики
+	if len(response.InvalidPhones) > MaxPreviewPhoneDetails {
+		response.InvalidPhones = response.InvalidPhones[:MaxPreviewPhoneDetails]
+	}
+	if response.FileInvalid > len(response.InvalidPhones) {
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("Показаны первые %d невалидных номеров из %d", len(response.InvalidPhones), response.FileInvalid))
+	}
+	if len(response.DuplicatePhones) > MaxPreviewPhoneDetails {
+		response.DuplicatePhones = response.DuplicatePhones[:MaxPreviewPhoneDetails]
+	}
+	if response.FileDuplicates > len(response.DuplicatePhones) {
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("Показаны первые %d дубликатов из %d", len(response.DuplicatePhones), response.FileDuplicates))
+	}
+
+	return response
+}
+
+// validatePreviewAudienceRequest проверяет источники номеров по тем же ограничениям, что и создание кампании
+func (ci *CampaignInteractor) validatePreviewAudienceRequest(req dto.PreviewAudienceRequest) error {
+	if req.PhoneFile == nil && len(req.AdditionalNumbers) == 0 {
+		return campaign.ErrNoPhoneNumbers
+	}
+	if len(req.AdditionalNumbers) > MaxAdditionalNumbers {
+		return ErrTooManyAdditionalNumbers
+	}
+	if len(req.ExcludeNumbers) > MaxExcludeNumbers {
+		return ErrTooManyExcludeNumbers
+	}
+	return nil
+}
@@ -2,41 +2,91 @@ package interactor
 
 import (
 	"context"
+	"sync"
+	"time"
 	"whatsapp-service/internal/entities/campaign/repository"
 	"whatsapp-service/internal/interfaces"
 	retailcrmInterfaces "whatsapp-service/internal/usecases/retailcrm/interfaces"
 
 	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/usecases/campaigns/dto"
+	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
 	"whatsapp-service/internal/usecases/campaigns/ports"
 )
 
+// CampaignOptions содержит настраиваемые ограничения для операций с кампаниями
+type CampaignOptions struct {
+	MaxConcurrentCampaigns int           // Максимальное количество одновременно отправляемых кампаний
+	FrequencyCapMessages   int           // Максимум сообщений одному получателю за окно (0 = без лимита)
+	FrequencyCapWindow     time.Duration // Скользящее окно лимита частоты отправки
+	PhoneFileMaxSize       int64         // Максимальный размер файла с номерами в байтах
+	PhoneFileMaxRows       int           // Максимум строк с данными в файле с номерами
+}
+
 // CampaignInteractor объединяет все операции с кампаниями
 type CampaignInteractor struct {
 	campaignRepo     repository.CampaignRepository
+	optOutRepo       repository.OptOutRepository
 	dispatcher       ports.Dispatcher
 	registry         ports.CampaignRegistry
-	fileParser       ports.FileParser
+	fileParsers      ports.FileParserSelector
+	phones           *campaign.PhoneNormalizer // Нормализация номеров, введенных вручную
+	exporter         ports.ResultsExporter
+	mediaStorage     ports.MediaStorage
 	retailCRMUseCase retailcrmInterfaces.RetailCRMUseCase
+	statsUseCase     campaignInterfaces.CampaignStatsUseCase
+	options          CampaignOptions
+	frequencyCap     *campaign.FrequencyCap // nil, если лимит частоты отключен
 	logger           interfaces.Logger
+
+	// launchMu сериализует проверку лимита одновременных кампаний и перевод кампании в статус "started"
+	launchMu sync.Mutex
+
+	// resumeFailures считает подряд неудачные попытки планировщика возобновить прерванную кампанию
+	resumeFailures   map[string]int
+	resumeFailuresMu sync.Mutex
 }
 
 // NewCampaignInteractor создает новый экземпляр unified use case
 func NewCampaignInteractor(
 	campaignRepo repository.CampaignRepository,
+	optOutRepo repository.OptOutRepository,
 	dispatcher ports.Dispatcher,
 	registry ports.CampaignRegistry,
-	fileParser ports.FileParser,
+	fileParsers ports.FileParserSelector,
+	phones *campaign.PhoneNormalizer,
+	exporter ports.ResultsExporter,
+	mediaStorage ports.MediaStorage,
 	retailCRMUseCase retailcrmInterfaces.RetailCRMUseCase,
+	statsUseCase campaignInterfaces.CampaignStatsUseCase,
+	options CampaignOptions,
 	logger interfaces.Logger,
 ) *CampaignInteractor {
+	if options.MaxConcurrentCampaigns <= 0 {
+		options.MaxConcurrentCampaigns = MaxConcurrentCampaigns
+	}
+	if options.PhoneFileMaxSize <= 0 {
+		options.PhoneFileMaxSize = PhoneFileMaxSize
+	}
+	if options.PhoneFileMaxRows <= 0 {
+		options.PhoneFileMaxRows = PhoneFileMaxRows
+	}
+
 	return &CampaignInteractor{
 		campaignRepo:     campaignRepo,
+		optOutRepo:       optOutRepo,
 		dispatcher:       dispatcher,
 		registry:         registry,
-		fileParser:       fileParser,
+		fileParsers:      fileParsers,
+		phones:           phones,
+		exporter:         exporter,
+		mediaStorage:     mediaStorage,
 		retailCRMUseCase: retailCRMUseCase,
+		statsUseCase:     statsUseCase,
+		options:          options,
+		frequencyCap:     campaign.NewFrequencyCap(options.FrequencyCapMessages, options.FrequencyCapWindow),
 		logger:           logger,
+		resumeFailures:   make(map[string]int),
 	}
 }
 
@@ -51,41 +101,19 @@ func (ci *CampaignInteractor) GetByID(ctx context.Context, req dto.GetCampaignBy
 		return nil, err
 	}
 
-	// Получаем статусы номеров телефонов
-	campaignStatuses, err := ci.campaignRepo.ListPhoneStatusesByCampaignID(ctx, req.CampaignID)
+	// Количество номеров по вариантам и статусам считается в БД, сами номера отдаются постранично через ListRecipients
+	statusCounts, err := ci.campaignRepo.CountPhoneStatusesGrouped(ctx, req.CampaignID)
 	if err != nil {
-		ci.logger.Error("failed to get campaign statuses",
+		ci.logger.Error("failed to count campaign statuses",
 			"campaign_id", req.CampaignID, "error", err)
 		return nil, err
 	}
 
-	// Разделяем статусы на отправленные и неудачные
-	var sentNumbers, failedNumbers []dto.PhoneNumberStatus
-	for _, status := range campaignStatuses {
-		phoneStatus := dto.PhoneNumberStatus{
-			ID:                status.ID(),
-			PhoneNumber:       status.PhoneNumber(),
-			Status:            string(status.Status()),
-			Error:             status.ErrorMessage(),
-			WhatsappMessageID: status.WhatsappMessageID(),
-			CreatedAt:         status.CreatedAt().Format("2006-01-02 15:04:05"),
-		}
-
-		if status.SentAt() != nil {
-			phoneStatus.SentAt = status.SentAt().Format("2006-01-02 15:04:05")
-		}
-		if status.DeliveredAt() != nil {
-			phoneStatus.DeliveredAt = status.DeliveredAt().Format("2006-01-02 15:04:05")
-		}
-		if status.ReadAt() != nil {
-			phoneStatus.ReadAt = status.ReadAt().Format("2006-01-02 15:04:05")
-		}
-
-		if status.Status() == campaign.CampaignStatusTypeSent {
-			sentNumbers = append(sentNumbers, phoneStatus)
-		} else if status.Status() == campaign.CampaignStatusTypeFailed {
-			failedNumbers = append(failedNumbers, phoneStatus)
-		}
+	variantStats := newVariantStats(campaignEntity.Variants())
+	var recipientCounts dto.RecipientCounts
+	for _, count := range statusCounts {
+		countRecipientStatus(&recipientCounts, count)
+		countVariantStatus(variantStats, count)
 	}
 
 	// Информация о медиафайле
@@ -96,7 +124,9 @@ func (ci *CampaignInteractor) GetByID(ctx context.Context, req dto.GetCampaignBy
 			Filename:    media.Filename(),
 			MimeType:    media.MimeType(),
 			MessageType: string(media.MessageType()),
-			Size:        int64(len(media.Data())),
+			Size:        media.Size(),
+			StoragePath: media.StoragePath(),
+			ChecksumMD5: media.ChecksumMD5(),
 			CreatedAt:   campaignEntity.CreatedAt().Format("2006-01-02 15:04:05"),
 		}
 	}
@@ -109,18 +139,105 @@ func (ci *CampaignInteractor) GetByID(ctx context.Context, req dto.GetCampaignBy
 		TotalCount:      campaignEntity.Metrics().Total,
 		ProcessedCount:  campaignEntity.Metrics().Processed,
 		ErrorCount:      campaignEntity.Metrics().Errors,
+		DeliveredCount:  campaignEntity.Metrics().Delivered,
+		ReadCount:       campaignEntity.Metrics().Read,
 		MessagesPerHour: campaignEntity.MessagesPerHour(),
 		CategoryName:    campaignEntity.CategoryName(),
+		ScheduledAt:     formatScheduledAt(campaignEntity),
+		SendingWindow:   mapSendingWindow(campaignEntity.SendingWindow()),
 		CreatedAt:       campaignEntity.CreatedAt().Format("2006-01-02 15:04:05"),
-		SentNumbers:     sentNumbers,
-		FailedNumbers:   failedNumbers,
+		RecipientCounts: recipientCounts,
 		Media:           mediaInfo,
+		Variants:        variantStats,
 	}
 
 	ci.logger.Debug("campaign interactor GetByID completed successfully", "campaign_id", req.CampaignID)
 	return response, nil
 }
 
+// newVariantStats создает пустую статистику по вариантам сообщения кампании
+func newVariantStats(variants []*campaign.MessageVariant) []dto.VariantStats {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	stats := make([]dto.VariantStats, len(variants))
+	for i, variant := range variants {
+		stats[i] = dto.VariantStats{
+			Name:     variant.Name(),
+			Message:  variant.Message(),
+			Percent:  variant.Percent(),
+			HasMedia: variant.Media() != nil,
+		}
+	}
+	return stats
+}
+
+// countRecipientStatus учитывает группу номеров в количестве по статусам
+func countRecipientStatus(counts *dto.RecipientCounts, count repository.PhoneStatusCount) {
+	switch count.Status {
+	case campaign.CampaignStatusTypePending:
+		counts.Pending += count.Count
+	case campaign.CampaignStatusTypeFailed:
+		counts.Failed += count.Count
+	case campaign.CampaignStatusTypeCancelled:
+		counts.Cancelled += count.Count
+	}
+
+	if count.Status.IsSent() {
+		counts.Sent += count.Count
+	}
+	if count.Status == campaign.CampaignStatusTypeDelivered || count.Status == campaign.CampaignStatusTypeRead {
+		counts.Delivered += count.Count
+	}
+	if count.Status == campaign.CampaignStatusTypeRead {
+		counts.Read += count.Count
+	}
+}
+
+// countVariantStatus учитывает группу номеров в статистике ее варианта сообщения
+func countVariantStatus(stats []dto.VariantStats, count repository.PhoneStatusCount) {
+	for i := range stats {
+		if stats[i].Name != count.Variant {
+			continue
+		}
+
+		stats[i].Total += count.Count
+		switch {
+		case count.Status.IsSent():
+			stats[i].Sent += count.Count
+		case count.Status == campaign.CampaignStatusTypeFailed:
+			stats[i].Failed += count.Count
+		}
+		return
+	}
+}
+
+// mapPhoneNumberStatus преобразует статус номера в DTO
+func mapPhoneNumberStatus(status *campaign.CampaignPhoneStatus) dto.PhoneNumberStatus {
+	phoneStatus := dto.PhoneNumberStatus{
+		ID:                status.ID(),
+		PhoneNumber:       status.PhoneNumber(),
+		Status:            string(status.Status()),
+		Error:             status.ErrorMessage(),
+		WhatsappMessageID: status.WhatsappMessageID(),
+		Variant:           status.Variant(),
+		CreatedAt:         status.CreatedAt().Format("2006-01-02 15:04:05"),
+	}
+
+	if status.SentAt() != nil {
+		phoneStatus.SentAt = status.SentAt().Format("2006-01-02 15:04:05")
+	}
+	if status.DeliveredAt() != nil {
+		phoneStatus.DeliveredAt = status.DeliveredAt().Format("2006-01-02 15:04:05")
+	}
+	if status.ReadAt() != nil {
+		phoneStatus.ReadAt = status.ReadAt().Format("2006-01-02 15:04:05")
+	}
+
+	return phoneStatus
+}
+
 // List получает список всех кампаний с возможностью фильтрации и пагинации
 func (ci *CampaignInteractor) List(ctx context.Context, req dto.ListCampaignsRequest) (*dto.ListCampaignsResponse, error) {
 	ci.logger.Debug("campaign interactor List started", "limit", req.Limit, "offset", req.Offset, "status", req.Status)
@@ -164,8 +281,11 @@ func (ci *CampaignInteractor) List(ctx context.Context, req dto.ListCampaignsReq
 			TotalCount:      camp.Metrics().Total,
 			ProcessedCount:  camp.Metrics().Processed,
 			ErrorCount:      camp.Metrics().Errors,
+			DeliveredCount:  camp.Metrics().Delivered,
+			ReadCount:       camp.Metrics().Read,
 			MessagesPerHour: camp.MessagesPerHour(),
 			CategoryName:    camp.CategoryName(),
+			ScheduledAt:     formatScheduledAt(camp),
 			CreatedAt:       camp.CreatedAt().Format("2006-01-02 15:04:05"),
 		}
 	}
@@ -181,6 +301,27 @@ func (ci *CampaignInteractor) List(ctx context.Context, req dto.ListCampaignsReq
 	return response, nil
 }
 
+// formatScheduledAt форматирует время запланированного запуска кампании
+func formatScheduledAt(c *campaign.Campaign) string {
+	if c.ScheduledAt() == nil {
+		return ""
+	}
+	return c.ScheduledAt().Format("2006-01-02 15:04:05")
+}
+
+// mapSendingWindow преобразует окно отправки кампании в DTO
+func mapSendingWindow(window *campaign.SendingWindow) *dto.SendingWindow {
+	if window == nil {
+		return nil
+	}
+	return &dto.SendingWindow{
+		Start:    window.Start(),
+		End:      window.End(),
+		Timezone: window.Timezone(),
+		Weekdays: window.Weekdays(),
+	}
+}
+
 // calculateCampaignMetrics вычисляет метрики кампании на основе статусов
 func (ci *CampaignInteractor) calculateCampaignMetrics(ctx context.Context, campaignID string) (processedCount int, errorCount int) {
 	// Получаем все статусы для кампании
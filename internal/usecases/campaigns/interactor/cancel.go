@@ -157,10 +157,10 @@ func (ci *CampaignInteractor) buildCancelResponse(ctx context.Context, campaignE
 
 	var cancelledNumbers, alreadySentNumbers int
 	for _, status := range statuses {
-		switch status.Status() {
-		case campaign.CampaignStatusTypePending:
+		switch {
+		case status.Status() == campaign.CampaignStatusTypePending:
 			cancelledNumbers++
-		case campaign.CampaignStatusTypeSent:
+		case status.IsSuccessful():
 			alreadySentNumbers++
 		}
 	}
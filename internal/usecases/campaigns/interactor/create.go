@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"mime/multipart"
+	"strings"
+	"time"
 	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/usecases/campaigns/dto"
+	infraDTO "whatsapp-service/internal/usecases/dto"
 	retailcrmDTO "whatsapp-service/internal/usecases/retailcrm/dto"
 )
 
@@ -18,6 +22,9 @@ const (
 	MaxMessagesPerHour    = 3600
 	MaxAdditionalNumbers  = 1000
 	MaxExcludeNumbers     = 1000
+	PhoneStatusBatchSize  = 1000     // Количество статусов номеров, сохраняемых одним запросом к БД
+	PhoneFileMaxSize      = 50 << 20 // Максимальный размер файла с номерами по умолчанию (50 МБ)
+	PhoneFileMaxRows      = 500000   // Максимум строк с данными в файле с номерами по умолчанию
 )
 
 // Кастомные ошибки для create операций
@@ -27,14 +34,12 @@ var (
 	ErrMessageTooLong           = fmt.Errorf("message too long: maximum %d characters", MaxMessageLength)
 	ErrTooManyAdditionalNumbers = fmt.Errorf("too many additional numbers: maximum %d", MaxAdditionalNumbers)
 	ErrTooManyExcludeNumbers    = fmt.Errorf("too many exclude numbers: maximum %d", MaxExcludeNumbers)
+	ErrCheckOptOuts             = fmt.Errorf("failed to check opt-out list")
+	ErrCheckFrequencyCap        = fmt.Errorf("failed to check frequency cap")
 )
 
 // Create выполняет создание кампании
 func (ci *CampaignInteractor) Create(ctx context.Context, req dto.CreateCampaignRequest) (*dto.CreateCampaignResponse, error) {
-	if err := ci.checkActiveCampaigns(ctx); err != nil {
-		return nil, err
-	}
-
 	if err := ci.validateCreateRequest(req); err != nil {
 		return nil, err
 	}
@@ -56,16 +61,32 @@ func (ci *CampaignInteractor) Create(ctx context.Context, req dto.CreateCampaign
 		campaignEntity.Metrics().Total = 0
 	} else {
 		// Если фильтрации нет, добавляем номера сразу
-		if err := ci.addNumbersToCampaign(campaignEntity, phoneProcessingResult); err != nil {
+		if err := ci.addNumbersToCampaign(ctx, campaignEntity, phoneProcessingResult); err != nil {
 			return nil, err
 		}
 	}
 
+	if err := ci.scheduleCampaign(campaignEntity, req.ScheduledAt); err != nil {
+		return nil, err
+	}
+
+	if err := ci.applySendingWindow(campaignEntity, req.SendingWindow); err != nil {
+		return nil, err
+	}
+
 	if err := ci.processMediaFile(campaignEntity, req.MediaFile); err != nil {
 		return nil, err
 	}
 
-	if err := ci.saveCampaignWithStatuses(ctx, campaignEntity); err != nil {
+	if err := ci.applyVariants(campaignEntity, req.Variants); err != nil {
+		return nil, err
+	}
+
+	if err := ci.storeMedia(ctx, campaignEntity); err != nil {
+		return nil, err
+	}
+
+	if err := ci.saveCampaignWithStatuses(ctx, campaignEntity, phoneProcessingResult); err != nil {
 		return nil, err
 	}
 
@@ -82,6 +103,8 @@ func (ci *CampaignInteractor) Create(ctx context.Context, req dto.CreateCampaign
 			ExcludePhones:    copyPhones(phoneProcessingResult.ExcludePhones),
 			InvalidCount:     phoneProcessingResult.InvalidCount,
 			TotalTargets:     phoneProcessingResult.TotalTargets,
+			Variables:        phoneProcessingResult.Variables,
+			VariableColumns:  phoneProcessingResult.VariableColumns,
 		}
 
 		go ci.processCategoryFilteringAsync(campaignEntity.ID(), asyncResult, req.SelectedCategoryName, req.AutoStartAfterFilter)
@@ -125,7 +148,7 @@ func (ci *CampaignInteractor) processCategoryFilteringAsync(campaignID string, r
 		return
 	}
 
-	if err := ci.addNumbersToCampaign(campaignEntity, result); err != nil {
+	if err := ci.addNumbersToCampaign(ctx, campaignEntity, result); err != nil {
 		if err == campaign.ErrNoPhoneNumbers {
 			ci.logger.Info("campaign interactor: no phone numbers found after category filtering",
 				"campaign_id", campaignID,
@@ -145,6 +168,13 @@ func (ci *CampaignInteractor) processCategoryFilteringAsync(campaignID string, r
 			)
 			return
 		}
+	} else if campaignEntity.ScheduledAt() != nil {
+		campaignEntity.SetStatus(campaign.CampaignStatusScheduled)
+		ci.logger.Info("campaign interactor: setting campaign status to scheduled",
+			"campaign_id", campaignID,
+			"scheduled_at", campaignEntity.ScheduledAt(),
+			"total_targets", result.TotalTargets,
+		)
 	} else {
 		campaignEntity.SetStatus(campaign.CampaignStatusPending)
 		ci.logger.Info("campaign interactor: setting campaign status to pending",
@@ -154,6 +184,28 @@ func (ci *CampaignInteractor) processCategoryFilteringAsync(campaignID string, r
 		)
 	}
 
+	// Статусы номеров сохраняются до смены статуса кампании, чтобы кампания не стала
+	// доступной для запуска с неполным списком получателей
+	if result.TotalTargets > 0 {
+		if err := ci.campaignRepo.SavePhoneStatusBatches(ctx, ci.phoneStatusBatches(campaignEntity, result.Targets, result.Variables)); err != nil {
+			ci.logger.Error("campaign interactor: failed to save campaign statuses after filtering",
+				"error", err,
+				"campaign_id", campaignID,
+			)
+			if err := ci.campaignRepo.UpdateStatus(ctx, campaignID, campaign.CampaignStatusFailed); err != nil {
+				ci.logger.Error("campaign interactor: failed to mark campaign as failed",
+					"error", err,
+					"campaign_id", campaignID,
+				)
+			}
+			return
+		}
+	} else {
+		ci.logger.Info("campaign interactor: no phone numbers to save statuses for",
+			"campaign_id", campaignID,
+		)
+	}
+
 	if err := ci.campaignRepo.Update(ctx, campaignEntity); err != nil {
 		ci.logger.Error("campaign interactor: failed to update campaign after filtering",
 			"error", err,
@@ -171,33 +223,6 @@ func (ci *CampaignInteractor) processCategoryFilteringAsync(campaignID string, r
 		return
 	}
 
-	// Сохраняем статусы номеров (только если есть номера)
-	if result.TotalTargets > 0 {
-		targetNumbers := campaignEntity.Audience().AllTargets()
-		statuses := make([]*campaign.CampaignPhoneStatus, 0, len(targetNumbers))
-
-		for _, phone := range targetNumbers {
-			status := campaign.NewCampaignStatus(campaignID, phone.Value())
-			statuses = append(statuses, status)
-		}
-
-		if err := ci.saveCampaignStatuses(ctx, statuses); err != nil {
-			ci.logger.Error("campaign interactor: failed to save campaign statuses after filtering",
-				"error", err,
-				"campaign_id", campaignID,
-			)
-			return
-		}
-
-		for _, status := range statuses {
-			campaignEntity.Delivery().Add(status)
-		}
-	} else {
-		ci.logger.Info("campaign interactor: no phone numbers to save statuses for",
-			"campaign_id", campaignID,
-		)
-	}
-
 	ci.logger.Info("campaign interactor: async category filtering completed successfully",
 		"campaign_id", campaignID,
 		"category_name", categoryName,
@@ -346,26 +371,24 @@ func (ci *CampaignInteractor) filterByCategory(ctx context.Context, result *Phon
 	return nil
 }
 
-func (ci *CampaignInteractor) checkActiveCampaigns(ctx context.Context) error {
-	activeCampaigns, err := ci.campaignRepo.GetActiveCampaigns(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to check active campaigns: %w", err)
-	}
-
-	if len(activeCampaigns) > 0 {
-		return campaign.ErrCampaignAlreadyRunning
-	}
-
-	return nil
-}
-
 // PhoneProcessingResult содержит результаты обработки номеров
 type PhoneProcessingResult struct {
 	FilePhones       []*campaign.PhoneNumber
 	AdditionalPhones []*campaign.PhoneNumber
 	ExcludePhones    []*campaign.PhoneNumber
 	InvalidCount     int
+	InvalidNumbers   []string // Невалидные дополнительные номера
+	CorrectedCount   int      // Номера файла, автоматически приведенные к международному формату
+	FileStatistics   infraDTO.ParseStatistics
+	FileInvalid      []infraDTO.InvalidPhone   // Невалидные номера файла с номерами строк
+	FileDuplicates   []infraDTO.DuplicatePhone // Повторы номеров внутри файла
+	FileWarnings     []string                  // Предупреждения парсера файла
+	BlockedCount     int                       // Номера, исключенные по глобальному стоп-листу
+	CappedPhones     []*campaign.PhoneNumber   // Номера, исключенные по лимиту частоты отправки
+	Targets          []*campaign.PhoneNumber   // Итоговые получатели после всех исключений
 	TotalTargets     int
+	Variables        map[string]map[string]string // Переменные шаблона по номеру телефона (из файла)
+	VariableColumns  []string                     // Колонки файла, доступные в шаблоне
 }
 
 // processPhoneNumbers обрабатывает все телефонные номера из запроса
@@ -373,60 +396,97 @@ func (ci *CampaignInteractor) processPhoneNumbers(req dto.CreateCampaignRequest)
 	result := &PhoneProcessingResult{}
 
 	if req.PhoneFile != nil {
-		filePhones, err := ci.parsePhoneFile(req.PhoneFile)
+		parseResult, err := ci.parsePhoneFile(req.PhoneFile, infraDTO.ParseOptions{
+			Sheet:     req.PhoneSheet,
+			AllSheets: req.PhoneAllSheets,
+			Column:    req.PhoneColumn,
+		})
 		if err != nil {
+			if isPhoneFileError(err) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("failed to parse phone file: %w", err)
 		}
-		result.FilePhones = filePhones
+
+		result.FilePhones = make([]*campaign.PhoneNumber, len(parseResult.ValidPhones))
+		for i := range parseResult.ValidPhones {
+			result.FilePhones[i] = &parseResult.ValidPhones[i]
+		}
+		result.CorrectedCount = parseResult.Statistics.CorrectedCount
+		result.FileStatistics = parseResult.Statistics
+		result.FileInvalid = parseResult.InvalidPhones
+		result.FileDuplicates = parseResult.DuplicatePhones
+		result.FileWarnings = parseResult.Warnings
+		result.Variables = parseResult.Variables
+		result.VariableColumns = parseResult.Columns
 	}
 
-	result.AdditionalPhones, result.InvalidCount = ci.parsePhoneStrings(req.AdditionalNumbers)
+	result.AdditionalPhones, result.InvalidNumbers = ci.parsePhoneStrings(req.AdditionalNumbers)
+	result.InvalidCount = len(result.InvalidNumbers)
 
 	result.ExcludePhones, _ = ci.parsePhoneStrings(req.ExcludeNumbers)
 
 	return result, nil
 }
 
-// parsePhoneFile парсит номера из файла
-func (ci *CampaignInteractor) parsePhoneFile(file *multipart.FileHeader) ([]*campaign.PhoneNumber, error) {
+// parsePhoneFile парсит номера и переменные шаблона из файла с учетом лимитов размера и количества строк
+func (ci *CampaignInteractor) parsePhoneFile(file *multipart.FileHeader, options infraDTO.ParseOptions) (*infraDTO.ParseResult, error) {
+	if err := ci.checkPhoneFileSize(file); err != nil {
+		return nil, err
+	}
+
+	parser, err := ci.fileParsers.ForFile(file.Filename, file.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	options.MaxRows = ci.options.PhoneFileMaxRows
+
 	f, err := file.Open()
 	if err != nil {
 		return nil, fmt.Errorf("failed to open phone file: %w", err)
 	}
 	defer f.Close()
 
-	phones, err := ci.fileParser.ParsePhoneNumbers(f)
+	result, err := parser.ParsePhoneNumbersWithOptions(f, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse phone numbers: %w", err)
 	}
 
-	result := make([]*campaign.PhoneNumber, len(phones))
-	for i, phone := range phones {
-		result[i] = &phone
-	}
-
 	return result, nil
 }
 
-// parsePhoneStrings парсит номера из массива строк
-func (ci *CampaignInteractor) parsePhoneStrings(phoneStrings []string) ([]*campaign.PhoneNumber, int) {
+// checkPhoneFileSize проверяет размер файла с номерами до чтения его содержимого
+func (ci *CampaignInteractor) checkPhoneFileSize(file *multipart.FileHeader) error {
+	if file.Size > ci.options.PhoneFileMaxSize {
+		ci.logger.Warn("campaign interactor: phone file too large",
+			"filename", file.Filename,
+			"size", file.Size,
+			"max_size", ci.options.PhoneFileMaxSize,
+		)
+		return campaign.ErrPhoneFileTooLarge
+	}
+	return nil
+}
+
+// parsePhoneStrings парсит номера из массива строк, возвращая валидные номера и невалидные значения
+func (ci *CampaignInteractor) parsePhoneStrings(phoneStrings []string) ([]*campaign.PhoneNumber, []string) {
 	var phones []*campaign.PhoneNumber
-	var invalidCount int
+	var invalid []string
 
 	for _, phoneStr := range phoneStrings {
-		phone, err := campaign.NewPhoneNumber(phoneStr)
+		phone, err := ci.phones.NewPhoneNumber(phoneStr)
 		if err != nil {
-			invalidCount++
+			invalid = append(invalid, phoneStr)
 			continue
 		}
 		phones = append(phones, phone)
 	}
 
-	return phones, invalidCount
+	return phones, invalid
 }
 
-// addNumbersToCampaign добавляет номера в кампанию
-func (ci *CampaignInteractor) addNumbersToCampaign(campaignEntity *campaign.Campaign, result *PhoneProcessingResult) error {
+// addNumbersToCampaign добавляет номера в кампанию, исключая номера из стоп-листа
+func (ci *CampaignInteractor) addNumbersToCampaign(ctx context.Context, campaignEntity *campaign.Campaign, result *PhoneProcessingResult) error {
 	if len(result.FilePhones) > 0 {
 		if err := campaignEntity.AddPhoneNumbers(result.FilePhones); err != nil {
 			return fmt.Errorf("failed to add file phone numbers: %w", err)
@@ -441,127 +501,320 @@ func (ci *CampaignInteractor) addNumbersToCampaign(campaignEntity *campaign.Camp
 		campaignEntity.AddExcludedNumbers(result.ExcludePhones)
 	}
 
-	targetNumbers := campaignEntity.Audience().AllTargets()
-	if len(targetNumbers) == 0 {
+	// Получатели вычисляются один раз, исключенные номера затем убираются из уже готового списка
+	targets := campaignEntity.Audience().AllTargets()
+
+	blocked, err := ci.findOptedOut(ctx, targets)
+	if err != nil {
+		return err
+	}
+	if len(blocked) > 0 {
+		campaignEntity.AddBlockedNumbers(blocked)
+		targets = withoutPhones(targets, blocked)
+	}
+	result.BlockedCount = len(blocked)
+
+	capped, err := ci.findFrequencyCapped(ctx, targets)
+	if err != nil {
+		return err
+	}
+	if len(capped) > 0 {
+		campaignEntity.AddCappedNumbers(capped)
+		targets = withoutPhones(targets, capped)
+	}
+	result.CappedPhones = capped
+
+	result.Targets = targets
+	result.TotalTargets = len(targets)
+	if len(targets) == 0 {
 		return campaign.ErrNoPhoneNumbers
 	}
 
-	campaignEntity.Metrics().Total = len(targetNumbers)
-	result.TotalTargets = len(targetNumbers)
+	campaignEntity.Metrics().Total = len(targets)
 
 	return nil
 }
 
-// processMediaFile обрабатывает медиа-файл
-func (ci *CampaignInteractor) processMediaFile(c *campaign.Campaign, mediaFile *multipart.FileHeader) error {
-	if mediaFile == nil {
-		return nil
+// findOptedOut возвращает номера из phones, находящиеся в глобальном стоп-листе.
+// Если стоп-лист недоступен, кампания не создается: отправка на отписавшиеся номера недопустима
+func (ci *CampaignInteractor) findOptedOut(ctx context.Context, phones []*campaign.PhoneNumber) ([]*campaign.PhoneNumber, error) {
+	if len(phones) == 0 {
+		return nil, nil
 	}
 
-	mediaData, err := ci.parseMediaFile(mediaFile)
+	optedOut, err := filterInBatches(phoneValues(phones), func(batch []string) ([]string, error) {
+		return ci.optOutRepo.FilterOptedOut(ctx, batch)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to parse media file: %w", err)
+		ci.logger.Error("campaign interactor: failed to check opt-out list", "phones", len(phones), "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrCheckOptOuts, err.Error())
 	}
 
-	media := campaign.NewMedia(mediaFile.Filename, mediaFile.Header.Get("Content-Type"), mediaData)
-	if !media.IsValid() {
-		return fmt.Errorf("invalid media file: unsupported format")
+	return selectPhones(phones, optedOut), nil
+}
+
+// findFrequencyCapped возвращает номера из phones, которым за окно лимита частоты
+// уже отправлено максимальное количество сообщений
+func (ci *CampaignInteractor) findFrequencyCapped(ctx context.Context, phones []*campaign.PhoneNumber) ([]*campaign.PhoneNumber, error) {
+	if ci.frequencyCap == nil || len(phones) == 0 {
+		return nil, nil
 	}
 
-	c.SetMedia(media)
-	return nil
+	capped, err := ci.filterFrequencyCapped(ctx, phoneValues(phones))
+	if err != nil {
+		ci.logger.Error("campaign interactor: failed to check frequency cap", "phones", len(phones), "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrCheckFrequencyCap, err.Error())
+	}
+
+	return selectPhones(phones, capped), nil
 }
 
-// saveCampaignWithStatuses сохраняет кампанию и создает статусы в транзакции
-func (ci *CampaignInteractor) saveCampaignWithStatuses(ctx context.Context, campaignEntity *campaign.Campaign) error {
-	if err := ci.campaignRepo.Save(ctx, campaignEntity); err != nil {
-		ci.logger.Error("Failed to save campaign to DB", map[string]interface{}{
-			"error":      err.Error(),
-			"campaignID": campaignEntity.ID(),
-		})
-		return fmt.Errorf("failed to save campaign: %w", err)
+// filterFrequencyCapped возвращает номера из phoneNumbers, достигшие лимита частоты отправки
+func (ci *CampaignInteractor) filterFrequencyCapped(ctx context.Context, phoneNumbers []string) ([]string, error) {
+	since := ci.frequencyCap.WindowStart(time.Now())
+	return filterInBatches(phoneNumbers, func(batch []string) ([]string, error) {
+		return ci.campaignRepo.FilterFrequencyCapped(ctx, batch, since, ci.frequencyCap.MaxMessages())
+	})
+}
+
+// filterInBatches передает values в filter пакетами по PhoneStatusBatchSize, как и при сохранении
+// статусов, чтобы параметр запроса не рос вместе с аудиторией. Возвращает объединенный результат
+func filterInBatches(values []string, filter func(batch []string) ([]string, error)) ([]string, error) {
+	var matched []string
+	for start := 0; start < len(values); start += PhoneStatusBatchSize {
+		batchMatched, err := filter(values[start:min(start+PhoneStatusBatchSize, len(values))])
+		if err != nil {
+			return nil, err
+		}
+		matched = append(matched, batchMatched...)
+	}
+	return matched, nil
+}
+
+// phoneValues возвращает строковые значения номеров
+func phoneValues(phones []*campaign.PhoneNumber) []string {
+	values := make([]string, len(phones))
+	for i, phone := range phones {
+		values[i] = phone.Value()
+	}
+	return values
+}
+
+// selectPhones возвращает номера из phones, значения которых есть в values
+func selectPhones(phones []*campaign.PhoneNumber, values []string) []*campaign.PhoneNumber {
+	valueSet := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		valueSet[value] = struct{}{}
+	}
+
+	selected := make([]*campaign.PhoneNumber, 0, len(values))
+	for _, phone := range phones {
+		if _, exists := valueSet[phone.Value()]; exists {
+			selected = append(selected, phone)
+		}
+	}
+	return selected
+}
+
+// withoutPhones возвращает номера из phones, которых нет в excluded
+func withoutPhones(phones, excluded []*campaign.PhoneNumber) []*campaign.PhoneNumber {
+	excludedSet := make(map[string]struct{}, len(excluded))
+	for _, phone := range excluded {
+		excludedSet[phone.Value()] = struct{}{}
+	}
+
+	kept := make([]*campaign.PhoneNumber, 0, len(phones))
+	for _, phone := range phones {
+		if _, exists := excludedSet[phone.Value()]; !exists {
+			kept = append(kept, phone)
+		}
+	}
+	return kept
+}
+
+// scheduleCampaign планирует запуск кампании на указанное время.
+// Кампания с фильтрацией получает статус scheduled после завершения фильтрации
+func (ci *CampaignInteractor) scheduleCampaign(campaignEntity *campaign.Campaign, scheduledAt *time.Time) error {
+	if scheduledAt == nil {
+		return nil
 	}
 
-	// Для кампаний с фильтрацией номера будут сохранены после завершения фильтрации
 	if campaignEntity.Status() == campaign.CampaignStatusFiltering {
+		campaignEntity.SetScheduledAt(scheduledAt)
 		return nil
 	}
 
-	targetNumbers := campaignEntity.Audience().AllTargets()
-	statuses := make([]*campaign.CampaignPhoneStatus, 0, len(targetNumbers))
+	return campaignEntity.Schedule(*scheduledAt)
+}
+
+// applySendingWindow устанавливает кампании разрешенное окно отправки
+func (ci *CampaignInteractor) applySendingWindow(campaignEntity *campaign.Campaign, req *dto.SendingWindow) error {
+	if req == nil {
+		return nil
+	}
 
-	for _, phone := range targetNumbers {
-		status := campaign.NewCampaignStatus(campaignEntity.ID(), phone.Value())
-		statuses = append(statuses, status)
+	window, err := campaign.NewSendingWindow(req.Start, req.End, req.Timezone, req.Weekdays)
+	if err != nil {
+		return err
 	}
 
-	if err := ci.saveCampaignStatuses(ctx, statuses); err != nil {
-		return fmt.Errorf("failed to save campaign statuses: %w", err)
+	campaignEntity.SetSendingWindow(window)
+	return nil
+}
+
+// processMediaFile обрабатывает медиа-файл
+func (ci *CampaignInteractor) processMediaFile(c *campaign.Campaign, mediaFile *multipart.FileHeader) error {
+	if mediaFile == nil {
+		return nil
 	}
 
-	for _, status := range statuses {
-		campaignEntity.Delivery().Add(status)
+	media, err := ci.loadMedia(mediaFile)
+	if err != nil {
+		return err
 	}
 
+	c.SetMedia(media)
 	return nil
 }
 
-// saveCampaignStatuses сохраняет статусы пакетно
-func (ci *CampaignInteractor) saveCampaignStatuses(ctx context.Context, statuses []*campaign.CampaignPhoneStatus) error {
-	// Если нет статусов для сохранения, это нормально
-	if len(statuses) == 0 {
-		return nil
+// loadMedia читает и проверяет медиа-файл из multipart
+func (ci *CampaignInteractor) loadMedia(mediaFile *multipart.FileHeader) (*campaign.Media, error) {
+	mediaData, err := ci.parseMediaFile(mediaFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse media file: %w", err)
 	}
 
-	var lastErr error
-	successCount := 0
+	media := campaign.NewMedia(mediaFile.Filename, mediaFile.Header.Get("Content-Type"), mediaData)
+	if !media.IsValid() {
+		return nil, fmt.Errorf("invalid media file: unsupported format")
+	}
+
+	return media, nil
+}
+
+// applyVariants устанавливает кампании варианты сообщения для A/B тестирования
+func (ci *CampaignInteractor) applyVariants(c *campaign.Campaign, req []dto.MessageVariant) error {
+	if len(req) == 0 {
+		return nil
+	}
 
-	for i, status := range statuses {
-		if i%100 == 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
+	variants := make([]*campaign.MessageVariant, 0, len(req))
+	for _, variantReq := range req {
+		var media *campaign.Media
+		if variantReq.MediaFile != nil {
+			loaded, err := ci.loadMedia(variantReq.MediaFile)
+			if err != nil {
+				return fmt.Errorf("variant %s: %w", variantReq.Name, err)
 			}
+			media = loaded
 		}
 
-		if err := ci.campaignRepo.SavePhoneStatus(ctx, status); err != nil {
-			ci.logger.Error("Failed to save campaign status", map[string]interface{}{
-				"error":      err.Error(),
-				"campaignID": status.CampaignID(),
-				"phone":      status.PhoneNumber(),
-			})
-			lastErr = err
-			continue
+		variant, err := campaign.NewMessageVariant(variantReq.Name, variantReq.Message, media, variantReq.Percent)
+		if err != nil {
+			return err
 		}
-		successCount++
+		variants = append(variants, variant)
 	}
 
-	if successCount == 0 && lastErr != nil {
-		return fmt.Errorf("failed to save any campaign statuses: %w", lastErr)
+	return c.SetVariants(variants)
+}
+
+// saveCampaignWithStatuses сохраняет кампанию и статусы ее номеров в одной транзакции.
+// Для кампаний с фильтрацией номера будут сохранены после завершения фильтрации
+func (ci *CampaignInteractor) saveCampaignWithStatuses(ctx context.Context, campaignEntity *campaign.Campaign, result *PhoneProcessingResult) error {
+	var batches iter.Seq[[]*campaign.CampaignPhoneStatus]
+	if campaignEntity.Status() != campaign.CampaignStatusFiltering {
+		batches = ci.phoneStatusBatches(campaignEntity, result.Targets, result.Variables)
 	}
 
-	if successCount < len(statuses) {
-		ci.logger.Warn("Some campaign statuses failed to save", map[string]interface{}{
-			"total":   len(statuses),
-			"success": successCount,
-			"failed":  len(statuses) - successCount,
+	if err := ci.campaignRepo.SaveWithPhoneStatuses(ctx, campaignEntity, batches); err != nil {
+		ci.logger.Error("Failed to save campaign to DB", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignEntity.ID(),
 		})
+		// Кампания не сохранена, медиафайлы в хранилище больше не нужны
+		ci.removeStoredMedia(ctx, campaignEntity)
+		return fmt.Errorf("failed to save campaign: %w", err)
 	}
 
 	return nil
 }
 
+// phoneStatusBatches создает статусы номеров кампании пакетами по PhoneStatusBatchSize: получателей targets
+// с переменными шаблона и назначенными вариантами сообщения, затем отмененные по лимиту частоты номера.
+// Очередной пакет создается только после сохранения предыдущего
+func (ci *CampaignInteractor) phoneStatusBatches(c *campaign.Campaign, targets []*campaign.PhoneNumber, variables map[string]map[string]string) iter.Seq[[]*campaign.CampaignPhoneStatus] {
+	return func(yield func([]*campaign.CampaignPhoneStatus) bool) {
+		assigner := c.VariantAssigner()
+
+		for start := 0; start < len(targets); start += PhoneStatusBatchSize {
+			batch := make([]*campaign.CampaignPhoneStatus, 0, min(PhoneStatusBatchSize, len(targets)-start))
+			for _, phone := range targets[start:min(start+PhoneStatusBatchSize, len(targets))] {
+				status := campaign.NewCampaignStatus(c.ID(), phone.Value())
+				if vars, exists := variables[phone.Value()]; exists {
+					status.SetVariables(vars)
+				}
+				batch = append(batch, status)
+			}
+			assigner.Assign(batch)
+
+			if !yield(batch) {
+				return
+			}
+		}
+
+		capped := c.Audience().Capped
+		if ci.frequencyCap == nil {
+			return
+		}
+		for start := 0; start < len(capped); start += PhoneStatusBatchSize {
+			batch := make([]*campaign.CampaignPhoneStatus, 0, min(PhoneStatusBatchSize, len(capped)-start))
+			for _, phone := range capped[start:min(start+PhoneStatusBatchSize, len(capped))] {
+				status := campaign.NewCampaignStatus(c.ID(), phone.Value())
+				status.CancelWithReason(ci.frequencyCap.ErrorMessage())
+				batch = append(batch, status)
+			}
+
+			if !yield(batch) {
+				return
+			}
+		}
+	}
+}
+
 // buildCreateResponse строит ответ на запрос создания кампании
 func (ci *CampaignInteractor) buildCreateResponse(campaignEntity *campaign.Campaign, result *PhoneProcessingResult) *dto.CreateCampaignResponse {
 	response := &dto.CreateCampaignResponse{
 		Campaign:      campaignEntity,
 		ValidPhones:   result.TotalTargets,
 		InvalidPhones: result.InvalidCount,
+		BlockedPhones: result.BlockedCount,
+		CappedPhones:  len(result.CappedPhones),
 		TotalNumbers:  result.TotalTargets,
 		Warnings:      make([]string, 0),
 	}
 
+	if window := campaignEntity.SendingWindow(); window != nil {
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("Отправка разрешена с %s до %s (%s)", window.Start(), window.End(), window.Timezone()))
+	}
+
+	if variants := campaignEntity.Variants(); len(variants) > 0 {
+		split := make([]string, len(variants))
+		for i, variant := range variants {
+			split[i] = fmt.Sprintf("%s — %d%%", variant.Name(), variant.Percent())
+		}
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("A/B тест: %s", strings.Join(split, ", ")))
+	}
+
+	if scheduledAt := campaignEntity.ScheduledAt(); scheduledAt != nil {
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("Запуск запланирован на %s", scheduledAt.Format(time.RFC3339)))
+	}
+
+	response.Warnings = append(response.Warnings, ci.templateWarnings(campaignEntity, result)...)
+
 	// Если кампания в статусе filtering, показываем информацию о фильтрации
 	if campaignEntity.Status() == campaign.CampaignStatusFiltering {
 		response.TotalNumbers = 0 // Показываем 0, так как фильтрация еще не завершена
@@ -574,12 +827,28 @@ func (ci *CampaignInteractor) buildCreateResponse(campaignEntity *campaign.Campa
 				fmt.Sprintf("Исключено %d номеров", len(result.ExcludePhones)))
 		}
 
+		if result.BlockedCount > 0 {
+			response.Warnings = append(response.Warnings,
+				fmt.Sprintf("Исключено %d номеров из стоп-листа", result.BlockedCount))
+		}
+
+		if len(result.CappedPhones) > 0 {
+			response.Warnings = append(response.Warnings,
+				fmt.Sprintf("Исключено %d номеров по лимиту частоты: не более %d сообщений за %s",
+					len(result.CappedPhones), ci.frequencyCap.MaxMessages(), ci.frequencyCap.WindowLabel()))
+		}
+
 		if result.InvalidCount > 0 {
 			response.Warnings = append(response.Warnings,
 				fmt.Sprintf("Пропущено %d невалидных номеров", result.InvalidCount))
 		}
 	}
 
+	if result.CorrectedCount > 0 {
+		response.Warnings = append(response.Warnings,
+			fmt.Sprintf("Автоматически исправлен формат %d номеров из файла", result.CorrectedCount))
+	}
+
 	ci.logger.Info("Successfully created campaign", map[string]interface{}{
 		"campaignID":   campaignEntity.ID(),
 		"name":         campaignEntity.Name(),
@@ -587,11 +856,61 @@ func (ci *CampaignInteractor) buildCreateResponse(campaignEntity *campaign.Campa
 		"totalNumbers": response.TotalNumbers,
 		"invalidCount": result.InvalidCount,
 		"excludeCount": len(result.ExcludePhones),
+		"blockedCount": result.BlockedCount,
+		"cappedCount":  len(result.CappedPhones),
 	})
 
 	return response
 }
 
+// templateWarnings проверяет, что для всех переменных шаблона есть значения у получателей.
+// Отсутствующая переменная при отправке заменяется пустой строкой, поэтому о ней нужно предупредить заранее
+func (ci *CampaignInteractor) templateWarnings(campaignEntity *campaign.Campaign, result *PhoneProcessingResult) []string {
+	messages := []string{campaignEntity.Message()}
+	for _, variant := range campaignEntity.Variants() {
+		messages = append(messages, variant.Message())
+	}
+
+	placeholders := campaign.TemplateVariables(strings.Join(messages, "\n"))
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	available := make(map[string]struct{}, len(result.VariableColumns))
+	for _, column := range result.VariableColumns {
+		available[column] = struct{}{}
+	}
+
+	recipients := result.Targets
+	if campaignEntity.Status() == campaign.CampaignStatusFiltering {
+		recipients = make([]*campaign.PhoneNumber, 0, len(result.FilePhones)+len(result.AdditionalPhones))
+		recipients = append(recipients, result.FilePhones...)
+		recipients = append(recipients, result.AdditionalPhones...)
+	}
+
+	warnings := make([]string, 0)
+	for _, name := range placeholders {
+		if _, exists := available[name]; !exists {
+			warnings = append(warnings,
+				fmt.Sprintf("Переменная {{%s}} не найдена в колонках файла и будет заменена пустой строкой", name))
+			continue
+		}
+
+		missing := 0
+		for _, phone := range recipients {
+			if result.Variables[phone.Value()][name] == "" {
+				missing++
+			}
+		}
+		if missing > 0 {
+			warnings = append(warnings,
+				fmt.Sprintf("У %d получателей нет значения переменной {{%s}}, она будет заменена пустой строкой", missing, name))
+		}
+	}
+
+	return warnings
+}
+
 // validateCreateRequest проверяет валидность запроса с детальной валидацией
 func (ci *CampaignInteractor) validateCreateRequest(req dto.CreateCampaignRequest) error {
 	if req.Name == "" {
@@ -626,6 +945,16 @@ func (ci *CampaignInteractor) validateCreateRequest(req dto.CreateCampaignReques
 		return ErrTooManyExcludeNumbers
 	}
 
+	if req.ScheduledAt != nil && !req.ScheduledAt.After(time.Now()) {
+		return campaign.ErrScheduledTimeInPast
+	}
+
+	for _, variant := range req.Variants {
+		if len(variant.Message) > MaxMessageLength {
+			return ErrMessageTooLong
+		}
+	}
+
 	return nil
 }
 
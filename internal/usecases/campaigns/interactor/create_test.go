@@ -0,0 +1,98 @@
+package interactor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"whatsapp-service/internal/entities/campaign"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustPhones(t *testing.T, values ...string) []*campaign.PhoneNumber {
+	t.Helper()
+	phones := make([]*campaign.PhoneNumber, len(values))
+	for i, value := range values {
+		phone, err := campaign.RestorePhoneNumber(value)
+		require.NoError(t, err)
+		phones[i] = phone
+	}
+	return phones
+}
+
+func TestSelectPhones(t *testing.T) {
+	phones := mustPhones(t, "79161234567", "79162345678", "79163456789")
+
+	tests := []struct {
+		name   string
+		phones []*campaign.PhoneNumber
+		values []string
+		want   []string
+	}{
+		{name: "keeps audience order", phones: phones, values: []string{"79163456789", "79161234567"}, want: []string{"79161234567", "79163456789"}},
+		{name: "ignores unknown values", phones: phones, values: []string{"79162345678", "79990000000"}, want: []string{"79162345678"}},
+		{name: "duplicate values", phones: phones, values: []string{"79162345678", "79162345678"}, want: []string{"79162345678"}},
+		{name: "no values", phones: phones, values: nil, want: []string{}},
+		{name: "no phones", phones: nil, values: []string{"79161234567"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, phoneValues(selectPhones(tt.phones, tt.values)))
+		})
+	}
+}
+
+func TestWithoutPhones(t *testing.T) {
+	phones := mustPhones(t, "79161234567", "79162345678", "79163456789")
+
+	tests := []struct {
+		name     string
+		phones   []*campaign.PhoneNumber
+		excluded []*campaign.PhoneNumber
+		want     []string
+	}{
+		{name: "keeps audience order", phones: phones, excluded: mustPhones(t, "79162345678"), want: []string{"79161234567", "79163456789"}},
+		{name: "ignores unknown numbers", phones: phones, excluded: mustPhones(t, "79990000000"), want: []string{"79161234567", "79162345678", "79163456789"}},
+		{name: "excludes all", phones: phones, excluded: phones, want: []string{}},
+		{name: "no phones", phones: nil, excluded: phones, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, phoneValues(withoutPhones(tt.phones, tt.excluded)))
+		})
+	}
+}
+
+func TestFilterInBatches(t *testing.T) {
+	values := make([]string, 2*PhoneStatusBatchSize+1)
+	for i := range values {
+		values[i] = fmt.Sprintf("7916%07d", i)
+	}
+
+	var batchSizes []int
+	matched, err := filterInBatches(values, func(batch []string) ([]string, error) {
+		batchSizes = append(batchSizes, len(batch))
+		return batch[:1], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{PhoneStatusBatchSize, PhoneStatusBatchSize, 1}, batchSizes)
+	require.Equal(t, []string{values[0], values[PhoneStatusBatchSize], values[2*PhoneStatusBatchSize]}, matched)
+
+	errFilter := errors.New("filter failed")
+	calls := 0
+	_, err = filterInBatches(values, func(batch []string) ([]string, error) {
+		calls++
+		return nil, errFilter
+	})
+	require.ErrorIs(t, err, errFilter)
+	require.Equal(t, 1, calls)
+
+	matched, err = filterInBatches(nil, func(batch []string) ([]string, error) {
+		t.Fatal("filter must not be called for empty input")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, matched)
+}
@@ -0,0 +1,166 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/interfaces"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+	campaignInterfaces "whatsapp-service/internal/usecases/campaigns/interfaces"
+)
+
+// Константы для обработки подтверждений доставки
+const (
+	MaxDeliveryReceiptsPerRequest = 1000 // Максимальное количество подтверждений в одном webhook
+)
+
+// Кастомные ошибки для обработки подтверждений доставки
+var (
+	ErrTooManyDeliveryReceipts = fmt.Errorf("too many delivery receipts: maximum %d per request", MaxDeliveryReceiptsPerRequest)
+	ErrProcessDeliveryReceipt  = fmt.Errorf("failed to process delivery receipt")
+)
+
+// CampaignDeliveryInteractor продвигает статусы номеров кампаний по подтверждениям доставки и прочтения
+type CampaignDeliveryInteractor struct {
+	campaignRepo repository.CampaignRepository
+	statsUseCase campaignInterfaces.CampaignStatsUseCase
+	logger       interfaces.Logger
+}
+
+// NewCampaignDeliveryInteractor создает новый экземпляр use case подтверждений доставки
+func NewCampaignDeliveryInteractor(
+	campaignRepo repository.CampaignRepository,
+	statsUseCase campaignInterfaces.CampaignStatsUseCase,
+	logger interfaces.Logger,
+) *CampaignDeliveryInteractor {
+	return &CampaignDeliveryInteractor{
+		campaignRepo: campaignRepo,
+		statsUseCase: statsUseCase,
+		logger:       logger,
+	}
+}
+
+// ProcessReceipts обрабатывает пакет подтверждений доставки.
+// Статус номера только продвигается вперед (sent → delivered → read), поэтому повторные
+// и пришедшие не по порядку подтверждения не меняют данные и не увеличивают счетчики
+func (di *CampaignDeliveryInteractor) ProcessReceipts(ctx context.Context, req dto.ProcessDeliveryReceiptsRequest) (*dto.ProcessDeliveryReceiptsResponse, error) {
+	if len(req.Receipts) > MaxDeliveryReceiptsPerRequest {
+		return nil, ErrTooManyDeliveryReceipts
+	}
+
+	response := &dto.ProcessDeliveryReceiptsResponse{}
+
+	for _, receipt := range req.Receipts {
+		if receipt.MessageID == "" || (receipt.Status != campaign.CampaignStatusTypeDelivered && receipt.Status != campaign.CampaignStatusTypeRead) {
+			response.Ignored++
+			continue
+		}
+
+		status, err := di.campaignRepo.GetPhoneStatusByMessageID(ctx, receipt.MessageID)
+		if err != nil {
+			if errors.Is(err, campaign.ErrPhoneNumberNotFound) {
+				response.NotFound++
+				continue
+			}
+			di.logger.Error("Failed to get phone status by message ID", map[string]interface{}{
+				"error":     err.Error(),
+				"messageID": receipt.MessageID,
+			})
+			return nil, fmt.Errorf("%w: %s", ErrProcessDeliveryReceipt, err.Error())
+		}
+
+		updated, err := di.applyReceipt(ctx, status, receipt)
+		if err != nil {
+			return nil, err
+		}
+
+		if updated {
+			response.Updated++
+		} else {
+			response.Ignored++
+		}
+	}
+
+	di.logger.Info("Delivery receipts processed", map[string]interface{}{
+		"received": len(req.Receipts),
+		"updated":  response.Updated,
+		"ignored":  response.Ignored,
+		"notFound": response.NotFound,
+	})
+
+	return response, nil
+}
+
+// applyReceipt применяет подтверждение к статусу номера и обновляет счетчики кампании.
+// Возвращает false, если статус не изменился
+func (di *CampaignDeliveryInteractor) applyReceipt(ctx context.Context, status *campaign.CampaignPhoneStatus, receipt dto.DeliveryReceipt) (bool, error) {
+	at := receipt.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	previousStatus := status.Status()
+	wasDelivered := status.DeliveredAt() != nil
+
+	var changed bool
+	if receipt.Status == campaign.CampaignStatusTypeRead {
+		changed = status.MarkAsRead(at)
+	} else {
+		changed = status.MarkAsDelivered(at)
+	}
+	if !changed {
+		return false, nil
+	}
+
+	updated, err := di.campaignRepo.UpdatePhoneDeliveryStatus(ctx, status, previousStatus)
+	if err != nil {
+		di.logger.Error("Failed to update phone delivery status", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": status.CampaignID(),
+			"messageID":  receipt.MessageID,
+			"status":     string(status.Status()),
+		})
+		return false, fmt.Errorf("%w: %s", ErrProcessDeliveryReceipt, err.Error())
+	}
+	if !updated {
+		// Статус успел измениться параллельным подтверждением — оно и учтет счетчики
+		return false, nil
+	}
+
+	if !wasDelivered {
+		di.incrementDeliveryCounter(ctx, status.CampaignID(), campaign.StatsCounterDelivered, at)
+	}
+	if status.Status() == campaign.CampaignStatusTypeRead {
+		di.incrementDeliveryCounter(ctx, status.CampaignID(), campaign.StatsCounterRead, at)
+	}
+
+	return true, nil
+}
+
+// incrementDeliveryCounter увеличивает счетчик кампании и дневную статистику.
+// Ошибки только логируются: статус номера уже сохранен
+func (di *CampaignDeliveryInteractor) incrementDeliveryCounter(ctx context.Context, campaignID string, counter campaign.StatsCounter, at time.Time) {
+	var err error
+	if counter == campaign.StatsCounterRead {
+		err = di.campaignRepo.IncrementReadCount(ctx, campaignID)
+	} else {
+		err = di.campaignRepo.IncrementDeliveredCount(ctx, campaignID)
+	}
+	if err != nil {
+		di.logger.Error("Failed to increment campaign delivery counter", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+			"counter":    string(counter),
+		})
+	}
+
+	// Ошибка статистики уже залогирована в use case
+	_ = di.statsUseCase.Record(ctx, dto.RecordStatsRequest{
+		CampaignID: campaignID,
+		Counter:    counter,
+		At:         at,
+	})
+}
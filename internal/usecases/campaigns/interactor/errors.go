@@ -0,0 +1,143 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для разбора ошибок кампании
+const (
+	DefaultErrorsLimit        = 100  // Количество номеров на странице по умолчанию
+	MaxErrorsLimit            = 1000 // Максимальное количество номеров на странице
+	MaxErrorsCampaignIDLength = 36   // UUID length
+)
+
+// Кастомные ошибки для разбора ошибок кампании
+var (
+	ErrErrorsCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrErrorsCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxErrorsCampaignIDLength)
+	ErrInvalidErrorsPagination  = fmt.Errorf("limit and offset must not be negative")
+	ErrGetCampaignErrors        = fmt.Errorf("failed to get campaign errors")
+)
+
+// GetErrors возвращает неудачные номера кампании, сгруппированные по нормализованной причине ошибки,
+// и страницу самих номеров (с фильтром по причине, если он указан)
+func (ci *CampaignInteractor) GetErrors(ctx context.Context, req dto.GetCampaignErrorsRequest) (*dto.GetCampaignErrorsResponse, error) {
+	limit, err := ci.validateErrorsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ошибка возвращается без обертки, чтобы отсутствие кампании отдавалось как 404
+	if _, err := ci.campaignRepo.GetByID(ctx, req.CampaignID); err != nil {
+		ci.logger.Error("campaign interactor GetErrors: failed to get campaign", "campaign_id", req.CampaignID, "error", err)
+		return nil, err
+	}
+
+	failed, err := ci.campaignRepo.GetFailedPhoneStatuses(ctx, req.CampaignID)
+	if err != nil {
+		ci.logger.Error("campaign interactor GetErrors: failed to get failed statuses", "campaign_id", req.CampaignID, "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrGetCampaignErrors, err.Error())
+	}
+
+	response := ci.buildErrorsResponse(req, failed, limit)
+
+	ci.logger.Debug("campaign interactor GetErrors completed successfully",
+		"campaign_id", req.CampaignID, "total_failed", response.TotalFailed, "groups", len(response.Groups))
+	return response, nil
+}
+
+// validateErrorsRequest проверяет запрос и возвращает лимит с примененным значением по умолчанию
+func (ci *CampaignInteractor) validateErrorsRequest(req dto.GetCampaignErrorsRequest) (int, error) {
+	if req.CampaignID == "" {
+		return 0, ErrErrorsCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxErrorsCampaignIDLength {
+		return 0, ErrErrorsCampaignIDTooLong
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return 0, ErrInvalidErrorsPagination
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultErrorsLimit
+	}
+	if limit > MaxErrorsLimit {
+		limit = MaxErrorsLimit
+	}
+	return limit, nil
+}
+
+// buildErrorsResponse группирует неудачные номера по причине и формирует страницу номеров
+func (ci *CampaignInteractor) buildErrorsResponse(req dto.GetCampaignErrorsRequest, failed []*campaign.CampaignPhoneStatus, limit int) *dto.GetCampaignErrorsResponse {
+	response := &dto.GetCampaignErrorsResponse{
+		CampaignID:  req.CampaignID,
+		TotalFailed: len(failed),
+		Groups:      make([]dto.CampaignErrorGroup, 0),
+		Errors:      make([]dto.FailedNumber, 0),
+		Limit:       limit,
+		Offset:      req.Offset,
+	}
+
+	reasonFilter := strings.TrimSpace(req.Reason)
+	groupIndex := make(map[string]int)
+	var matched []dto.FailedNumber
+
+	for _, status := range failed {
+		item := dto.FailedNumber{
+			PhoneNumber: status.PhoneNumber(),
+			Error:       status.ErrorMessage(),
+			Reason:      campaign.NormalizeSendError(status.ErrorMessage()),
+			Retryable:   campaign.IsRetryableSendError(status.ErrorMessage()),
+			Variant:     status.Variant(),
+		}
+
+		if item.Retryable {
+			response.RetryableCount++
+		}
+
+		// Признак повтора определяется по исходному тексту ошибки, а нормализация может отбросить
+		// его фрагмент (например, тело ответа сервера), поэтому номера одной группы считаются по отдельности
+		idx, exists := groupIndex[item.Reason]
+		if !exists {
+			idx = len(response.Groups)
+			groupIndex[item.Reason] = idx
+			response.Groups = append(response.Groups, dto.CampaignErrorGroup{
+				Reason:  item.Reason,
+				Example: item.Error,
+			})
+		}
+		response.Groups[idx].Count++
+		if item.Retryable {
+			response.Groups[idx].RetryableCount++
+			response.Groups[idx].Retryable = true
+		}
+
+		if reasonFilter == "" || item.Reason == reasonFilter {
+			matched = append(matched, item)
+		}
+	}
+
+	sort.SliceStable(response.Groups, func(i, j int) bool {
+		if response.Groups[i].Count != response.Groups[j].Count {
+			return response.Groups[i].Count > response.Groups[j].Count
+		}
+		return response.Groups[i].Reason < response.Groups[j].Reason
+	})
+
+	response.Total = len(matched)
+	if req.Offset < len(matched) {
+		end := req.Offset + limit
+		if end > len(matched) {
+			end = len(matched)
+		}
+		response.Errors = matched[req.Offset:end]
+	}
+
+	return response
+}
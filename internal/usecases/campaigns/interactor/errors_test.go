@@ -0,0 +1,52 @@
+package interactor
+
+import (
+	"testing"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func failedStatus(phoneNumber, errorMsg string) *campaign.CampaignPhoneStatus {
+	return campaign.RestoreCampaignStatus("", "campaign-1", phoneNumber, campaign.CampaignStatusTypeFailed, errorMsg, nil, time.Now())
+}
+
+func TestBuildErrorsResponse_GroupRetryable(t *testing.T) {
+	failed := []*campaign.CampaignPhoneStatus{
+		// Нормализация отбрасывает тело ответа, поэтому обе ошибки попадают в одну группу
+		failedStatus("79162345678", "request failed: status 502 - invalid recipient"),
+		failedStatus("79161234567", "request failed: status 502 - server error: bad gateway"),
+		failedStatus("79163456789", "request failed: status 502 - invalid recipient"),
+		failedStatus("79164567890", "attempt 1 failed: timeout"),
+		failedStatus("79165678901", "number is not registered in whatsapp"),
+	}
+
+	response := (&CampaignInteractor{}).buildErrorsResponse(dto.GetCampaignErrorsRequest{CampaignID: "campaign-1"}, failed, 10)
+
+	require.Equal(t, 5, response.TotalFailed)
+	require.Equal(t, 2, response.RetryableCount)
+
+	groups := make(map[string]dto.CampaignErrorGroup, len(response.Groups))
+	for _, group := range response.Groups {
+		groups[group.Reason] = group
+	}
+	require.Len(t, groups, 3)
+
+	mixed := groups["request failed: status 502"]
+	require.Equal(t, 3, mixed.Count)
+	require.Equal(t, 1, mixed.RetryableCount)
+	require.True(t, mixed.Retryable)
+
+	timeout := groups["timeout"]
+	require.Equal(t, 1, timeout.Count)
+	require.Equal(t, 1, timeout.RetryableCount)
+	require.True(t, timeout.Retryable)
+
+	notRegistered := groups["number is not registered in whatsapp"]
+	require.Equal(t, 0, notRegistered.RetryableCount)
+	require.False(t, notRegistered.Retryable)
+
+	require.Equal(t, "request failed: status 502", response.Groups[0].Reason)
+}
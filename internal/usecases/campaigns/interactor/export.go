@@ -0,0 +1,172 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+)
+
+// Константы для выгрузки результатов
+const (
+	ExportBatchSize           = 1000 // Количество номеров, читаемых из БД за один запрос
+	MaxExportCampaignIDLength = 36   // UUID length
+	exportTimeLayout          = "2006-01-02 15:04:05"
+)
+
+// Кастомные ошибки для выгрузки результатов
+var (
+	ErrExportCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrExportCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxExportCampaignIDLength)
+	ErrInvalidExportFormat      = fmt.Errorf("invalid export format: expected xlsx or csv")
+	ErrInvalidExportStatus      = fmt.Errorf("invalid status filter: expected pending, sent, delivered, read, failed or cancelled")
+	ErrExportCampaign           = fmt.Errorf("failed to export campaign results")
+)
+
+// exportHeader заголовок таблицы выгрузки
+var exportHeader = []string{"Телефон", "Статус", "Ошибка", "Отправлено", "Доставлено", "Прочитано"}
+
+// Export выгружает номера кампании с результатами отправки в w.
+// Номера читаются из БД страницами по ExportBatchSize, поэтому память не зависит от размера кампании
+func (ci *CampaignInteractor) Export(ctx context.Context, req dto.ExportCampaignRequest, w io.Writer) (*dto.ExportCampaignResponse, error) {
+	statuses, err := ci.validateExportRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ошибка возвращается без обертки, чтобы отсутствие кампании отдавалось как 404
+	if _, err := ci.campaignRepo.GetByID(ctx, req.CampaignID); err != nil {
+		ci.logger.Error("campaign interactor Export: failed to get campaign", "campaign_id", req.CampaignID, "error", err)
+		return nil, err
+	}
+
+	rows, err := ci.writeExport(ctx, req, statuses, w)
+	if err != nil {
+		ci.logger.Error("campaign interactor Export: failed to write export",
+			"campaign_id", req.CampaignID, "format", req.Format, "rows", rows, "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrExportCampaign, err.Error())
+	}
+
+	ci.logger.Info("campaign interactor Export completed successfully",
+		"campaign_id", req.CampaignID, "format", req.Format, "status", req.Status, "rows", rows)
+
+	return &dto.ExportCampaignResponse{
+		CampaignID: req.CampaignID,
+		Format:     req.Format,
+		Rows:       rows,
+	}, nil
+}
+
+// validateExportRequest проверяет запрос и возвращает фильтр по статусам номеров
+func (ci *CampaignInteractor) validateExportRequest(req dto.ExportCampaignRequest) ([]campaign.CampaignStatusType, error) {
+	if req.CampaignID == "" {
+		return nil, ErrExportCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxExportCampaignIDLength {
+		return nil, ErrExportCampaignIDTooLong
+	}
+	if req.Format != ports.ExportFormatXLSX && req.Format != ports.ExportFormatCSV {
+		return nil, ErrInvalidExportFormat
+	}
+
+	statuses, ok := phoneStatusFilter(req.Status)
+	if !ok {
+		return nil, ErrInvalidExportStatus
+	}
+	return statuses, nil
+}
+
+// writeExport постранично читает номера кампании и пишет их в файл выгрузки.
+// Возвращает количество записанных номеров
+func (ci *CampaignInteractor) writeExport(ctx context.Context, req dto.ExportCampaignRequest, statuses []campaign.CampaignStatusType, w io.Writer) (int, error) {
+	writer, err := ci.exporter.NewWriter(w, req.Format)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := ci.writeExportRows(ctx, req.CampaignID, statuses, writer)
+	if err != nil {
+		writer.Abort()
+		return rows, err
+	}
+
+	return rows, writer.Close()
+}
+
+// writeExportRows пишет заголовок и все номера кампании, подходящие под фильтр
+func (ci *CampaignInteractor) writeExportRows(ctx context.Context, campaignID string, statuses []campaign.CampaignStatusType, writer ports.ResultsWriter) (int, error) {
+	if err := writer.WriteRow(exportHeader); err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	page := repository.PhoneStatusPage{
+		PhoneStatusFilter: repository.PhoneStatusFilter{Statuses: statuses},
+		Limit:             ExportBatchSize,
+	}
+	for {
+		batch, err := ci.campaignRepo.ListPhoneStatusesPage(ctx, campaignID, page)
+		if err != nil {
+			return rows, err
+		}
+
+		for _, status := range batch {
+			if err := writer.WriteRow(exportRow(status)); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+
+		if len(batch) < ExportBatchSize {
+			break
+		}
+		page.AfterID = batch[len(batch)-1].ID()
+	}
+
+	return rows, nil
+}
+
+// phoneStatusFilter возвращает статусы номеров для фильтра status ("sent" включает delivered и read).
+// Пустой фильтр означает все статусы; ok = false для неизвестного статуса
+func phoneStatusFilter(status string) (statuses []campaign.CampaignStatusType, ok bool) {
+	switch statusType := campaign.CampaignStatusType(status); statusType {
+	case "":
+		return nil, true
+	case campaign.CampaignStatusTypeSent:
+		// Доставленные и прочитанные сообщения тоже отправлены
+		return []campaign.CampaignStatusType{
+			campaign.CampaignStatusTypeSent,
+			campaign.CampaignStatusTypeDelivered,
+			campaign.CampaignStatusTypeRead,
+		}, true
+	case campaign.CampaignStatusTypePending, campaign.CampaignStatusTypeDelivered, campaign.CampaignStatusTypeRead,
+		campaign.CampaignStatusTypeFailed, campaign.CampaignStatusTypeCancelled:
+		return []campaign.CampaignStatusType{statusType}, true
+	default:
+		return nil, false
+	}
+}
+
+// exportRow формирует строку выгрузки для номера
+func exportRow(status *campaign.CampaignPhoneStatus) []string {
+	return []string{
+		status.PhoneNumber(),
+		string(status.Status()),
+		status.ErrorMessage(),
+		formatExportTime(status.SentAt()),
+		formatExportTime(status.DeliveredAt()),
+		formatExportTime(status.ReadAt()),
+	}
+}
+
+// formatExportTime форматирует необязательное время для выгрузки
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(exportTimeLayout)
+}
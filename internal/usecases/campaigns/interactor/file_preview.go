@@ -0,0 +1,74 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+	infraDTO "whatsapp-service/internal/usecases/dto"
+)
+
+// Кастомные ошибки для анализа файла с номерами
+var (
+	ErrPreviewFileRequired = fmt.Errorf("phone file is required")
+	ErrPreviewPhoneFile    = fmt.Errorf("failed to analyze phone file")
+)
+
+// PreviewPhoneFile анализирует файл с номерами без создания кампании, чтобы пользователь
+// мог выбрать лист и колонку до загрузки
+func (ci *CampaignInteractor) PreviewPhoneFile(ctx context.Context, req dto.PreviewPhoneFileRequest) (*infraDTO.FileAnalysis, error) {
+	if req.File == nil {
+		return nil, ErrPreviewFileRequired
+	}
+	if err := ci.checkPhoneFileSize(req.File); err != nil {
+		return nil, err
+	}
+
+	parser, err := ci.fileParsers.ForFile(req.File.Filename, req.File.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := req.File.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrPreviewPhoneFile, err.Error())
+	}
+	defer f.Close()
+
+	analysis, err := parser.Analyze(f, req.Sheet)
+	if err != nil {
+		if isPhoneFileError(err) {
+			return nil, err
+		}
+		ci.logger.Warn("campaign interactor PreviewPhoneFile: failed to analyze file",
+			"filename", req.File.Filename, "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrPreviewPhoneFile, err.Error())
+	}
+	analysis.Filename = req.File.Filename
+
+	ci.logger.Debug("campaign interactor PreviewPhoneFile completed",
+		"filename", req.File.Filename,
+		"sheet", analysis.Sheet,
+		"suggested_column", analysis.SuggestedColumn,
+		"estimated_phones", analysis.EstimatedPhones,
+	)
+
+	return analysis, nil
+}
+
+// isPhoneFileError проверяет, что ошибка — ошибка валидации файла с номерами, которая
+// отдается клиенту как есть (вместе с подробностями, например лимитом строк)
+func isPhoneFileError(err error) bool {
+	for _, target := range []error{
+		campaign.ErrUnsupportedPhoneFile,
+		campaign.ErrPhoneFileSheetNotFound,
+		campaign.ErrPhoneFileTooLarge,
+		campaign.ErrPhoneFileTooManyRows,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package interactor
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+
+	"github.com/google/uuid"
+)
+
+// Кастомные ошибки для хранения медиафайлов
+var (
+	ErrStoreMedia         = fmt.Errorf("failed to store media file")
+	ErrMediaChecksumMatch = fmt.Errorf("media file checksum mismatch")
+)
+
+// storeMedia сохраняет медиафайлы кампании и ее вариантов в хранилище.
+// В БД остаются только метаданные и путь к файлу
+func (ci *CampaignInteractor) storeMedia(ctx context.Context, c *campaign.Campaign) error {
+	for _, media := range campaignMedia(c) {
+		key := mediaStorageKey(c.ID(), media.Filename())
+		if err := ci.mediaStorage.Put(ctx, key, media.Data(), media.MimeType()); err != nil {
+			ci.logger.Error("campaign interactor: failed to store media file",
+				"campaign_id", c.ID(),
+				"filename", media.Filename(),
+				"error", err,
+			)
+			ci.removeStoredMedia(ctx, c)
+			return fmt.Errorf("%w: %s", ErrStoreMedia, err.Error())
+		}
+		media.SetStoragePath(key)
+	}
+	return nil
+}
+
+// removeStoredMedia удаляет из хранилища уже сохраненные медиафайлы кампании
+func (ci *CampaignInteractor) removeStoredMedia(ctx context.Context, c *campaign.Campaign) {
+	for _, media := range campaignMedia(c) {
+		if media.StoragePath() == "" {
+			continue
+		}
+		if err := ci.mediaStorage.Delete(ctx, media.StoragePath()); err != nil {
+			ci.logger.Warn("campaign interactor: failed to remove stored media file",
+				"campaign_id", c.ID(),
+				"storage_path", media.StoragePath(),
+				"error", err,
+			)
+		}
+	}
+}
+
+// loadStoredMedia читает медиафайл из хранилища и сверяет контрольную сумму
+func (ci *CampaignInteractor) loadStoredMedia(ctx context.Context, storagePath, checksumMD5 string) ([]byte, error) {
+	data, err := ci.mediaStorage.Get(ctx, storagePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if checksumMD5 != "" {
+		sum := md5.Sum(data)
+		if hex.EncodeToString(sum[:]) != checksumMD5 {
+			return nil, fmt.Errorf("%w: %s", ErrMediaChecksumMatch, storagePath)
+		}
+	}
+
+	return data, nil
+}
+
+// campaignMedia возвращает медиафайлы кампании и ее вариантов
+func campaignMedia(c *campaign.Campaign) []*campaign.Media {
+	media := make([]*campaign.Media, 0, len(c.Variants())+1)
+	if c.Media() != nil {
+		media = append(media, c.Media())
+	}
+	for _, variant := range c.Variants() {
+		if variant.Media() != nil {
+			media = append(media, variant.Media())
+		}
+	}
+	return media
+}
+
+// mediaStorageKey формирует ключ файла в хранилище: campaigns/<id кампании>/<uuid><расширение>
+func mediaStorageKey(campaignID, filename string) string {
+	return path.Join("campaigns", campaignID, uuid.NewString()+strings.ToLower(filepath.Ext(filename)))
+}
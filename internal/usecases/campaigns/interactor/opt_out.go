@@ -0,0 +1,296 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/interfaces"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/ports"
+	infraDTO "whatsapp-service/internal/usecases/dto"
+)
+
+// Константы для стоп-листа
+const (
+	DefaultOptOutsLimit        = 100      // Размер страницы по умолчанию
+	MaxOptOutsLimit            = 1000     // Максимальный размер страницы
+	MaxOptOutReasonLength      = 500      // Максимальная длина причины
+	MaxOptOutsSearchLength     = 20       // Максимальная длина строки поиска по номеру
+	MaxOptOutImportNumbers     = 1000     // Максимальное количество номеров списком в одном запросе
+	OptOutImportBatchSize      = 1000     // Количество номеров, добавляемых одним запросом к БД
+	MaxOptOutImportFileNumbers = 50000    // Максимальное количество строк с номерами в файле
+	MaxOptOutImportFileSize    = 10 << 20 // Максимальный размер файла с номерами (10 МБ)
+)
+
+// Кастомные ошибки для стоп-листа
+var (
+	ErrOptOutReasonTooLong     = fmt.Errorf("reason too long: maximum %d characters", MaxOptOutReasonLength)
+	ErrInvalidOptOutsSearch    = fmt.Errorf("search must be a part of phone number: up to %d digits", MaxOptOutsSearchLength)
+	ErrInvalidOptOutPagination = fmt.Errorf("limit and offset must not be negative")
+	ErrNoOptOutNumbers         = fmt.Errorf("file or phone numbers are required")
+	ErrTooManyOptOutNumbers    = fmt.Errorf("too many phone numbers: maximum %d", MaxOptOutImportNumbers)
+	ErrParseOptOutFile         = fmt.Errorf("failed to parse opt-out file")
+	ErrAddOptOut               = fmt.Errorf("failed to add phone number to opt-out list")
+	ErrRemoveOptOut            = fmt.Errorf("failed to remove phone number from opt-out list")
+	ErrListOptOuts             = fmt.Errorf("failed to list opt-out list")
+)
+
+// OptOutOptions содержит настройки автоматической отписки по входящим сообщениям
+type OptOutOptions struct {
+	Keywords            []string // Стоп-слова, ответ которыми отписывает получателя
+	ConfirmationMessage string   // Подтверждение отписки (пустая строка = не отправлять)
+}
+
+// OptOutInteractor управляет глобальным стоп-листом номеров.
+// Номера стоп-листа исключаются из всех кампаний при создании и запуске
+type OptOutInteractor struct {
+	optOutRepo     repository.OptOutRepository
+	campaignRepo   repository.CampaignRepository
+	dispatcher     ports.Dispatcher
+	messageGateway interfaces.MessageGateway
+	fileParsers    ports.FileParserSelector
+	phones         *campaign.PhoneNormalizer
+	options        OptOutOptions
+	logger         interfaces.Logger
+}
+
+// NewOptOutInteractor создает новый экземпляр use case стоп-листа
+func NewOptOutInteractor(
+	optOutRepo repository.OptOutRepository,
+	campaignRepo repository.CampaignRepository,
+	dispatcher ports.Dispatcher,
+	messageGateway interfaces.MessageGateway,
+	fileParsers ports.FileParserSelector,
+	phones *campaign.PhoneNormalizer,
+	options OptOutOptions,
+	logger interfaces.Logger,
+) *OptOutInteractor {
+	return &OptOutInteractor{
+		optOutRepo:     optOutRepo,
+		campaignRepo:   campaignRepo,
+		dispatcher:     dispatcher,
+		messageGateway: messageGateway,
+		fileParsers:    fileParsers,
+		phones:         phones,
+		options:        options,
+		logger:         logger,
+	}
+}
+
+// Add добавляет номер в стоп-лист вручную. Повторное добавление не меняет существующую запись
+func (oi *OptOutInteractor) Add(ctx context.Context, req dto.AddOptOutRequest) (*dto.AddOptOutResponse, error) {
+	if len(req.Reason) > MaxOptOutReasonLength {
+		return nil, ErrOptOutReasonTooLong
+	}
+
+	phone, err := oi.phones.NewPhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return nil, campaign.ErrInvalidPhoneNumber
+	}
+
+	optOut := campaign.NewOptOut(phone, strings.TrimSpace(req.Reason), campaign.OptOutSourceManual)
+	added, err := oi.optOutRepo.Add(ctx, optOut)
+	if err != nil {
+		oi.logger.Error("opt-out interactor Add: failed to add phone number", "phone_number", phone.Value(), "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrAddOptOut, err.Error())
+	}
+
+	oi.logger.Info("opt-out interactor Add completed successfully", "phone_number", phone.Value(), "added", added)
+	return &dto.AddOptOutResponse{
+		OptOut: mapOptOut(optOut),
+		Added:  added,
+	}, nil
+}
+
+// Remove удаляет номер из стоп-листа
+func (oi *OptOutInteractor) Remove(ctx context.Context, req dto.RemoveOptOutRequest) error {
+	phone, err := oi.phones.NewPhoneNumber(req.PhoneNumber)
+	if err != nil {
+		return campaign.ErrInvalidPhoneNumber
+	}
+
+	if err := oi.optOutRepo.Delete(ctx, phone.Value()); err != nil {
+		// Ошибка возвращается без обертки, чтобы отсутствие номера отдавалось как 404
+		if err == campaign.ErrOptOutNotFound {
+			return err
+		}
+		oi.logger.Error("opt-out interactor Remove: failed to remove phone number", "phone_number", phone.Value(), "error", err)
+		return fmt.Errorf("%w: %s", ErrRemoveOptOut, err.Error())
+	}
+
+	oi.logger.Info("opt-out interactor Remove completed successfully", "phone_number", phone.Value())
+	return nil
+}
+
+// List возвращает страницу стоп-листа от новых записей к старым
+func (oi *OptOutInteractor) List(ctx context.Context, req dto.ListOptOutsRequest) (*dto.ListOptOutsResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, ErrInvalidOptOutPagination
+	}
+
+	search := strings.TrimPrefix(strings.TrimSpace(req.Search), "+")
+	if len(search) > MaxOptOutsSearchLength || strings.Trim(search, "0123456789") != "" {
+		return nil, ErrInvalidOptOutsSearch
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultOptOutsLimit
+	}
+	if limit > MaxOptOutsLimit {
+		limit = MaxOptOutsLimit
+	}
+
+	optOuts, err := oi.optOutRepo.List(ctx, search, limit, req.Offset)
+	if err != nil {
+		oi.logger.Error("opt-out interactor List: failed to list opt-outs", "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrListOptOuts, err.Error())
+	}
+
+	total, err := oi.optOutRepo.Count(ctx, search)
+	if err != nil {
+		oi.logger.Error("opt-out interactor List: failed to count opt-outs", "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrListOptOuts, err.Error())
+	}
+
+	response := &dto.ListOptOutsResponse{
+		OptOuts: make([]dto.OptOut, len(optOuts)),
+		Total:   total,
+		Limit:   limit,
+		Offset:  req.Offset,
+	}
+	for i, optOut := range optOuts {
+		response.OptOuts[i] = mapOptOut(optOut)
+	}
+
+	return response, nil
+}
+
+// Import загружает номера в стоп-лист из Excel файла и/или списка строк.
+// Уже находящиеся в стоп-листе номера не изменяются
+func (oi *OptOutInteractor) Import(ctx context.Context, req dto.ImportOptOutsRequest) (*dto.ImportOptOutsResponse, error) {
+	if err := oi.validateImportRequest(req); err != nil {
+		return nil, err
+	}
+
+	phones, invalid, err := oi.collectImportPhones(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	optOuts := make([]*campaign.OptOut, len(phones))
+	for i, phone := range phones {
+		optOuts[i] = campaign.NewOptOut(phone, reason, campaign.OptOutSourceImport)
+	}
+
+	added := 0
+	for start := 0; start < len(optOuts); start += OptOutImportBatchSize {
+		end := start + OptOutImportBatchSize
+		if end > len(optOuts) {
+			end = len(optOuts)
+		}
+
+		inserted, err := oi.optOutRepo.AddBatch(ctx, optOuts[start:end])
+		if err != nil {
+			oi.logger.Error("opt-out interactor Import: failed to add batch", "added", added, "error", err)
+			return nil, fmt.Errorf("%w: %s", ErrAddOptOut, err.Error())
+		}
+		added += inserted
+	}
+
+	response := &dto.ImportOptOutsResponse{
+		Total:          len(phones),
+		Added:          added,
+		AlreadyPresent: len(phones) - added,
+		Invalid:        invalid,
+	}
+
+	oi.logger.Info("opt-out interactor Import completed successfully",
+		"total", response.Total, "added", response.Added, "invalid", response.Invalid)
+	return response, nil
+}
+
+// validateImportRequest проверяет запрос загрузки номеров
+func (oi *OptOutInteractor) validateImportRequest(req dto.ImportOptOutsRequest) error {
+	if req.File == nil && len(req.PhoneNumbers) == 0 {
+		return ErrNoOptOutNumbers
+	}
+	if len(req.PhoneNumbers) > MaxOptOutImportNumbers {
+		return ErrTooManyOptOutNumbers
+	}
+	if len(req.Reason) > MaxOptOutReasonLength {
+		return ErrOptOutReasonTooLong
+	}
+	return nil
+}
+
+// collectImportPhones собирает уникальные валидные номера из файла и списка.
+// Возвращает номера и количество невалидных значений
+func (oi *OptOutInteractor) collectImportPhones(req dto.ImportOptOutsRequest) ([]*campaign.PhoneNumber, int, error) {
+	var phones []*campaign.PhoneNumber
+	invalid := 0
+
+	if req.File != nil {
+		if req.File.Size > MaxOptOutImportFileSize {
+			return nil, 0, campaign.ErrPhoneFileTooLarge
+		}
+
+		parser, err := oi.fileParsers.ForFile(req.File.Filename, req.File.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, 0, err
+		}
+
+		f, err := req.File.Open()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to open opt-out file: %w", err)
+		}
+		defer f.Close()
+
+		parseResult, err := parser.ParsePhoneNumbersWithOptions(f, infraDTO.ParseOptions{MaxRows: MaxOptOutImportFileNumbers})
+		if err != nil {
+			if isPhoneFileError(err) {
+				return nil, 0, err
+			}
+			return nil, 0, fmt.Errorf("%w: %s", ErrParseOptOutFile, err.Error())
+		}
+
+		for i := range parseResult.ValidPhones {
+			phones = append(phones, &parseResult.ValidPhones[i])
+		}
+		invalid += parseResult.Statistics.InvalidCount
+	}
+
+	for _, value := range req.PhoneNumbers {
+		phone, err := oi.phones.NewPhoneNumber(value)
+		if err != nil {
+			invalid++
+			continue
+		}
+		phones = append(phones, phone)
+	}
+
+	seen := make(map[string]struct{}, len(phones))
+	unique := make([]*campaign.PhoneNumber, 0, len(phones))
+	for _, phone := range phones {
+		if _, exists := seen[phone.Value()]; exists {
+			continue
+		}
+		seen[phone.Value()] = struct{}{}
+		unique = append(unique, phone)
+	}
+
+	return unique, invalid, nil
+}
+
+// mapOptOut преобразует запись стоп-листа в DTO
+func mapOptOut(optOut *campaign.OptOut) dto.OptOut {
+	return dto.OptOut{
+		PhoneNumber: optOut.PhoneNumber,
+		Reason:      optOut.Reason,
+		Source:      string(optOut.Source),
+		CreatedAt:   optOut.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
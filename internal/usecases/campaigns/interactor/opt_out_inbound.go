@@ -0,0 +1,112 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для обработки входящих сообщений
+const (
+	MaxInboundMessagesPerRequest = 1000 // Максимальное количество входящих сообщений в одном webhook
+	maxInboundReasonLength       = 100  // Сколько символов текста сообщения сохраняется как причина отписки
+)
+
+// Кастомные ошибки для обработки входящих сообщений
+var (
+	ErrTooManyInboundMessages = fmt.Errorf("too many inbound messages: maximum %d per request", MaxInboundMessagesPerRequest)
+	ErrProcessInboundMessage  = fmt.Errorf("failed to process inbound message")
+)
+
+// ProcessInbound обрабатывает входящие сообщения получателей.
+// Ответ стоп-словом добавляет отправителя в стоп-лист, отменяет его ожидающие номера во всех кампаниях
+// и убирает уже поставленные в очередь сообщения. Остальные сообщения игнорируются
+func (oi *OptOutInteractor) ProcessInbound(ctx context.Context, req dto.ProcessInboundMessagesRequest) (*dto.ProcessInboundMessagesResponse, error) {
+	if len(req.Messages) > MaxInboundMessagesPerRequest {
+		return nil, ErrTooManyInboundMessages
+	}
+
+	response := &dto.ProcessInboundMessagesResponse{}
+
+	for _, message := range req.Messages {
+		if !campaign.IsOptOutKeyword(message.Text, oi.options.Keywords) {
+			response.Ignored++
+			continue
+		}
+
+		phone, err := oi.phones.NewPhoneNumber(message.From)
+		if err != nil {
+			oi.logger.Warn("opt-out interactor ProcessInbound: invalid sender phone number", "from", message.From)
+			response.Ignored++
+			continue
+		}
+
+		cancelled, err := oi.optOutInbound(ctx, phone, message.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		response.OptedOut++
+		response.CancelledNumbers += cancelled
+	}
+
+	oi.logger.Info("opt-out interactor ProcessInbound completed",
+		"received", len(req.Messages),
+		"opted_out", response.OptedOut,
+		"cancelled_numbers", response.CancelledNumbers,
+		"ignored", response.Ignored)
+
+	return response, nil
+}
+
+// optOutInbound отписывает отправителя стоп-слова. Возвращает количество отмененных номеров кампаний
+func (oi *OptOutInteractor) optOutInbound(ctx context.Context, phone *campaign.PhoneNumber, text string) (int, error) {
+	reason := strings.TrimSpace(text)
+	if runes := []rune(reason); len(runes) > maxInboundReasonLength {
+		reason = string(runes[:maxInboundReasonLength])
+	}
+
+	added, err := oi.optOutRepo.Add(ctx, campaign.NewOptOut(phone, reason, campaign.OptOutSourceInbound))
+	if err != nil {
+		oi.logger.Error("opt-out interactor ProcessInbound: failed to add phone number", "phone_number", phone.Value(), "error", err)
+		return 0, fmt.Errorf("%w: %s", ErrProcessInboundMessage, err.Error())
+	}
+
+	// Сначала убираем сообщения из очередей, чтобы диспетчер не успел их отправить
+	dropped := oi.dispatcher.DropRecipient(phone.Value())
+
+	cancelled, err := oi.campaignRepo.CancelPendingPhoneStatusesByNumber(ctx, phone.Value(), campaign.OptOutErrorMessage)
+	if err != nil {
+		oi.logger.Error("opt-out interactor ProcessInbound: failed to cancel pending numbers", "phone_number", phone.Value(), "error", err)
+		return 0, fmt.Errorf("%w: %s", ErrProcessInboundMessage, err.Error())
+	}
+
+	oi.logger.Info("opt-out interactor: recipient opted out by inbound message",
+		"phone_number", phone.Value(), "added", added, "dropped_from_queue", dropped, "cancelled_numbers", cancelled)
+
+	// Подтверждение отправляется один раз: повторное стоп-слово не должно порождать новые сообщения
+	if added {
+		oi.sendOptOutConfirmation(ctx, phone)
+	}
+
+	return cancelled, nil
+}
+
+// sendOptOutConfirmation отправляет подтверждение отписки, если оно настроено.
+// Ошибка отправки не отменяет отписку и только логируется
+func (oi *OptOutInteractor) sendOptOutConfirmation(ctx context.Context, phone *campaign.PhoneNumber) {
+	if oi.options.ConfirmationMessage == "" {
+		return
+	}
+
+	result, err := oi.messageGateway.SendTextMessage(ctx, phone.Value(), oi.options.ConfirmationMessage, false)
+	if err != nil {
+		oi.logger.Warn("opt-out interactor: failed to send opt-out confirmation", "phone_number", phone.Value(), "error", err)
+		return
+	}
+	if result != nil && !result.Success {
+		oi.logger.Warn("opt-out interactor: opt-out confirmation was not sent", "phone_number", phone.Value(), "error", result.Error)
+	}
+}
@@ -0,0 +1,140 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для pause операций
+const (
+	MaxPauseCampaignIDLength = 36 // UUID length
+)
+
+// Кастомные ошибки для pause операций
+var (
+	ErrPauseCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrPauseCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxPauseCampaignIDLength)
+	ErrPauseCampaignNotFound   = fmt.Errorf("campaign not found")
+	ErrCannotBePaused          = fmt.Errorf("campaign cannot be paused")
+	ErrDispatcherPause         = fmt.Errorf("failed to pause campaign in dispatcher")
+	ErrPauseStatusUpdate       = fmt.Errorf("failed to update campaign status")
+)
+
+// Pause приостанавливает запущенную кампанию. Очередь кампании остается в диспетчере до вызова Resume
+func (ci *CampaignInteractor) Pause(ctx context.Context, req dto.PauseCampaignRequest) (*dto.PauseCampaignResponse, error) {
+	if err := ci.validatePauseRequest(req); err != nil {
+		return nil, err
+	}
+
+	c, err := ci.getPauseCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ci.validatePause(c); err != nil {
+		return nil, err
+	}
+
+	if err := ci.pauseInDispatcher(c.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := ci.updatePauseCampaignStatus(ctx, c); err != nil {
+		_ = ci.dispatcher.Resume(c.ID())
+		return nil, err
+	}
+
+	response := ci.buildPauseResponse(ctx, c)
+
+	ci.logger.Info("Campaign paused successfully", map[string]interface{}{
+		"campaignID":     c.ID(),
+		"pendingNumbers": response.PendingNumbers,
+	})
+
+	return response, nil
+}
+
+// validatePauseRequest проверяет валидность запроса на приостановку
+func (ci *CampaignInteractor) validatePauseRequest(req dto.PauseCampaignRequest) error {
+	if req.CampaignID == "" {
+		return ErrPauseCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxPauseCampaignIDLength {
+		return ErrPauseCampaignIDTooLong
+	}
+	return nil
+}
+
+// getPauseCampaign получает кампанию по ID
+func (ci *CampaignInteractor) getPauseCampaign(ctx context.Context, campaignID string) (*campaign.Campaign, error) {
+	c, err := ci.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		ci.logger.Error("Failed to get campaign", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, fmt.Errorf("%w: %w", ErrPauseCampaignNotFound, err)
+	}
+	return c, nil
+}
+
+// validatePause проверяет возможность приостановки кампании
+func (ci *CampaignInteractor) validatePause(c *campaign.Campaign) error {
+	if !c.CanBePaused() {
+		ci.logger.Warn("Campaign cannot be paused", map[string]interface{}{
+			"campaignID": c.ID(),
+			"status":     string(c.Status()),
+		})
+		return fmt.Errorf("%w: current status is %s", ErrCannotBePaused, c.Status())
+	}
+	return nil
+}
+
+// pauseInDispatcher откладывает очередь кампании в диспетчере
+func (ci *CampaignInteractor) pauseInDispatcher(campaignID string) error {
+	if err := ci.dispatcher.Pause(campaignID); err != nil {
+		ci.logger.Warn("Failed to pause campaign in dispatcher", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return fmt.Errorf("%w: %s", ErrDispatcherPause, err.Error())
+	}
+	return nil
+}
+
+// updatePauseCampaignStatus переводит кампанию в статус "приостановлена"
+func (ci *CampaignInteractor) updatePauseCampaignStatus(ctx context.Context, c *campaign.Campaign) error {
+	if err := c.Pause(); err != nil {
+		return fmt.Errorf("%w: %s", ErrCannotBePaused, err.Error())
+	}
+
+	if err := ci.campaignRepo.UpdateStatus(ctx, c.ID(), c.Status()); err != nil {
+		ci.logger.Error("Failed to update campaign status", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+			"status":     string(c.Status()),
+		})
+		return fmt.Errorf("%w: %s", ErrPauseStatusUpdate, err.Error())
+	}
+
+	return nil
+}
+
+// buildPauseResponse строит ответ на приостановку кампании
+func (ci *CampaignInteractor) buildPauseResponse(ctx context.Context, c *campaign.Campaign) *dto.PauseCampaignResponse {
+	pendingCount, err := ci.campaignRepo.CountPhoneStatusesByCampaignID(ctx, c.ID(), campaign.CampaignStatusTypePending)
+	if err != nil {
+		ci.logger.Warn("Failed to count pending numbers for response", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+		})
+	}
+
+	return &dto.PauseCampaignResponse{
+		CampaignID:     c.ID(),
+		Status:         c.Status(),
+		PendingNumbers: pendingCount,
+	}
+}
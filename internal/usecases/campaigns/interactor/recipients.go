@@ -0,0 +1,122 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+
+	"github.com/google/uuid"
+)
+
+// Константы для списка номеров кампании
+const (
+	DefaultRecipientsLimit        = 100  // Размер страницы по умолчанию
+	MaxRecipientsLimit            = 1000 // Максимальный размер страницы
+	MaxRecipientsSearchLength     = 20   // Максимальная длина строки поиска по номеру
+	MaxRecipientsCampaignIDLength = 36   // UUID length
+)
+
+// Кастомные ошибки для списка номеров кампании
+var (
+	ErrRecipientsCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrRecipientsCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxRecipientsCampaignIDLength)
+	ErrInvalidRecipientsStatus      = fmt.Errorf("invalid status filter: expected pending, sent, delivered, read, failed or cancelled")
+	ErrInvalidRecipientsSearch      = fmt.Errorf("search must be a part of phone number: up to %d digits", MaxRecipientsSearchLength)
+	ErrInvalidRecipientsPagination  = fmt.Errorf("limit and offset must not be negative")
+	ErrInvalidRecipientsCursor      = fmt.Errorf("invalid cursor: expected recipient ID")
+	ErrListRecipients               = fmt.Errorf("failed to list campaign recipients")
+)
+
+// ListRecipients возвращает страницу номеров кампании.
+// Для последовательного обхода используется курсор After (keyset), для перехода на произвольную страницу — Offset
+func (ci *CampaignInteractor) ListRecipients(ctx context.Context, req dto.ListRecipientsRequest) (*dto.ListRecipientsResponse, error) {
+	page, err := ci.validateRecipientsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ошибка возвращается без обертки, чтобы отсутствие кампании отдавалось как 404
+	if _, err := ci.campaignRepo.GetByID(ctx, req.CampaignID); err != nil {
+		ci.logger.Error("campaign interactor ListRecipients: failed to get campaign", "campaign_id", req.CampaignID, "error", err)
+		return nil, err
+	}
+
+	statuses, err := ci.campaignRepo.ListPhoneStatusesPage(ctx, req.CampaignID, page)
+	if err != nil {
+		ci.logger.Error("campaign interactor ListRecipients: failed to list recipients", "campaign_id", req.CampaignID, "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrListRecipients, err.Error())
+	}
+
+	total, err := ci.campaignRepo.CountPhoneStatusesByFilter(ctx, req.CampaignID, page.PhoneStatusFilter)
+	if err != nil {
+		ci.logger.Error("campaign interactor ListRecipients: failed to count recipients", "campaign_id", req.CampaignID, "error", err)
+		return nil, fmt.Errorf("%w: %s", ErrListRecipients, err.Error())
+	}
+
+	response := &dto.ListRecipientsResponse{
+		CampaignID: req.CampaignID,
+		Recipients: make([]dto.PhoneNumberStatus, len(statuses)),
+		Total:      total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	}
+	for i, status := range statuses {
+		response.Recipients[i] = mapPhoneNumberStatus(status)
+	}
+	if len(statuses) == page.Limit {
+		response.NextCursor = statuses[len(statuses)-1].ID()
+	}
+
+	ci.logger.Debug("campaign interactor ListRecipients completed successfully",
+		"campaign_id", req.CampaignID, "returned", len(statuses), "total", total)
+	return response, nil
+}
+
+// validateRecipientsRequest проверяет запрос и формирует страницу для репозитория
+func (ci *CampaignInteractor) validateRecipientsRequest(req dto.ListRecipientsRequest) (repository.PhoneStatusPage, error) {
+	var page repository.PhoneStatusPage
+
+	if req.CampaignID == "" {
+		return page, ErrRecipientsCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxRecipientsCampaignIDLength {
+		return page, ErrRecipientsCampaignIDTooLong
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return page, ErrInvalidRecipientsPagination
+	}
+	if req.After != "" {
+		if _, err := uuid.Parse(req.After); err != nil {
+			return page, ErrInvalidRecipientsCursor
+		}
+	}
+
+	statuses, ok := phoneStatusFilter(req.Status)
+	if !ok {
+		return page, ErrInvalidRecipientsStatus
+	}
+
+	search := strings.TrimPrefix(strings.TrimSpace(req.Search), "+")
+	if len(search) > MaxRecipientsSearchLength || strings.Trim(search, "0123456789") != "" {
+		return page, ErrInvalidRecipientsSearch
+	}
+
+	page.Statuses = statuses
+	page.Search = search
+	page.AfterID = req.After
+	page.Limit = req.Limit
+	if page.Limit == 0 {
+		page.Limit = DefaultRecipientsLimit
+	}
+	if page.Limit > MaxRecipientsLimit {
+		page.Limit = MaxRecipientsLimit
+	}
+	// Курсор задает начало страницы сам по себе
+	if req.After == "" {
+		page.Offset = req.Offset
+	}
+
+	return page, nil
+}
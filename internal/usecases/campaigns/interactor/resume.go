@@ -0,0 +1,254 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для resume операций
+const (
+	MaxResumeCampaignIDLength = 36 // UUID length
+)
+
+// Кастомные ошибки для resume операций
+var (
+	ErrResumeCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrResumeCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxResumeCampaignIDLength)
+	ErrResumeCampaignNotFound   = fmt.Errorf("campaign not found")
+	ErrCannotBeResumed          = fmt.Errorf("campaign cannot be resumed")
+	ErrResumeStatusUpdate       = fmt.Errorf("failed to update campaign status")
+	ErrGetPendingStatuses       = fmt.Errorf("failed to get pending campaign statuses")
+)
+
+// Resume возобновляет приостановленную или прерванную кампанию.
+// Если очередь приостановленной кампании еще в диспетчере, она просто возвращается в обработку,
+// иначе в диспетчер повторно отправляются только номера в статусе pending.
+// Номера из стоп-листа и превысившие лимит частоты отменяются, как при запуске
+func (ci *CampaignInteractor) Resume(ctx context.Context, req dto.ResumeCampaignRequest) (*dto.ResumeCampaignResponse, error) {
+	if err := ci.validateResumeRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Кампания перечитывается и проверяется под блокировкой, а регистрация выполняется до ее снятия:
+	// второй одновременный запрос увидит кампанию уже запущенной
+	ci.launchMu.Lock()
+	c, pending, err := ci.prepareResume(ctx, req.CampaignID)
+	if err != nil {
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+
+	previousStatus := c.Status()
+	if err := ci.updateResumeCampaignStatus(ctx, c); err != nil {
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+
+	if previousStatus == campaign.CampaignStatusPaused && ci.resumeInDispatcher(c.ID()) {
+		ci.launchMu.Unlock()
+		ci.logger.Info("Paused campaign resumed in dispatcher", map[string]interface{}{
+			"campaignID":     c.ID(),
+			"pendingNumbers": len(pending),
+		})
+		return ci.buildResumeResponse(c, len(pending), 0, 0, true), nil
+	}
+
+	if len(pending) == 0 {
+		ci.launchMu.Unlock()
+		ci.finalizeStartCampaignStatus(c.ID(), false)
+		c.Finish()
+		return ci.buildResumeResponse(c, 0, 0, 0, false), nil
+	}
+
+	workerCtx, cancel, err := ci.registerStartCampaign(c.ID())
+	if err != nil {
+		// Уже зарегистрированную кампанию обрабатывает другой воркер, ее статус не откатывается
+		if !errors.Is(err, campaign.ErrCampaignAlreadyRunning) {
+			ci.rollbackResumeCampaignStatus(ctx, c, previousStatus)
+		}
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+	ci.launchMu.Unlock()
+
+	// Номера могли попасть в стоп-лист или исчерпать лимит частоты, пока кампания стояла
+	pending, blocked, err := ci.cancelOptedOutStatuses(ctx, c.ID(), pending)
+	if err != nil {
+		ci.abortResume(ctx, c, cancel, previousStatus)
+		return nil, err
+	}
+	pending, capped, err := ci.cancelFrequencyCappedStatuses(ctx, c.ID(), pending)
+	if err != nil {
+		ci.abortResume(ctx, c, cancel, previousStatus)
+		return nil, err
+	}
+	if len(pending) == 0 {
+		ci.registry.Unregister(c.ID())
+		cancel()
+		ci.finalizeStartCampaignStatus(c.ID(), false)
+		c.Finish()
+		return ci.buildResumeResponse(c, 0, blocked, capped, false), nil
+	}
+
+	if err := ci.submitStartJob(workerCtx, cancel, c, pending); err != nil {
+		ci.rollbackResumeCampaignStatus(ctx, c, previousStatus)
+		return nil, err
+	}
+
+	response := ci.buildResumeResponse(c, len(pending), blocked, capped, true)
+
+	ci.logger.Info("Campaign resumed successfully", map[string]interface{}{
+		"campaignID":       c.ID(),
+		"pendingNumbers":   response.PendingNumbers,
+		"alreadyProcessed": response.AlreadyProcessed,
+		"blockedNumbers":   blocked,
+		"cappedNumbers":    capped,
+	})
+
+	return response, nil
+}
+
+// validateResumeRequest проверяет валидность запроса на возобновление
+func (ci *CampaignInteractor) validateResumeRequest(req dto.ResumeCampaignRequest) error {
+	if req.CampaignID == "" {
+		return ErrResumeCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxResumeCampaignIDLength {
+		return ErrResumeCampaignIDTooLong
+	}
+	return nil
+}
+
+// getResumeCampaign получает кампанию по ID
+func (ci *CampaignInteractor) getResumeCampaign(ctx context.Context, campaignID string) (*campaign.Campaign, error) {
+	c, err := ci.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		ci.logger.Error("Failed to get campaign", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, fmt.Errorf("%w: %w", ErrResumeCampaignNotFound, err)
+	}
+	return c, nil
+}
+
+// validateResume проверяет возможность возобновления кампании
+func (ci *CampaignInteractor) validateResume(c *campaign.Campaign) error {
+	if !c.CanBeResumed() {
+		ci.logger.Warn("Campaign cannot be resumed", map[string]interface{}{
+			"campaignID": c.ID(),
+			"status":     string(c.Status()),
+		})
+		return fmt.Errorf("%w: current status is %s", ErrCannotBeResumed, c.Status())
+	}
+	return nil
+}
+
+// prepareResume перечитывает кампанию, проверяет возможность возобновления и лимит одновременных рассылок
+// и возвращает необработанные номера. Должен вызываться под ci.launchMu
+func (ci *CampaignInteractor) prepareResume(ctx context.Context, campaignID string) (*campaign.Campaign, []*campaign.CampaignPhoneStatus, error) {
+	c, err := ci.getResumeCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ci.validateResume(c); err != nil {
+		return nil, nil, err
+	}
+
+	pending, err := ci.getResumePendingStatuses(ctx, c.ID())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ci.checkConcurrencyLimit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return c, pending, nil
+}
+
+// getResumePendingStatuses получает номера кампании, которые еще не были обработаны
+func (ci *CampaignInteractor) getResumePendingStatuses(ctx context.Context, campaignID string) ([]*campaign.CampaignPhoneStatus, error) {
+	pending, err := ci.campaignRepo.GetPendingPhoneStatuses(ctx, campaignID)
+	if err != nil {
+		ci.logger.Error("Failed to get pending campaign statuses", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, fmt.Errorf("%w: %s", ErrGetPendingStatuses, err.Error())
+	}
+	return pending, nil
+}
+
+// updateResumeCampaignStatus переводит кампанию обратно в статус "запущена"
+func (ci *CampaignInteractor) updateResumeCampaignStatus(ctx context.Context, c *campaign.Campaign) error {
+	if err := c.Resume(); err != nil {
+		return fmt.Errorf("%w: %s", ErrCannotBeResumed, err.Error())
+	}
+
+	if err := ci.campaignRepo.UpdateStatus(ctx, c.ID(), c.Status()); err != nil {
+		ci.logger.Error("Failed to update campaign status", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+			"status":     string(c.Status()),
+		})
+		return fmt.Errorf("%w: %s", ErrResumeStatusUpdate, err.Error())
+	}
+
+	return nil
+}
+
+// resumeInDispatcher возвращает в обработку очередь, отложенную в диспетчере.
+// Возвращает false, если очереди в диспетчере уже нет (например, после перезапуска сервиса)
+func (ci *CampaignInteractor) resumeInDispatcher(campaignID string) bool {
+	if err := ci.dispatcher.Resume(campaignID); err != nil {
+		ci.logger.Info("Campaign queue not found in dispatcher, resubmitting pending numbers", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return false
+	}
+	return true
+}
+
+// rollbackResumeCampaignStatus возвращает кампании прежний статус, если отправку не удалось запустить
+func (ci *CampaignInteractor) rollbackResumeCampaignStatus(ctx context.Context, c *campaign.Campaign, previousStatus campaign.CampaignStatus) {
+	c.SetStatus(previousStatus)
+
+	if err := ci.campaignRepo.UpdateStatus(ctx, c.ID(), c.Status()); err != nil {
+		ci.logger.Error("Failed to rollback campaign status", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+		})
+	}
+}
+
+// abortResume снимает регистрацию кампании и возвращает ей прежний статус, если отправку не удалось запустить
+func (ci *CampaignInteractor) abortResume(ctx context.Context, c *campaign.Campaign, cancel context.CancelFunc, previousStatus campaign.CampaignStatus) {
+	ci.registry.Unregister(c.ID())
+	cancel()
+	ci.rollbackResumeCampaignStatus(ctx, c, previousStatus)
+}
+
+// buildResumeResponse строит ответ на возобновление кампании
+func (ci *CampaignInteractor) buildResumeResponse(c *campaign.Campaign, pendingCount, blocked, capped int, workerStarted bool) *dto.ResumeCampaignResponse {
+	alreadyProcessed := c.Metrics().Total - pendingCount
+	if alreadyProcessed < 0 {
+		alreadyProcessed = 0
+	}
+
+	return &dto.ResumeCampaignResponse{
+		CampaignID:          c.ID(),
+		Status:              c.Status(),
+		PendingNumbers:      pendingCount,
+		AlreadyProcessed:    alreadyProcessed,
+		BlockedNumbers:      blocked,
+		CappedNumbers:       capped,
+		EstimatedCompletion: estimateCompletion(c, pendingCount),
+		WorkerStarted:       workerStarted,
+	}
+}
@@ -0,0 +1,241 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для retry операций
+const (
+	MaxRetryCampaignIDLength = 36  // UUID length
+	MaxRetryErrorFilterLen   = 255 // Максимальная длина фильтра по тексту ошибки
+)
+
+// Кастомные ошибки для retry операций
+var (
+	ErrRetryCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrRetryCampaignIDTooLong  = fmt.Errorf("campaign ID too long: maximum %d characters", MaxRetryCampaignIDLength)
+	ErrRetryErrorFilterTooLong = fmt.Errorf("error filter too long: maximum %d characters", MaxRetryErrorFilterLen)
+	ErrRetryCampaignNotFound   = fmt.Errorf("campaign not found")
+	ErrCannotRetryFailed       = fmt.Errorf("failed numbers cannot be retried")
+	ErrGetFailedStatuses       = fmt.Errorf("failed to get failed campaign statuses")
+	ErrNoFailedNumbers         = fmt.Errorf("no failed numbers to retry")
+	ErrRetryStatusUpdate       = fmt.Errorf("failed to reset failed numbers")
+)
+
+// RetryFailed возвращает неудачные номера завершенной кампании в очередь и повторно отправляет их
+// с исходной скоростью кампании. Номера из стоп-листа и превысившие лимит частоты отменяются, как при запуске
+func (ci *CampaignInteractor) RetryFailed(ctx context.Context, req dto.RetryFailedRequest) (*dto.RetryFailedResponse, error) {
+	if err := ci.validateRetryRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Кампания перечитывается и проверяется под блокировкой, а регистрация и сброс номеров выполняются
+	// до ее снятия: второй одновременный запрос увидит кампанию уже запущенной
+	ci.launchMu.Lock()
+	c, toRetry, err := ci.prepareRetry(ctx, req)
+	if err != nil {
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+
+	workerCtx, cancel, err := ci.registerStartCampaign(c.ID())
+	if err != nil {
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+
+	err = ci.resetRetryFailedStatuses(ctx, c, toRetry)
+	ci.launchMu.Unlock()
+	if err != nil {
+		ci.registry.Unregister(c.ID())
+		cancel()
+		return nil, err
+	}
+
+	// Номера могли попасть в стоп-лист или исчерпать лимит частоты после неудачной отправки
+	toRetry, blocked, err := ci.cancelOptedOutStatuses(ctx, c.ID(), toRetry)
+	if err != nil {
+		ci.abortRetry(ctx, c, cancel)
+		return nil, err
+	}
+	toRetry, capped, err := ci.cancelFrequencyCappedStatuses(ctx, c.ID(), toRetry)
+	if err != nil {
+		ci.abortRetry(ctx, c, cancel)
+		return nil, err
+	}
+	if len(toRetry) == 0 {
+		ci.registry.Unregister(c.ID())
+		cancel()
+		ci.finalizeStartCampaignStatus(c.ID(), false)
+		c.Finish()
+		return ci.buildRetryResponse(c, 0, blocked, capped, false), nil
+	}
+
+	if err := ci.submitStartJob(workerCtx, cancel, c, toRetry); err != nil {
+		ci.interruptRetryCampaign(ctx, c)
+		return nil, err
+	}
+
+	ci.logger.Info("Failed numbers resubmitted", map[string]interface{}{
+		"campaignID":     c.ID(),
+		"retriedNumbers": len(toRetry),
+		"blockedNumbers": blocked,
+		"cappedNumbers":  capped,
+		"errorContains":  req.ErrorContains,
+	})
+
+	return ci.buildRetryResponse(c, len(toRetry), blocked, capped, true), nil
+}
+
+// buildRetryResponse строит ответ на повторную отправку неудачных номеров
+func (ci *CampaignInteractor) buildRetryResponse(c *campaign.Campaign, retried, blocked, capped int, workerStarted bool) *dto.RetryFailedResponse {
+	return &dto.RetryFailedResponse{
+		CampaignID:          c.ID(),
+		Status:              c.Status(),
+		RetriedNumbers:      retried,
+		BlockedNumbers:      blocked,
+		CappedNumbers:       capped,
+		EstimatedCompletion: estimateCompletion(c, retried),
+		WorkerStarted:       workerStarted,
+	}
+}
+
+// validateRetryRequest проверяет валидность запроса на повторную отправку
+func (ci *CampaignInteractor) validateRetryRequest(req dto.RetryFailedRequest) error {
+	if req.CampaignID == "" {
+		return ErrRetryCampaignIDRequired
+	}
+	if len(req.CampaignID) > MaxRetryCampaignIDLength {
+		return ErrRetryCampaignIDTooLong
+	}
+	if len(req.ErrorContains) > MaxRetryErrorFilterLen {
+		return ErrRetryErrorFilterTooLong
+	}
+	return nil
+}
+
+// getRetryCampaign получает кампанию по ID
+func (ci *CampaignInteractor) getRetryCampaign(ctx context.Context, campaignID string) (*campaign.Campaign, error) {
+	c, err := ci.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		ci.logger.Error("Failed to get campaign", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, fmt.Errorf("%w: %w", ErrRetryCampaignNotFound, err)
+	}
+	return c, nil
+}
+
+// validateRetry проверяет, что кампания завершена и не обрабатывается в данный момент
+func (ci *CampaignInteractor) validateRetry(c *campaign.Campaign) error {
+	if !c.CanRetryFailed() {
+		ci.logger.Warn("Campaign failed numbers cannot be retried", map[string]interface{}{
+			"campaignID": c.ID(),
+			"status":     string(c.Status()),
+		})
+		return fmt.Errorf("%w: current status is %s", ErrCannotRetryFailed, c.Status())
+	}
+	return nil
+}
+
+// getRetryFailedStatuses получает неудачные номера, подходящие под фильтр по тексту ошибки
+func (ci *CampaignInteractor) getRetryFailedStatuses(ctx context.Context, campaignID, errorContains string) ([]*campaign.CampaignPhoneStatus, error) {
+	failed, err := ci.campaignRepo.GetFailedPhoneStatuses(ctx, campaignID)
+	if err != nil {
+		ci.logger.Error("Failed to get failed campaign statuses", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, fmt.Errorf("%w: %s", ErrGetFailedStatuses, err.Error())
+	}
+
+	filter := strings.ToLower(strings.TrimSpace(errorContains))
+	toRetry := make([]*campaign.CampaignPhoneStatus, 0, len(failed))
+	for _, status := range failed {
+		if !status.CanBeRetried() {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(status.ErrorMessage()), filter) {
+			continue
+		}
+		toRetry = append(toRetry, status)
+	}
+
+	if len(toRetry) == 0 {
+		return nil, fmt.Errorf("%w: campaign %s", ErrNoFailedNumbers, campaignID)
+	}
+
+	return toRetry, nil
+}
+
+// prepareRetry перечитывает кампанию, проверяет возможность повтора и лимит одновременных рассылок
+// и возвращает неудачные номера для повторной отправки. Должен вызываться под ci.launchMu
+func (ci *CampaignInteractor) prepareRetry(ctx context.Context, req dto.RetryFailedRequest) (*campaign.Campaign, []*campaign.CampaignPhoneStatus, error) {
+	c, err := ci.getRetryCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ci.validateRetry(c); err != nil {
+		return nil, nil, err
+	}
+
+	toRetry, err := ci.getRetryFailedStatuses(ctx, c.ID(), req.ErrorContains)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ci.checkConcurrencyLimit(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return c, toRetry, nil
+}
+
+// resetRetryFailedStatuses переводит номера обратно в pending и в той же транзакции сохраняет статус и счетчики кампании
+func (ci *CampaignInteractor) resetRetryFailedStatuses(ctx context.Context, c *campaign.Campaign, toRetry []*campaign.CampaignPhoneStatus) error {
+	if err := c.RetryFailed(len(toRetry)); err != nil {
+		return fmt.Errorf("%w: %s", ErrCannotRetryFailed, err.Error())
+	}
+
+	if err := ci.campaignRepo.RetryFailedPhoneStatuses(ctx, c, statusPhoneNumbers(toRetry)); err != nil {
+		ci.logger.Error("Failed to reset failed numbers", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+			"numbers":    len(toRetry),
+		})
+		return fmt.Errorf("%w: %s", ErrRetryStatusUpdate, err.Error())
+	}
+
+	for _, status := range toRetry {
+		status.Retry()
+	}
+	return nil
+}
+
+// abortRetry снимает регистрацию кампании, если повторную отправку не удалось запустить после сброса номеров
+func (ci *CampaignInteractor) abortRetry(ctx context.Context, c *campaign.Campaign, cancel context.CancelFunc) {
+	ci.registry.Unregister(c.ID())
+	cancel()
+	ci.interruptRetryCampaign(ctx, c)
+}
+
+// interruptRetryCampaign помечает кампанию прерванной, если повторную отправку не удалось запустить.
+// Сброшенные номера остаются в pending и будут отправлены при возобновлении
+func (ci *CampaignInteractor) interruptRetryCampaign(ctx context.Context, c *campaign.Campaign) {
+	if err := c.Interrupt(); err != nil {
+		return
+	}
+
+	if err := ci.campaignRepo.UpdateStatus(ctx, c.ID(), c.Status()); err != nil {
+		ci.logger.Error("Failed to mark campaign as interrupted", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+		})
+	}
+}
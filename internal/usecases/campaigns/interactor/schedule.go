@@ -0,0 +1,174 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для запуска запланированных кампаний
+const (
+	DefaultDueCampaignsLimit = 50 // Максимум кампаний, запускаемых за один проход планировщика
+	MaxResumeAttempts        = 3  // Неудачных попыток подряд, после которых прерванная кампания помечается неудачной
+)
+
+// Кастомные ошибки для запуска запланированных кампаний
+var (
+	ErrListDueCampaigns         = fmt.Errorf("failed to list due scheduled campaigns")
+	ErrListInterruptedCampaigns = fmt.Errorf("failed to list interrupted campaigns")
+)
+
+// StartDueCampaigns возобновляет прерванные остановкой сервиса кампании и запускает запланированные
+// кампании, время запуска которых наступило. Кампании, не запущенные из-за лимита одновременных рассылок,
+// остаются в статусе interrupted или scheduled и будут запущены при следующем вызове
+func (ci *CampaignInteractor) StartDueCampaigns(ctx context.Context, req dto.StartDueCampaignsRequest) (*dto.StartDueCampaignsResponse, error) {
+	now := req.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultDueCampaignsLimit
+	}
+
+	response := &dto.StartDueCampaignsResponse{
+		ResumedCampaignIDs: make([]string, 0),
+		StartedCampaignIDs: make([]string, 0),
+	}
+
+	limitReached, err := ci.resumeInterruptedCampaigns(ctx, limit, response)
+	if err != nil {
+		return nil, err
+	}
+	if limitReached {
+		return response, nil
+	}
+
+	due, err := ci.campaignRepo.ListDueScheduled(ctx, now, limit)
+	if err != nil {
+		ci.logger.Error("Failed to list due scheduled campaigns", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, fmt.Errorf("%w: %s", ErrListDueCampaigns, err.Error())
+	}
+
+	for i, c := range due {
+		_, err := ci.Start(ctx, dto.StartCampaignRequest{CampaignID: c.ID()})
+		if err == nil {
+			response.StartedCampaignIDs = append(response.StartedCampaignIDs, c.ID())
+			continue
+		}
+
+		if errors.Is(err, ErrConcurrencyLimitReached) {
+			response.DeferredCount += len(due) - i
+			ci.logger.Info("Scheduled campaigns deferred by concurrency limit", map[string]interface{}{
+				"deferred": len(due) - i,
+			})
+			break
+		}
+
+		ci.logger.Error("Failed to start scheduled campaign", map[string]interface{}{
+			"error":       err.Error(),
+			"campaignID":  c.ID(),
+			"scheduledAt": c.ScheduledAt(),
+		})
+
+		if errors.Is(err, ErrCannotBeStarted) || errors.Is(err, ErrNoPendingNumbers) {
+			ci.failCampaign(ctx, c)
+			response.FailedCount++
+		}
+	}
+
+	return response, nil
+}
+
+// resumeInterruptedCampaigns возобновляет до limit прерванных кампаний. Кампании, которые не удалось
+// возобновить, пропускаются до следующего вызова, а после MaxResumeAttempts неудач подряд помечаются неудачными.
+// Возвращает true, если достигнут лимит одновременных рассылок: оставшиеся кампании будут возобновлены при следующем вызове
+func (ci *CampaignInteractor) resumeInterruptedCampaigns(ctx context.Context, limit int, response *dto.StartDueCampaignsResponse) (bool, error) {
+	// Возобновленные и помеченные неудачными кампании уходят из выборки, смещение растет только на пропущенные
+	attempted, offset := 0, 0
+	for attempted < limit {
+		interrupted, err := ci.campaignRepo.ListByStatus(ctx, string(campaign.CampaignStatusInterrupted), limit-attempted, offset)
+		if err != nil {
+			ci.logger.Error("Failed to list interrupted campaigns", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return false, fmt.Errorf("%w: %s", ErrListInterruptedCampaigns, err.Error())
+		}
+		if len(interrupted) == 0 {
+			return false, nil
+		}
+
+		for i, c := range interrupted {
+			attempted++
+			_, err := ci.Resume(ctx, dto.ResumeCampaignRequest{CampaignID: c.ID()})
+			if err == nil {
+				ci.resetResumeFailures(c.ID())
+				response.ResumedCampaignIDs = append(response.ResumedCampaignIDs, c.ID())
+				continue
+			}
+
+			if errors.Is(err, ErrConcurrencyLimitReached) {
+				response.DeferredCount += len(interrupted) - i
+				ci.logger.Info("Interrupted campaigns deferred by concurrency limit", map[string]interface{}{
+					"deferred": len(interrupted) - i,
+				})
+				return true, nil
+			}
+
+			ci.logger.Error("Failed to resume interrupted campaign", map[string]interface{}{
+				"error":      err.Error(),
+				"campaignID": c.ID(),
+			})
+
+			// Кампанию уже обрабатывает другой воркер, из выборки она ушла
+			if errors.Is(err, campaign.ErrCampaignAlreadyRunning) {
+				ci.resetResumeFailures(c.ID())
+				continue
+			}
+
+			if ci.recordResumeFailure(c.ID()) >= MaxResumeAttempts {
+				ci.resetResumeFailures(c.ID())
+				ci.failCampaign(ctx, c)
+				response.FailedCount++
+				continue
+			}
+			offset++
+		}
+	}
+
+	return false, nil
+}
+
+// recordResumeFailure учитывает неудачную попытку возобновления кампании и возвращает число неудач подряд
+func (ci *CampaignInteractor) recordResumeFailure(campaignID string) int {
+	ci.resumeFailuresMu.Lock()
+	defer ci.resumeFailuresMu.Unlock()
+
+	ci.resumeFailures[campaignID]++
+	return ci.resumeFailures[campaignID]
+}
+
+// resetResumeFailures сбрасывает счетчик неудачных попыток возобновления кампании
+func (ci *CampaignInteractor) resetResumeFailures(campaignID string) {
+	ci.resumeFailuresMu.Lock()
+	defer ci.resumeFailuresMu.Unlock()
+
+	delete(ci.resumeFailures, campaignID)
+}
+
+// failCampaign помечает кампанию, которую невозможно запустить или возобновить, как неудачную,
+// чтобы планировщик не пытался обрабатывать ее повторно
+func (ci *CampaignInteractor) failCampaign(ctx context.Context, c *campaign.Campaign) {
+	if err := ci.campaignRepo.UpdateStatus(ctx, c.ID(), campaign.CampaignStatusFailed); err != nil {
+		ci.logger.Error("Failed to mark campaign as failed", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": c.ID(),
+		})
+	}
+}
@@ -2,9 +2,12 @@ package interactor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 	"whatsapp-service/internal/entities/campaign"
 	"whatsapp-service/internal/usecases/campaigns/dto"
+	"whatsapp-service/internal/usecases/campaigns/ports"
 	infraDTO "whatsapp-service/internal/usecases/dto"
 )
 
@@ -12,7 +15,7 @@ import (
 const (
 	MaxStartCampaignIDLength = 36  // UUID length
 	StatusUpdateBatchSize    = 100 // Batch size for status updates
-	MaxConcurrentCampaigns   = 10  // Maximum concurrent campaigns
+	MaxConcurrentCampaigns   = 10  // Default maximum of concurrently sending campaigns
 )
 
 // Кастомные ошибки для start операций
@@ -26,6 +29,9 @@ var (
 	ErrGetStatuses             = fmt.Errorf("failed to get campaign statuses")
 	ErrRegistryRegister        = fmt.Errorf("failed to register campaign")
 	ErrDispatcherSubmit        = fmt.Errorf("failed to submit job to dispatcher")
+	ErrNoPendingNumbers        = fmt.Errorf("campaign cannot be started: all phone numbers are already processed")
+	ErrConcurrencyLimitReached = fmt.Errorf("too many campaigns are sending at the same time")
+	ErrCancelExcluded          = fmt.Errorf("failed to cancel excluded numbers")
 )
 
 // Start выполняет запуск кампании
@@ -39,15 +45,38 @@ func (ci *CampaignInteractor) Start(ctx context.Context, req dto.StartCampaignRe
 		return nil, err
 	}
 
-	if err := ci.validateStart(c); err != nil {
+	statuses, err := ci.getStartCampaignStatuses(ctx, req.CampaignID)
+	if err != nil {
 		return nil, err
 	}
 
-	if err := ci.updateStartCampaignStatus(ctx, c); err != nil {
+	pending, skipped := splitPendingStatuses(statuses)
+
+	if err := ci.validateStart(c, statuses, pending); err != nil {
 		return nil, err
 	}
 
-	statuses, err := ci.getStartCampaignStatuses(ctx, req.CampaignID)
+	// Номера могли попасть в стоп-лист или исчерпать лимит частоты после создания кампании
+	pending, blocked, err := ci.cancelOptedOutStatuses(ctx, c.ID(), pending)
+	if err != nil {
+		return nil, err
+	}
+	pending, capped, err := ci.cancelFrequencyCappedStatuses(ctx, c.ID(), pending)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) == 0 {
+		return nil, fmt.Errorf("%w: campaign %s", ErrNoPendingNumbers, c.ID())
+	}
+
+	ci.launchMu.Lock()
+	if err := ci.checkConcurrencyLimit(ctx); err != nil {
+		ci.launchMu.Unlock()
+		return nil, err
+	}
+
+	err = ci.updateStartCampaignStatus(ctx, c)
+	ci.launchMu.Unlock()
 	if err != nil {
 		return nil, err
 	}
@@ -57,16 +86,21 @@ func (ci *CampaignInteractor) Start(ctx context.Context, req dto.StartCampaignRe
 		return nil, err
 	}
 
-	if err := ci.submitStartJob(workerCtx, cancel, c, statuses); err != nil {
+	if err := ci.submitStartJob(workerCtx, cancel, c, pending); err != nil {
 		return nil, err
 	}
 
-	response := ci.buildStartResponse(c, statuses)
+	response := ci.buildStartResponse(c, pending, skipped+blocked+capped)
+	response.BlockedNumbers = blocked
+	response.CappedNumbers = capped
 
 	ci.logger.Info("Campaign started successfully", map[string]interface{}{
-		"campaignID":   c.ID(),
-		"status":       string(c.Status()),
-		"totalNumbers": len(statuses),
+		"campaignID":     c.ID(),
+		"status":         string(c.Status()),
+		"totalNumbers":   len(pending),
+		"skippedNumbers": skipped,
+		"blockedNumbers": blocked,
+		"cappedNumbers":  capped,
 	})
 
 	return response, nil
@@ -97,7 +131,7 @@ func (ci *CampaignInteractor) getStartCampaign(ctx context.Context, campaignID s
 }
 
 // validateStart проверяет возможность запуска кампании
-func (ci *CampaignInteractor) validateStart(c *campaign.Campaign) error {
+func (ci *CampaignInteractor) validateStart(c *campaign.Campaign, statuses, pending []*campaign.CampaignPhoneStatus) error {
 	if !c.CanBeStarted() {
 		ci.logger.Warn("Campaign cannot be started", map[string]interface{}{
 			"campaignID": c.ID(),
@@ -106,15 +140,6 @@ func (ci *CampaignInteractor) validateStart(c *campaign.Campaign) error {
 		return fmt.Errorf("%w: current status is %s", ErrCannotBeStarted, c.Status())
 	}
 
-	statuses, err := ci.campaignRepo.ListPhoneStatusesByCampaignID(context.Background(), c.ID())
-	if err != nil {
-		ci.logger.Error("Failed to check phone numbers for campaign", map[string]interface{}{
-			"error":      err.Error(),
-			"campaignID": c.ID(),
-		})
-		return fmt.Errorf("%w: %s", ErrGetStatuses, err.Error())
-	}
-
 	if len(statuses) == 0 {
 		ci.logger.Warn("No phone numbers found for campaign", map[string]interface{}{
 			"campaignID": c.ID(),
@@ -123,6 +148,36 @@ func (ci *CampaignInteractor) validateStart(c *campaign.Campaign) error {
 		return fmt.Errorf("campaign cannot be started: no phone numbers found for campaign %s", c.ID())
 	}
 
+	if len(pending) == 0 {
+		ci.logger.Warn("No pending phone numbers left for campaign", map[string]interface{}{
+			"campaignID": c.ID(),
+			"total":      len(statuses),
+		})
+		return fmt.Errorf("%w: campaign %s", ErrNoPendingNumbers, c.ID())
+	}
+
+	return nil
+}
+
+// checkConcurrencyLimit проверяет, что количество одновременно отправляемых кампаний не превышает лимит.
+// Должен вызываться под ci.launchMu вместе с переводом кампании в статус "started"
+func (ci *CampaignInteractor) checkConcurrencyLimit(ctx context.Context) error {
+	running, err := ci.campaignRepo.CountByStatus(ctx, string(campaign.CampaignStatusStarted))
+	if err != nil {
+		ci.logger.Error("Failed to count running campaigns", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return fmt.Errorf("failed to count running campaigns: %w", err)
+	}
+
+	if running >= ci.options.MaxConcurrentCampaigns {
+		ci.logger.Warn("Concurrent campaigns limit reached", map[string]interface{}{
+			"running": running,
+			"limit":   ci.options.MaxConcurrentCampaigns,
+		})
+		return fmt.Errorf("%w: %d of %d", ErrConcurrencyLimitReached, running, ci.options.MaxConcurrentCampaigns)
+	}
+
 	return nil
 }
 
@@ -162,7 +217,106 @@ func (ci *CampaignInteractor) getStartCampaignStatuses(ctx context.Context, camp
 	return statuses, nil
 }
 
-// registerStartCampaign регистрирует кампанию в registry
+// splitPendingStatuses отделяет номера, ожидающие отправки, от уже обработанных (sent, failed, cancelled)
+func splitPendingStatuses(statuses []*campaign.CampaignPhoneStatus) ([]*campaign.CampaignPhoneStatus, int) {
+	pending := make([]*campaign.CampaignPhoneStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if status.Status() == campaign.CampaignStatusTypePending {
+			pending = append(pending, status)
+		}
+	}
+	return pending, len(statuses) - len(pending)
+}
+
+// cancelOptedOutStatuses отменяет ожидающие номера, находящиеся в стоп-листе.
+// Возвращает оставшиеся для отправки номера и количество отмененных
+func (ci *CampaignInteractor) cancelOptedOutStatuses(ctx context.Context, campaignID string, pending []*campaign.CampaignPhoneStatus) ([]*campaign.CampaignPhoneStatus, int, error) {
+	optedOut, err := filterInBatches(statusPhoneNumbers(pending), func(batch []string) ([]string, error) {
+		return ci.optOutRepo.FilterOptedOut(ctx, batch)
+	})
+	if err != nil {
+		ci.logger.Error("Failed to check opt-out list", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, 0, fmt.Errorf("%w: %s", ErrCheckOptOuts, err.Error())
+	}
+
+	return ci.cancelPendingStatuses(ctx, campaignID, pending, optedOut, campaign.OptOutErrorMessage)
+}
+
+// cancelFrequencyCappedStatuses отменяет ожидающие номера, которым за окно лимита частоты
+// уже отправлено максимальное количество сообщений
+func (ci *CampaignInteractor) cancelFrequencyCappedStatuses(ctx context.Context, campaignID string, pending []*campaign.CampaignPhoneStatus) ([]*campaign.CampaignPhoneStatus, int, error) {
+	if ci.frequencyCap == nil {
+		return pending, 0, nil
+	}
+
+	capped, err := ci.filterFrequencyCapped(ctx, statusPhoneNumbers(pending))
+	if err != nil {
+		ci.logger.Error("Failed to check frequency cap", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": campaignID,
+		})
+		return nil, 0, fmt.Errorf("%w: %s", ErrCheckFrequencyCap, err.Error())
+	}
+
+	return ci.cancelPendingStatuses(ctx, campaignID, pending, capped, ci.frequencyCap.ErrorMessage())
+}
+
+// cancelPendingStatuses отменяет с причиной reason ожидающие номера из phoneNumbers.
+// Возвращает оставшиеся для отправки номера и количество отмененных
+func (ci *CampaignInteractor) cancelPendingStatuses(ctx context.Context, campaignID string, pending []*campaign.CampaignPhoneStatus, phoneNumbers []string, reason string) ([]*campaign.CampaignPhoneStatus, int, error) {
+	if len(phoneNumbers) == 0 {
+		return pending, 0, nil
+	}
+
+	excluded := make(map[string]struct{}, len(phoneNumbers))
+	for _, phone := range phoneNumbers {
+		excluded[phone] = struct{}{}
+	}
+
+	remaining := make([]*campaign.CampaignPhoneStatus, 0, len(pending))
+	cancelled := 0
+	for _, status := range pending {
+		if _, exists := excluded[status.PhoneNumber()]; !exists {
+			remaining = append(remaining, status)
+			continue
+		}
+
+		if err := ci.campaignRepo.UpdatePhoneStatusByNumber(ctx, campaignID, status.PhoneNumber(),
+			campaign.CampaignStatusTypeCancelled, reason); err != nil {
+			ci.logger.Error("Failed to cancel excluded number", map[string]interface{}{
+				"error":       err.Error(),
+				"campaignID":  campaignID,
+				"phoneNumber": status.PhoneNumber(),
+			})
+			return nil, 0, fmt.Errorf("%w: %s", ErrCancelExcluded, err.Error())
+		}
+		cancelled++
+	}
+
+	ci.logger.Info("Excluded numbers cancelled before start", map[string]interface{}{
+		"campaignID":       campaignID,
+		"cancelledNumbers": cancelled,
+		"reason":           reason,
+	})
+
+	return remaining, cancelled, nil
+}
+
+// statusPhoneNumbers возвращает номера телефонов статусов
+func statusPhoneNumbers(statuses []*campaign.CampaignPhoneStatus) []string {
+	phones := make([]string, len(statuses))
+	for i, status := range statuses {
+		phones[i] = status.PhoneNumber()
+	}
+	return phones
+}
+
+// registerStartCampaign регистрирует кампанию в registry.
+// Если кампания уже зарегистрирована (еще отправляется или завершает обработку результатов),
+// возвращает campaign.ErrCampaignAlreadyRunning
 func (ci *CampaignInteractor) registerStartCampaign(campaignID string) (context.Context, context.CancelFunc, error) {
 	workerCtx, cancel := context.WithCancel(context.Background())
 
@@ -172,6 +326,9 @@ func (ci *CampaignInteractor) registerStartCampaign(campaignID string) (context.
 			"campaignID": campaignID,
 		})
 		cancel()
+		if errors.Is(err, ports.ErrCampaignAlreadyActive) {
+			return nil, nil, fmt.Errorf("%w: %s", campaign.ErrCampaignAlreadyRunning, campaignID)
+		}
 		return nil, nil, fmt.Errorf("%w: %s", ErrRegistryRegister, err.Error())
 	}
 
@@ -180,14 +337,13 @@ func (ci *CampaignInteractor) registerStartCampaign(campaignID string) (context.
 
 // submitStartJob подготавливает и отправляет задание в диспетчер
 func (ci *CampaignInteractor) submitStartJob(workerCtx context.Context, cancel context.CancelFunc, c *campaign.Campaign, statuses []*campaign.CampaignPhoneStatus) error {
-	mediaInfo := ci.prepareStartMediaInfo(c)
-
-	messages := ci.prepareStartMessages(c, statuses, mediaInfo)
+	messages := ci.prepareStartMessages(c, statuses)
 
 	job := &infraDTO.DispatcherJob{
 		CampaignID:      c.ID(),
 		MessagesPerHour: c.MessagesPerHour(),
 		Messages:        messages,
+		SendingWindow:   c.SendingWindow(),
 	}
 
 	resultsCh, err := ci.dispatcher.Submit(workerCtx, job)
@@ -208,12 +364,20 @@ func (ci *CampaignInteractor) submitStartJob(workerCtx context.Context, cancel c
 }
 
 // prepareStartMediaInfo подготавливает медиа-информацию для сообщений
-func (ci *CampaignInteractor) prepareStartMediaInfo(c *campaign.Campaign) *infraDTO.MediaInfo {
-	if c.Media() == nil {
+func (ci *CampaignInteractor) prepareStartMediaInfo(media *campaign.Media) *infraDTO.MediaInfo {
+	if media == nil {
 		return nil
 	}
 
-	media := c.Media()
+	// Медиафайл из хранилища читается только при отправке первого сообщения с ним
+	if media.Data() == nil && media.StoragePath() != "" {
+		storagePath, checksumMD5 := media.StoragePath(), media.ChecksumMD5()
+		return infraDTO.NewLazyMediaInfo(media.Filename(), media.MimeType(), media.MessageType(),
+			func(ctx context.Context) ([]byte, error) {
+				return ci.loadStoredMedia(ctx, storagePath, checksumMD5)
+			})
+	}
+
 	return &infraDTO.MediaInfo{
 		Data:        media.Data(),
 		Filename:    media.Filename(),
@@ -222,14 +386,26 @@ func (ci *CampaignInteractor) prepareStartMediaInfo(c *campaign.Campaign) *infra
 	}
 }
 
-// prepareStartMessages подготавливает сообщения для отправки
-func (ci *CampaignInteractor) prepareStartMessages(c *campaign.Campaign, statuses []*campaign.CampaignPhoneStatus, mediaInfo *infraDTO.MediaInfo) []infraDTO.Message {
+// prepareStartMessages подготавливает сообщения для отправки с учетом варианта сообщения получателя.
+// Номера, которые уже были обработаны, пропускаются, чтобы повторный запуск не дублировал рассылку
+func (ci *CampaignInteractor) prepareStartMessages(c *campaign.Campaign, statuses []*campaign.CampaignPhoneStatus) []infraDTO.Message {
 	messages := make([]infraDTO.Message, 0, len(statuses))
+	mediaByVariant := make(map[string]*infraDTO.MediaInfo)
 
 	for _, status := range statuses {
+		if status.Status() != campaign.CampaignStatusTypePending {
+			continue
+		}
+
+		mediaInfo, exists := mediaByVariant[status.Variant()]
+		if !exists {
+			mediaInfo = ci.prepareStartMediaInfo(c.MediaFor(status.Variant()))
+			mediaByVariant[status.Variant()] = mediaInfo
+		}
+
 		messages = append(messages, infraDTO.Message{
 			PhoneNumber: status.PhoneNumber(),
-			Text:        c.Message(),
+			Text:        campaign.RenderTemplate(c.MessageFor(status.Variant()), status.Variables()),
 			Media:       mediaInfo,
 		})
 	}
@@ -238,22 +414,36 @@ func (ci *CampaignInteractor) prepareStartMessages(c *campaign.Campaign, statuse
 }
 
 // buildStartResponse строит ответ на запуск кампании
-func (ci *CampaignInteractor) buildStartResponse(c *campaign.Campaign, statuses []*campaign.CampaignPhoneStatus) *dto.StartCampaignResponse {
-	estimatedTime := "unknown"
-	if c.MessagesPerHour() > 0 && len(statuses) > 0 {
-		hoursToComplete := float64(len(statuses)) / float64(c.MessagesPerHour())
-		estimatedTime = fmt.Sprintf("%.1f hours", hoursToComplete)
-	}
-
+func (ci *CampaignInteractor) buildStartResponse(c *campaign.Campaign, pending []*campaign.CampaignPhoneStatus, skipped int) *dto.StartCampaignResponse {
 	return &dto.StartCampaignResponse{
 		CampaignID:          c.ID(),
 		Status:              c.Status(),
-		TotalNumbers:        len(statuses),
-		EstimatedCompletion: estimatedTime,
+		TotalNumbers:        len(pending),
+		SkippedNumbers:      skipped,
+		EstimatedCompletion: estimateCompletion(c, len(pending)),
 		WorkerStarted:       true,
 	}
 }
 
+// estimateCompletion оценивает время отправки заданного количества сообщений кампании.
+// Если у кампании задано окно отправки, время вне окна добавляется к оценке
+func estimateCompletion(c *campaign.Campaign, messagesCount int) string {
+	messagesPerHour := c.MessagesPerHour()
+	if messagesPerHour <= 0 || messagesCount <= 0 {
+		return "unknown"
+	}
+
+	hoursToComplete := float64(messagesCount) / float64(messagesPerHour)
+
+	if window := c.SendingWindow(); window != nil {
+		now := time.Now()
+		sendingDuration := time.Duration(hoursToComplete * float64(time.Hour))
+		hoursToComplete = window.CompletionTime(now, sendingDuration).Sub(now).Hours()
+	}
+
+	return fmt.Sprintf("%.1f hours", hoursToComplete)
+}
+
 // processStartResults обрабатывает результаты отправки от диспетчера
 func (ci *CampaignInteractor) processStartResults(ctx context.Context, campaignID string, resultsCh <-chan *infraDTO.MessageSendResult) {
 	defer ci.registry.Unregister(campaignID)
@@ -291,21 +481,32 @@ func (ci *CampaignInteractor) processStartMessageResult(campaignID string, resul
 
 	var newStatus campaign.CampaignStatusType
 	var errMsg string
+	var sentAt *time.Time
+	counter := campaign.StatsCounterSent
 
 	if result.Success {
 		newStatus = campaign.CampaignStatusTypeSent
+		at := result.Timestamp
+		if at.IsZero() {
+			at = time.Now()
+		}
+		sentAt = &at
 	} else {
 		newStatus = campaign.CampaignStatusTypeFailed
 		errMsg = result.Error
+		counter = campaign.StatsCounterFailed
 	}
 
-	// Обновляем статус конкретного номера
-	err := ci.campaignRepo.UpdatePhoneStatusByNumber(
+	// Обновляем статус конкретного номера вместе с ID сообщения у провайдера,
+	// по которому затем сопоставляются подтверждения доставки
+	updated, err := ci.campaignRepo.UpdatePhoneSendResultByNumber(
 		ctx,
 		campaignID,
 		result.PhoneNumber,
 		newStatus,
 		errMsg,
+		result.MessageID,
+		sentAt,
 	)
 
 	if err != nil {
@@ -314,10 +515,36 @@ func (ci *CampaignInteractor) processStartMessageResult(campaignID string, resul
 			"campaignID":  campaignID,
 			"phoneNumber": result.PhoneNumber,
 			"success":     result.Success,
+			"messageID":   result.MessageID,
 		})
 		return
 	}
 
+	// Номер отменили во время отправки: результат уже не влияет на статистику и счетчики кампании,
+	// а время отправки и ID сообщения репозиторий сохранил у отмененного номера
+	if updated == 0 {
+		ci.logger.Info("Send result for cancelled number skipped", map[string]interface{}{
+			"campaignID":  campaignID,
+			"phoneNumber": result.PhoneNumber,
+			"success":     result.Success,
+		})
+		return
+	}
+
+	if result.Success && result.MessageID == "" {
+		ci.logger.Warn("Provider did not return message ID, delivery receipts cannot be matched", map[string]interface{}{
+			"campaignID":  campaignID,
+			"phoneNumber": result.PhoneNumber,
+		})
+	}
+
+	// Ошибка статистики не должна влиять на обработку результата, она уже залогирована в use case
+	_ = ci.statsUseCase.Record(ctx, dto.RecordStatsRequest{
+		CampaignID: campaignID,
+		Counter:    counter,
+		At:         result.Timestamp,
+	})
+
 	// Инкрементируем счетчик обработанных сообщений в БД
 	err = ci.campaignRepo.IncrementProcessedCount(ctx, campaignID)
 	if err != nil {
@@ -356,6 +583,7 @@ func (ci *CampaignInteractor) finalizeStartCampaignStatus(campaignID string, was
 	}
 
 	// Получаем статистику обработанных сообщений
+	pendingCount := 0
 	statuses, err := ci.campaignRepo.ListPhoneStatusesByCampaignID(ctx, campaignID)
 	if err != nil {
 		ci.logger.Error("Failed to get campaign statuses for final update", map[string]interface{}{
@@ -367,12 +595,15 @@ func (ci *CampaignInteractor) finalizeStartCampaignStatus(campaignID string, was
 		processedCount := 0
 		errorCount := 0
 		for _, status := range statuses {
-			if status.Status() == campaign.CampaignStatusTypeSent || status.Status() == campaign.CampaignStatusTypeFailed {
+			if status.IsSuccessful() || status.IsFailed() {
 				processedCount++
 				if status.Status() == campaign.CampaignStatusTypeFailed {
 					errorCount++
 				}
 			}
+			if status.Status() == campaign.CampaignStatusTypePending {
+				pendingCount++
+			}
 		}
 
 		// Обновляем метрики в entity
@@ -387,6 +618,14 @@ func (ci *CampaignInteractor) finalizeStartCampaignStatus(campaignID string, was
 				"campaignID": campaignID,
 			})
 		}
+	} else if pendingCount > 0 && c.Status() == campaign.CampaignStatusStarted {
+		// Диспетчер закрыл канал до отправки всех номеров (остановка сервиса) — кампанию можно будет возобновить
+		if err := c.Interrupt(); err != nil {
+			ci.logger.Error("Failed to transition campaign to interrupted state", map[string]interface{}{
+				"error":      err.Error(),
+				"campaignID": campaignID,
+			})
+		}
 	} else {
 		c.Finish()
 	}
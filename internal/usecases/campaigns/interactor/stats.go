@@ -0,0 +1,177 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"whatsapp-service/internal/entities/campaign"
+	"whatsapp-service/internal/entities/campaign/repository"
+	"whatsapp-service/internal/interfaces"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// Константы для статистики кампаний
+const (
+	DefaultStatsPeriodDays = 30  // Период статистики по умолчанию
+	MaxStatsPeriodDays     = 366 // Максимальный период статистики за один запрос
+	statsDateLayout        = "2006-01-02"
+)
+
+// Кастомные ошибки для статистики кампаний
+var (
+	ErrStatsCampaignIDRequired = fmt.Errorf("campaign ID is required")
+	ErrStatsCampaignNotFound   = fmt.Errorf("campaign not found")
+	ErrInvalidStatsPeriod      = fmt.Errorf("stats period start must not be after its end")
+	ErrStatsPeriodTooLong      = fmt.Errorf("stats period too long: maximum %d days", MaxStatsPeriodDays)
+	ErrRecordStats             = fmt.Errorf("failed to record campaign stats")
+	ErrGetStats                = fmt.Errorf("failed to get campaign stats")
+)
+
+// CampaignStatsInteractor ведет дневную статистику отправки кампаний
+type CampaignStatsInteractor struct {
+	campaignRepo repository.CampaignRepository
+	statsRepo    repository.CampaignStatsRepository
+	logger       interfaces.Logger
+}
+
+// NewCampaignStatsInteractor создает новый экземпляр use case статистики
+func NewCampaignStatsInteractor(campaignRepo repository.CampaignRepository, statsRepo repository.CampaignStatsRepository, logger interfaces.Logger) *CampaignStatsInteractor {
+	return &CampaignStatsInteractor{
+		campaignRepo: campaignRepo,
+		statsRepo:    statsRepo,
+		logger:       logger,
+	}
+}
+
+// Record учитывает результат отправки в дневной статистике кампании
+func (si *CampaignStatsInteractor) Record(ctx context.Context, req dto.RecordStatsRequest) error {
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	if err := si.statsRepo.Increment(ctx, req.CampaignID, at, req.Counter); err != nil {
+		si.logger.Error("Failed to record campaign stats", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": req.CampaignID,
+			"counter":    string(req.Counter),
+		})
+		return fmt.Errorf("%w: %s", ErrRecordStats, err.Error())
+	}
+
+	return nil
+}
+
+// GetStats возвращает дневную статистику кампании за период.
+// Дни без отправок возвращаются с нулевыми значениями, чтобы ряд можно было сразу выводить на графике
+func (si *CampaignStatsInteractor) GetStats(ctx context.Context, req dto.GetCampaignStatsRequest) (*dto.GetCampaignStatsResponse, error) {
+	from, to, err := si.validateStatsRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Для несуществующей кампании возвращается ошибка, а не ряд из нулей
+	if _, err := si.campaignRepo.GetByID(ctx, req.CampaignID); err != nil {
+		si.logger.Error("Failed to get campaign", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": req.CampaignID,
+		})
+		return nil, fmt.Errorf("%w: %w", ErrStatsCampaignNotFound, err)
+	}
+
+	days, err := si.statsRepo.ListByPeriod(ctx, req.CampaignID, from, to)
+	if err != nil {
+		si.logger.Error("Failed to get campaign stats", map[string]interface{}{
+			"error":      err.Error(),
+			"campaignID": req.CampaignID,
+		})
+		return nil, fmt.Errorf("%w: %s", ErrGetStats, err.Error())
+	}
+
+	return si.buildStatsResponse(req.CampaignID, from, to, days), nil
+}
+
+// validateStatsRequest проверяет запрос и возвращает период с примененными значениями по умолчанию
+func (si *CampaignStatsInteractor) validateStatsRequest(req dto.GetCampaignStatsRequest) (time.Time, time.Time, error) {
+	if req.CampaignID == "" {
+		return time.Time{}, time.Time{}, ErrStatsCampaignIDRequired
+	}
+
+	to := req.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	to = truncateToDate(to)
+
+	from := req.From
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(DefaultStatsPeriodDays - 1))
+	}
+	from = truncateToDate(from)
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, ErrInvalidStatsPeriod
+	}
+	if to.Sub(from) >= MaxStatsPeriodDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrStatsPeriodTooLong
+	}
+
+	return from, to, nil
+}
+
+// buildStatsResponse строит непрерывный ряд дневной статистики и итог за период
+func (si *CampaignStatsInteractor) buildStatsResponse(campaignID string, from, to time.Time, days []*campaign.DailyStats) *dto.GetCampaignStatsResponse {
+	byDate := make(map[string]*campaign.DailyStats, len(days))
+	for _, day := range days {
+		byDate[day.Date.Format(statsDateLayout)] = day
+	}
+
+	response := &dto.GetCampaignStatsResponse{
+		CampaignID: campaignID,
+		From:       from.Format(statsDateLayout),
+		To:         to.Format(statsDateLayout),
+		Days:       make([]dto.DailyStats, 0, int(to.Sub(from).Hours()/24)+1),
+	}
+
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		key := date.Format(statsDateLayout)
+		item := dto.DailyStats{Date: key}
+
+		if day, exists := byDate[key]; exists {
+			item.Sent = day.Sent
+			item.Delivered = day.Delivered
+			item.Read = day.Read
+			item.Failed = day.Failed
+			item.DeliveryRate = day.DeliveryRate
+			item.ReadRate = day.ReadRate
+		}
+
+		response.Total.Sent += item.Sent
+		response.Total.Delivered += item.Delivered
+		response.Total.Read += item.Read
+		response.Total.Failed += item.Failed
+		response.Days = append(response.Days, item)
+	}
+
+	response.Total.DeliveryRate = percentOf(response.Total.Delivered, response.Total.Sent)
+	response.Total.ReadRate = percentOf(response.Total.Read, response.Total.Sent)
+
+	return response
+}
+
+// truncateToDate отбрасывает время, оставляя календарную дату в UTC
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// percentOf возвращает долю part от total в процентах с точностью до сотых (не более 100)
+func percentOf(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	percent := float64(int(float64(part)*10000/float64(total)+0.5)) / 100
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
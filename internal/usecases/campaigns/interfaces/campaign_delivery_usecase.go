@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// CampaignDeliveryUseCase обрабатывает подтверждения доставки и прочтения сообщений кампаний
+type CampaignDeliveryUseCase interface {
+	// ProcessReceipts переводит номера кампаний в статусы delivered/read по подтверждениям провайдера
+	ProcessReceipts(ctx context.Context, req dto.ProcessDeliveryReceiptsRequest) (*dto.ProcessDeliveryReceiptsResponse, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// CampaignStatsUseCase объединяет операции с дневной статистикой кампаний
+type CampaignStatsUseCase interface {
+	// Record учитывает результат отправки в дневной статистике кампании
+	Record(ctx context.Context, req dto.RecordStatsRequest) error
+
+	// GetStats возвращает дневную статистику кампании за период
+	GetStats(ctx context.Context, req dto.GetCampaignStatsRequest) (*dto.GetCampaignStatsResponse, error)
+}
@@ -2,7 +2,9 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"whatsapp-service/internal/usecases/campaigns/dto"
+	infraDTO "whatsapp-service/internal/usecases/dto"
 )
 
 // CampaignUseCase объединяет все операции с кампаниями
@@ -10,15 +12,42 @@ type CampaignUseCase interface {
 	// Create создает новую кампанию
 	Create(ctx context.Context, req dto.CreateCampaignRequest) (*dto.CreateCampaignResponse, error)
 
+	// PreviewPhoneFile анализирует файл с номерами: листы, колонки, предлагаемую колонку и первые строки
+	PreviewPhoneFile(ctx context.Context, req dto.PreviewPhoneFileRequest) (*infraDTO.FileAnalysis, error)
+
+	// PreviewAudience рассчитывает итоговую аудиторию кампании без ее создания
+	PreviewAudience(ctx context.Context, req dto.PreviewAudienceRequest) (*dto.PreviewAudienceResponse, error)
+
 	// Start запускает существующую кампанию
 	Start(ctx context.Context, req dto.StartCampaignRequest) (*dto.StartCampaignResponse, error)
 
+	// StartDueCampaigns возобновляет прерванные кампании и запускает запланированные, время запуска которых наступило
+	StartDueCampaigns(ctx context.Context, req dto.StartDueCampaignsRequest) (*dto.StartDueCampaignsResponse, error)
+
+	// Pause приостанавливает запущенную кампанию
+	Pause(ctx context.Context, req dto.PauseCampaignRequest) (*dto.PauseCampaignResponse, error)
+
+	// Resume возобновляет приостановленную или прерванную кампанию, отправляя только необработанные номера
+	Resume(ctx context.Context, req dto.ResumeCampaignRequest) (*dto.ResumeCampaignResponse, error)
+
+	// RetryFailed повторно отправляет сообщения на неудачные номера завершенной кампании
+	RetryFailed(ctx context.Context, req dto.RetryFailedRequest) (*dto.RetryFailedResponse, error)
+
 	// Cancel отменяет выполнение кампании
 	Cancel(ctx context.Context, req dto.CancelCampaignRequest) (*dto.CancelCampaignResponse, error)
 
 	// GetByID получает информацию о кампании по ID
 	GetByID(ctx context.Context, req dto.GetCampaignByIDRequest) (*dto.GetCampaignByIDResponse, error)
 
+	// ListRecipients возвращает страницу номеров кампании с фильтром по статусу и поиском по номеру
+	ListRecipients(ctx context.Context, req dto.ListRecipientsRequest) (*dto.ListRecipientsResponse, error)
+
+	// GetErrors возвращает ошибки отправки кампании, сгруппированные по причине, и страницу неудачных номеров
+	GetErrors(ctx context.Context, req dto.GetCampaignErrorsRequest) (*dto.GetCampaignErrorsResponse, error)
+
+	// Export потоково выгружает номера кампании с результатами отправки в файл формата req.Format
+	Export(ctx context.Context, req dto.ExportCampaignRequest, w io.Writer) (*dto.ExportCampaignResponse, error)
+
 	// List получает список всех кампаний с возможностью фильтрации и пагинации
 	List(ctx context.Context, req dto.ListCampaignsRequest) (*dto.ListCampaignsResponse, error)
 }
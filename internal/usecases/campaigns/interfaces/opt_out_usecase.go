@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"whatsapp-service/internal/usecases/campaigns/dto"
+)
+
+// OptOutUseCase объединяет операции с глобальным стоп-листом номеров
+type OptOutUseCase interface {
+	// Add добавляет номер в стоп-лист
+	Add(ctx context.Context, req dto.AddOptOutRequest) (*dto.AddOptOutResponse, error)
+
+	// Remove удаляет номер из стоп-листа
+	Remove(ctx context.Context, req dto.RemoveOptOutRequest) error
+
+	// List возвращает страницу стоп-листа
+	List(ctx context.Context, req dto.ListOptOutsRequest) (*dto.ListOptOutsResponse, error)
+
+	// Import загружает номера в стоп-лист из файла и/или списка
+	Import(ctx context.Context, req dto.ImportOptOutsRequest) (*dto.ImportOptOutsResponse, error)
+
+	// ProcessInbound отписывает получателей, ответивших стоп-словом
+	ProcessInbound(ctx context.Context, req dto.ProcessInboundMessagesRequest) (*dto.ProcessInboundMessagesResponse, error)
+}
@@ -1,11 +1,17 @@
 package ports
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrCampaignAlreadyActive возвращается Register, если кампания уже зарегистрирована
+var ErrCampaignAlreadyActive = errors.New("campaign is already active")
 
 // CampaignRegistry отслеживает активные (запущенные) кампании.
 // Это позволяет централизованно управлять их жизненным циклом (запуск, отмена).
 type CampaignRegistry interface {
-	// Register добавляет кампанию в реестр. Возвращает ошибку, если кампания уже зарегистрирована.
+	// Register добавляет кампанию в реестр. Возвращает ErrCampaignAlreadyActive, если кампания уже зарегистрирована.
 	Register(campaignID string, cancelFunc context.CancelFunc) error
 	// Unregister удаляет кампанию из реестра. Вызывается, когда кампания завершается.
 	Unregister(campaignID string)
@@ -21,6 +21,18 @@ type Dispatcher interface {
 	// Этот канал будет закрыт диспетчером, когда все сообщения из задания будут обработаны.
 	Submit(ctx context.Context, job *dto.DispatcherJob) (<-chan *dto.MessageSendResult, error)
 
+	// Pause откладывает очередь кампании, не теряя позицию в очереди и состояние лимитера.
+	// Возвращает ошибку, если кампании нет в диспетчере.
+	Pause(campaignID string) error
+
+	// Resume возвращает отложенную очередь кампании в обработку.
+	// Возвращает ошибку, если кампании нет в диспетчере.
+	Resume(campaignID string) error
+
+	// DropRecipient удаляет из очередей всех кампаний еще не отправленные сообщения на номер.
+	// Возвращает количество удаленных сообщений.
+	DropRecipient(phoneNumber string) int
+
 	// Start запускает фоновый процесс диспетчера. Должен быть вызван один раз при старте приложения.
 	Start(ctx context.Context)
 
@@ -13,4 +13,18 @@ type FileParser interface {
 
 	// ParsePhoneNumbersDetailed детальный парсинг с полной статистикой и обработкой ошибок
 	ParsePhoneNumbersDetailed(content io.Reader, columnName string) (*dto.ParseResult, error)
+
+	// ParsePhoneNumbersWithOptions детальный парсинг с выбором листа и колонки
+	ParsePhoneNumbersWithOptions(content io.Reader, options dto.ParseOptions) (*dto.ParseResult, error)
+
+	// Analyze возвращает листы, колонки, предлагаемую колонку с номерами и первые строки листа sheet
+	// (пусто = первый лист) без полного парсинга
+	Analyze(content io.Reader, sheet string) (*dto.FileAnalysis, error)
+}
+
+// FileParserSelector выбирает парсер для файла с номерами телефонов
+type FileParserSelector interface {
+	// ForFile возвращает парсер по расширению файла, а при неизвестном расширении — по MIME-типу.
+	// Возвращает campaign.ErrUnsupportedPhoneFile, если формат не поддерживается
+	ForFile(filename, contentType string) (FileParser, error)
 }
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+// MediaStorage хранит содержимое медиафайлов кампаний вне БД.
+// Ключ — путь файла внутри хранилища, сохраняется в media_files.storage_path
+type MediaStorage interface {
+	// Put сохраняет содержимое файла под ключом key
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get загружает содержимое файла по ключу
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete удаляет файл. Отсутствие файла не считается ошибкой
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,27 @@
+package ports
+
+import "io"
+
+// ExportFormat формат выгрузки результатов кампании
+type ExportFormat string
+
+const (
+	ExportFormatXLSX ExportFormat = "xlsx"
+	ExportFormatCSV  ExportFormat = "csv"
+)
+
+// ResultsWriter построчно записывает таблицу результатов в файл выгрузки
+type ResultsWriter interface {
+	// WriteRow записывает очередную строку таблицы
+	WriteRow(values []string) error
+	// Close дописывает файл в выходной поток. Должен быть вызван после последней строки
+	Close() error
+	// Abort освобождает ресурсы без дописывания файла (при ошибке выгрузки)
+	Abort()
+}
+
+// ResultsExporter создает потоковые writer'ы для выгрузки результатов кампаний
+type ResultsExporter interface {
+	// NewWriter открывает запись таблицы в формате format в поток w
+	NewWriter(w io.Writer, format ExportFormat) (ResultsWriter, error)
+}
@@ -1,8 +1,11 @@
 package dto
 
+import "whatsapp-service/internal/entities/campaign"
+
 // DispatcherJob представляет задание (список сообщений) для диспетчера.
 type DispatcherJob struct {
 	CampaignID      string
 	MessagesPerHour int
 	Messages        []Message
+	SendingWindow   *campaign.SendingWindow // Разрешенное окно отправки (nil = без ограничений)
 }
@@ -1,15 +1,53 @@
 package dto
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"whatsapp-service/internal/entities/campaign"
 )
 
 // MediaInfo содержит всю информацию, необходимую для отправки медиа-сообщения.
+// Если Data пустое, содержимое загружается функцией load при первой отправке и
+// переиспользуется для остальных сообщений с этим медиа. Неудачная загрузка повторяется
+// при следующей отправке
 type MediaInfo struct {
 	Data        []byte
 	Filename    string
 	MimeType    string
 	MessageType campaign.MessageType
+
+	load   func(ctx context.Context) ([]byte, error)
+	loadMu sync.Mutex
+}
+
+// NewLazyMediaInfo создает медиа-информацию, содержимое которой загружается из хранилища при отправке
+func NewLazyMediaInfo(filename, mimeType string, messageType campaign.MessageType, load func(ctx context.Context) ([]byte, error)) *MediaInfo {
+	return &MediaInfo{
+		Filename:    filename,
+		MimeType:    mimeType,
+		MessageType: messageType,
+		load:        load,
+	}
+}
+
+// Content возвращает содержимое файла, загружая его при первом обращении
+func (m *MediaInfo) Content(ctx context.Context) ([]byte, error) {
+	if m.load == nil {
+		return m.Data, nil
+	}
+
+	m.loadMu.Lock()
+	defer m.loadMu.Unlock()
+
+	if m.Data == nil {
+		data, err := m.load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load media %s: %w", m.Filename, err)
+		}
+		m.Data = data
+	}
+	return m.Data, nil
 }
 
 // Message представляет одно сообщение для отправки.
@@ -4,28 +4,51 @@ import (
 	"whatsapp-service/internal/entities/campaign"
 )
 
+// ParseOptions параметры парсинга файла с номерами
+type ParseOptions struct {
+	Sheet     string // Лист Excel (пусто = первый лист)
+	AllSheets bool   // Читать все листы Excel, пропуская листы без колонки с номерами
+	Column    string // Колонка с номерами (пусто = определить по заголовку)
+	MaxRows   int    // Максимум строк с данными во всех листах (0 = без ограничения)
+}
+
 // ParseResult детальный результат парсинга файла
 type ParseResult struct {
-	ValidPhones     []campaign.PhoneNumber // Валидные уникальные номера
-	InvalidPhones   []InvalidPhone         // Невалидные номера с деталями
-	DuplicatePhones []DuplicatePhone       // Дубликаты с информацией
-	Statistics      ParseStatistics        // Статистика парсинга
-	Warnings        []string               // Предупреждения
+	ValidPhones     []campaign.PhoneNumber       // Валидные уникальные номера
+	InvalidPhones   []InvalidPhone               // Невалидные номера с деталями (первые, общее количество в Statistics)
+	CorrectedPhones []CorrectedPhone             // Номера, автоматически приведенные к международному формату (первые)
+	DuplicatePhones []DuplicatePhone             // Дубликаты с информацией (первые)
+	Statistics      ParseStatistics              // Статистика парсинга
+	Warnings        []string                     // Предупреждения
+	Columns         []string                     // Нормализованные имена дополнительных колонок (кроме колонки с номером)
+	Variables       map[string]map[string]string // Значения дополнительных колонок по номеру телефона
 }
 
 // InvalidPhone информация о невалидном номере
 type InvalidPhone struct {
 	RawValue string // Исходное значение
+	Sheet    string // Лист Excel (пусто для CSV)
 	Row      int    // Номер строки в файле
 	Reason   string // Причина невалидности
 }
 
+// CorrectedPhone информация об автоматически исправленном номере
+type CorrectedPhone struct {
+	PhoneNumber campaign.PhoneNumber       // Нормализованный номер
+	RawValue    string                     // Исходное значение
+	Sheet       string                     // Лист Excel (пусто для CSV)
+	Row         int                        // Номер строки в файле
+	Corrections []campaign.PhoneCorrection // Примененные исправления
+}
+
 // DuplicatePhone информация о дубликате
 type DuplicatePhone struct {
-	PhoneNumber campaign.PhoneNumber // Номер телефона
-	RawValue    string               // Исходное значение
-	Row         int                  // Номер строки в файле
-	FirstSeenAt int                  // Строка где впервые встречен
+	PhoneNumber    campaign.PhoneNumber // Номер телефона
+	RawValue       string               // Исходное значение
+	Sheet          string               // Лист Excel (пусто для CSV)
+	Row            int                  // Номер строки в файле
+	FirstSeenAt    int                  // Строка где впервые встречен
+	FirstSeenSheet string               // Лист, где впервые встречен
 }
 
 // ParseStatistics статистика парсинга
@@ -36,16 +59,20 @@ type ParseStatistics struct {
 	EmptyRows      int // Пустые строки
 	ValidCount     int // Количество валидных номеров
 	InvalidCount   int // Количество невалидных номеров
+	CorrectedCount int // Количество автоматически исправленных номеров
 	DuplicateCount int // Количество дубликатов
 	UniqueCount    int // Количество уникальных номеров
 }
 
 // FileAnalysis анализ файла перед парсингом
 type FileAnalysis struct {
-	Filename        string   // Имя файла
-	TotalRows       int      // Общее количество строк
-	Columns         []string // Найденные колонки
-	SuggestedColumn string   // Предлагаемая колонка с номерами
-	EstimatedPhones int      // Примерное количество номеров
-	Warnings        []string // Предупреждения
+	Filename        string     // Имя файла
+	Sheets          []string   // Листы файла (пусто для CSV)
+	Sheet           string     // Проанализированный лист (пусто для CSV)
+	TotalRows       int        // Общее количество строк
+	Columns         []string   // Найденные колонки
+	SuggestedColumn string     // Предлагаемая колонка с номерами
+	EstimatedPhones int        // Примерное количество номеров
+	SampleRows      [][]string // Первые строки с данными
+	Warnings        []string   // Предупреждения
 }
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_campaigns_status_scheduled_at;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS scheduled_at;
@@ -0,0 +1,2 @@
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_campaigns_status_scheduled_at ON campaigns(status, scheduled_at);
@@ -0,0 +1,4 @@
+ALTER TABLE campaigns DROP COLUMN IF EXISTS send_window_weekdays;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS send_window_timezone;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS send_window_end;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS send_window_start;
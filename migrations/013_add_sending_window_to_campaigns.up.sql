@@ -0,0 +1,4 @@
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS send_window_start TEXT;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS send_window_end TEXT;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS send_window_timezone TEXT;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS send_window_weekdays SMALLINT[];
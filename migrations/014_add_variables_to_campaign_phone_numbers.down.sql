@@ -0,0 +1 @@
+ALTER TABLE campaign_phone_numbers DROP COLUMN IF EXISTS variables;
@@ -0,0 +1 @@
+ALTER TABLE campaign_phone_numbers ADD COLUMN IF NOT EXISTS variables JSONB;
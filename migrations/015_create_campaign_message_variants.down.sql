@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_campaign_phone_numbers_campaign_variant;
+
+ALTER TABLE campaign_phone_numbers DROP COLUMN IF EXISTS variant;
+
+DROP TABLE IF EXISTS campaign_message_variants;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS campaign_message_variants (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    campaign_id UUID NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    message TEXT NOT NULL DEFAULT '', -- пустая строка = текст кампании
+    media_file_id UUID REFERENCES media_files(id) ON DELETE SET NULL, -- NULL = медиа кампании
+    percent INT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE (campaign_id, name)
+);
+
+ALTER TABLE campaign_phone_numbers ADD COLUMN IF NOT EXISTS variant TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_phone_numbers_campaign_variant
+    ON campaign_phone_numbers (campaign_id, variant);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_campaign_phone_numbers_whatsapp_message_id;
+
+ALTER TABLE campaigns DROP COLUMN IF EXISTS read_count;
+ALTER TABLE campaigns DROP COLUMN IF EXISTS delivered_count;
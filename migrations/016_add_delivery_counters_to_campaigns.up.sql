@@ -0,0 +1,6 @@
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS delivered_count INT NOT NULL DEFAULT 0;
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS read_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_campaign_phone_numbers_whatsapp_message_id
+    ON campaign_phone_numbers (whatsapp_message_id)
+    WHERE whatsapp_message_id IS NOT NULL;
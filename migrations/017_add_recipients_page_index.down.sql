@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_campaign_phone_numbers_campaign_created_id;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_campaign_phone_numbers_campaign_created_id
+    ON campaign_phone_numbers (campaign_id, created_at, id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS opt_outs (
+    phone_number TEXT PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT 'manual', -- manual, import, inbound
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_opt_outs_created_at ON opt_outs (created_at DESC);